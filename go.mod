@@ -9,6 +9,7 @@ require (
 	github.com/mark3labs/mcp-go v0.36.0
 	github.com/skanehira/clipboard-image v1.0.0
 	github.com/xuri/excelize/v2 v2.9.2-0.20250717000717-dd07139785fe
+	golang.org/x/image v0.25.0
 )
 
 require (
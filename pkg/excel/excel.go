@@ -15,6 +15,15 @@ type Excel interface {
 	CreateNewSheet(sheetName string) error
 	// CopySheet copies a sheet from one to another.
 	CopySheet(srcSheetName, destSheetName string) error
+	// CopySheetToWorkbook copies a sheet to a new sheet in another workbook opened by the same backend.
+	// Values, formulas, styles, column widths, row heights, merged cells and tables are copied; formulas are copied as is.
+	CopySheetToWorkbook(srcSheetName string, destWorkbook Excel, destSheetName string) error
+	// GetDefinedNames returns all defined names in the workbook, including sheet-scoped ones.
+	GetDefinedNames() ([]DefinedName, error)
+	// SetDefinedName creates a defined name, or updates it if it already exists in the same scope.
+	SetDefinedName(definedName DefinedName) error
+	// DeleteDefinedName deletes a defined name. An empty scope means the workbook scope.
+	DeleteDefinedName(name, scope string) error
 	// Save saves the Excel file.
 	Save() error
 }
@@ -28,14 +37,26 @@ type Worksheet interface {
 	GetTables() ([]Table, error)
 	// GetPivotTable returns a pivot tables in this worksheet.
 	GetPivotTables() ([]PivotTable, error)
+	// AddPivotTable adds a pivot table to this worksheet. The range of the pivot table is updated to the added one.
+	AddPivotTable(pivotTable *PivotTable) error
 	// SetValue sets a value in the specified cell.
 	SetValue(cell string, value any) error
 	// SetFormula sets a formula in the specified cell.
 	SetFormula(cell string, formula string) error
+	// SetRangeValues writes values to all cells in the range at once, which is much faster than writing cells one by one.
+	// values is indexed by [row][column] from the start of the range. A string starting with "=" is written as a formula.
+	SetRangeValues(rangeStr string, values [][]any) error
 	// GetValue gets the value from the specified cell.
 	GetValue(cell string) (string, error)
 	// GetFormula gets the formula from the specified cell.
 	GetFormula(cell string) (string, error)
+	// GetRawValue gets the value stored in the specified cell without its number format, and the kind of the value.
+	// Dates and times are stored as numbers (e.g. 45306 for 2024-01-15), and booleans are TRUE or FALSE.
+	GetRawValue(cell string) (string, CellValueKind, error)
+	// GetRangeValues reads values, formulas and style IDs of all cells in the range at once,
+	// which is much faster than reading cells one by one. The result is indexed by [row][column] from the start of the range.
+	// A formula which fails to calculate has its error value (e.g. #NAME?) instead of failing the whole range.
+	GetRangeValues(rangeStr string) ([][]CellData, error)
 	// GetDimention gets the dimension of the worksheet.
 	GetDimention() (string, error)
 	// GetPagingStrategy returns the paging strategy for the worksheet.
@@ -49,6 +70,63 @@ type Worksheet interface {
 	GetCellStyle(cell string) (*CellStyle, error)
 	// SetCellStyle sets style for the specified cell.
 	SetCellStyle(cell string, style *CellStyle) error
+	// GetDataValidations returns data validation rules in this worksheet.
+	GetDataValidations() ([]DataValidation, error)
+	// AddDataValidation adds a data validation rule to the range of the rule.
+	AddDataValidation(validation *DataValidation) error
+	// DeleteDataValidation removes data validation rules from the specified range.
+	DeleteDataValidation(rangeStr string) error
+	// GetConditionalFormats returns conditional formatting rules in this worksheet.
+	GetConditionalFormats() ([]ConditionalFormat, error)
+	// AddConditionalFormat adds a conditional formatting rule to the range of the rule.
+	AddConditionalFormat(format *ConditionalFormat) error
+	// GetComments returns comments (notes) in this worksheet.
+	GetComments() ([]Comment, error)
+	// SetComment adds a comment to the cell of the comment, or replaces the existing one.
+	SetComment(comment Comment) error
+	// DeleteComment deletes the comment in the specified cell.
+	DeleteComment(cell string) error
+	// GetHyperlinks returns hyperlinks in the specified range.
+	GetHyperlinks(rangeStr string) ([]Hyperlink, error)
+	// SetHyperlink sets a hyperlink to the cell of the hyperlink, replacing the existing one.
+	SetHyperlink(hyperlink Hyperlink) error
+	// DeleteHyperlink deletes the hyperlink in the specified cell.
+	DeleteHyperlink(cell string) error
+	// GetCharts returns charts in this worksheet.
+	GetCharts() ([]Chart, error)
+	// AddChart adds a chart to this worksheet.
+	AddChart(chart *Chart) error
+	// GetPictures returns pictures anchored in the specified range. An empty range means the whole worksheet.
+	GetPictures(rangeStr string) ([]Picture, error)
+	// AddPicture adds a picture at the cell of the picture.
+	AddPicture(picture *Picture) error
+	// ClearRange clears what the modes select in the specified range, and updates the dimension of the worksheet.
+	ClearRange(rangeStr string, modes []ClearMode) error
+	// CopyRange copies the range to the destination cell of the sheet in the same workbook.
+	CopyRange(rangeStr string, destSheetName string, destCell string, options CopyRangeOptions) error
+	// SortRange sorts rows of the range by the keys, moving styles with the rows. The header row, if any, is kept at the top.
+	SortRange(rangeStr string, keys []SortKey, hasHeader bool) error
+	// GetAutoFilter returns the AutoFilter of this worksheet, or nil if it has no AutoFilter.
+	GetAutoFilter() (*AutoFilter, error)
+	// SetAutoFilter sets the AutoFilter and hides rows which do not match it.
+	// A nil AutoFilter removes the AutoFilter and shows the rows hidden by it.
+	SetAutoFilter(autoFilter *AutoFilter) error
+	// IsRowHidden reports whether the row is hidden.
+	IsRowHidden(row int) (bool, error)
+	// IsColumnHidden reports whether the column is hidden.
+	IsColumnHidden(col int) (bool, error)
+	// SetRowsHidden hides or shows rows from startRow to endRow.
+	SetRowsHidden(startRow, endRow int, hidden bool) error
+	// SetColumnsHidden hides or shows columns from startCol to endCol.
+	SetColumnsHidden(startCol, endCol int, hidden bool) error
+	// GetRowOutlineLevel returns the outline (group) level of the row, from 0 (not grouped) to 7.
+	GetRowOutlineLevel(row int) (int, error)
+	// GetColumnOutlineLevel returns the outline (group) level of the column, from 0 (not grouped) to 7.
+	GetColumnOutlineLevel(col int) (int, error)
+	// SetRowsOutlineLevel sets the outline level of rows from startRow to endRow.
+	SetRowsOutlineLevel(startRow, endRow int, level int) error
+	// SetColumnsOutlineLevel sets the outline level of columns from startCol to endCol.
+	SetColumnsOutlineLevel(startCol, endCol int, level int) error
 }
 
 type Table struct {
@@ -57,8 +135,205 @@ type Table struct {
 }
 
 type PivotTable struct {
-	Name  string
+	Name string
+	// Range is the range of the pivot table body, excluding filter fields above it.
+	// To add a pivot table, it is the top-left cell where the filter fields, if any, begin.
 	Range string
+	// SourceRange is the source data, a sheet-qualified range (e.g. "Sheet1!A1:E31"), a table name or a defined name.
+	SourceRange string
+	// Rows, Columns and Filters are the field names in the header row of the source data.
+	Rows    []string
+	Columns []string
+	Filters []string
+	Values  []PivotValueField
+}
+
+type PivotValueField struct {
+	Field    string
+	Function PivotFunction
+	// Name is the caption of the value field (e.g. "Sum of Sales").
+	Name string
+}
+
+type CopyRangeOptions struct {
+	Mode CopyMode
+	// Transpose swaps rows and columns.
+	Transpose bool
+	// Move clears the source after copying as cut and paste does, without shifting formulas.
+	// It is used only with CopyModeAll and without Transpose.
+	Move bool
+}
+
+type SortKey struct {
+	// Column is the column number in the sheet (e.g. 2 for column B).
+	Column   int
+	Order    SortOrder
+	DataType SortDataType
+	// CustomOrder lists values which are sorted first in this order. Other values follow in the normal order.
+	CustomOrder []string
+}
+
+type AutoFilter struct {
+	// Range is the range of the AutoFilter including its header row.
+	Range   string
+	Columns []FilterColumn
+}
+
+// FilterColumn is the criteria of a column of an AutoFilter. Rows match if the cell is one of Values (or empty with Blank),
+// or satisfies Conditions.
+type FilterColumn struct {
+	// Column is the column number in the sheet (e.g. 2 for column B).
+	Column int
+	// Values lists displayed values to show.
+	Values []string
+	// Blank also shows empty cells.
+	Blank bool
+	// Conditions are custom conditions. Excel allows at most 2 conditions.
+	Conditions []FilterCondition
+	// And joins the conditions with and instead of or.
+	And bool
+	// Other describes criteria which are neither values nor conditions (e.g. top 10), and which are not evaluated.
+	Other string
+}
+
+type FilterCondition struct {
+	Operator FilterOperator
+	// Value may contain wildcards * and ? with equal and notEqual operators.
+	Value string
+}
+
+// MaxOutlineLevel is the maximum outline level of rows and columns.
+const MaxOutlineLevel = 7
+
+type DefinedName struct {
+	Name string
+	// Scope is the sheet name of a sheet-scoped name. It is empty for workbook-scoped names.
+	Scope string
+	// RefersTo is the reference or formula without the leading "=" (e.g. "Sheet1!$A$1:$C$10").
+	RefersTo string
+	Comment  string
+}
+
+type DataValidation struct {
+	// Range is the target range. Multiple areas are separated by comma (e.g. A1:A10,C1:C10).
+	Range    string                 `yaml:"range"`
+	Type     DataValidationType     `yaml:"type"`
+	Operator DataValidationOperator `yaml:"operator,omitempty"`
+	// Formula1 and Formula2 are values, references or formulas without the leading "=".
+	Formula1 string `yaml:"formula1,omitempty"`
+	Formula2 string `yaml:"formula2,omitempty"`
+	// List is the literal items of a list validation. Formula1 is used if it is empty.
+	List         []string                 `yaml:"list,omitempty"`
+	AllowBlank   bool                     `yaml:"allowBlank,omitempty"`
+	InputTitle   string                   `yaml:"inputTitle,omitempty"`
+	InputMessage string                   `yaml:"inputMessage,omitempty"`
+	ErrorStyle   DataValidationErrorStyle `yaml:"errorStyle,omitempty"`
+	ErrorTitle   string                   `yaml:"errorTitle,omitempty"`
+	ErrorMessage string                   `yaml:"errorMessage,omitempty"`
+}
+
+type Comment struct {
+	Cell   string `yaml:"cell"`
+	Author string `yaml:"author,omitempty"`
+	Text   string `yaml:"text"`
+}
+
+type Hyperlink struct {
+	Cell string `yaml:"cell"`
+	// Address is an external URL or file path.
+	Address string `yaml:"address,omitempty"`
+	// Location is a place in this workbook (e.g. "Sheet2!A1"), or in the file of Address.
+	Location string `yaml:"location,omitempty"`
+	// Display is the text of the cell. It is used only to set a hyperlink.
+	Display string `yaml:"display,omitempty"`
+	Tooltip string `yaml:"tooltip,omitempty"`
+}
+
+type Chart struct {
+	Name  string
+	Type  ChartType
+	Title string
+	// XAxisTitle is the title of the category (horizontal) axis, YAxisTitle is the one of the value axis.
+	XAxisTitle     string
+	YAxisTitle     string
+	LegendPosition ChartLegendPosition
+	// Anchor is the top-left cell to add a chart. For existing charts, it is the range covered by the chart (e.g. "E2:L17").
+	Anchor string
+	// Width and Height are the size of a chart to add in pixels.
+	Width  int
+	Height int
+	Series []ChartSeries
+}
+
+type ChartSeries struct {
+	// Name, Categories and Values are references without the leading "=" (e.g. "Sheet1!$B$1", "Sheet1!$A$2:$A$10").
+	// Name may be a literal text for existing charts.
+	Name       string
+	Categories string
+	Values     string
+	// Type is the chart type of the series in a combo chart.
+	Type ChartType
+}
+
+type Picture struct {
+	// Cell is the top-left cell where the picture is anchored.
+	Cell string
+	// Extension is the file extension of the image format including the dot (e.g. ".png").
+	Extension string
+	Data      []byte
+	AltText   string
+	// ScaleX and ScaleY are the scale of a picture to add to its original size (e.g. 0.5).
+	ScaleX float64
+	ScaleY float64
+}
+
+type ConditionalFormat struct {
+	// Range is the target range. Multiple areas are separated by comma (e.g. A1:A10,C1:C10).
+	Range    string                    `yaml:"range"`
+	Type     ConditionalFormatType     `yaml:"type"`
+	Operator ConditionalFormatOperator `yaml:"operator,omitempty"`
+	// Formula1 and Formula2 are the compared values of cellValue, or the formula of formula type, without the leading "=".
+	Formula1 string `yaml:"formula1,omitempty"`
+	Formula2 string `yaml:"formula2,omitempty"`
+	// Rank is the number of items for top and bottom. Percent treats it as percentage.
+	Rank    int  `yaml:"rank,omitempty"`
+	Percent bool `yaml:"percent,omitempty"`
+	// Style is the format applied to matched cells. It is not used by colorScale, dataBar and iconSet.
+	Style *CellStyle `yaml:"style,omitempty"`
+	// MinColor, MidColor and MaxColor are colors of colorScale. A 3-color scale is used if MidColor is set.
+	MinColor     string `yaml:"minColor,omitempty"`
+	MidColor     string `yaml:"midColor,omitempty"`
+	MaxColor     string `yaml:"maxColor,omitempty"`
+	BarColor     string `yaml:"barColor,omitempty"`
+	IconStyle    string `yaml:"iconStyle,omitempty"`
+	ReverseIcons bool   `yaml:"reverseIcons,omitempty"`
+	StopIfTrue   bool   `yaml:"stopIfTrue,omitempty"`
+}
+
+// CellValueKind is the kind of the value stored in a cell.
+type CellValueKind int
+
+const (
+	CellValueKindEmpty CellValueKind = iota
+	CellValueKindString
+	// CellValueKindNumber includes dates and times, which are stored as serial numbers
+	CellValueKindNumber
+	CellValueKindBool
+	CellValueKindError
+)
+
+// CellData is a cell read by GetRangeValues.
+type CellData struct {
+	// Value is the displayed value, the same as GetValue returns.
+	Value string
+	// RawValue is the value stored without its number format, the same as GetRawValue returns.
+	// It is empty if the cell has no value.
+	RawValue string
+	// Formula starts with "=", or is empty if the cell has no formula.
+	Formula string
+	// StyleID identifies the style of the cell within the workbook. Cells with the same StyleID have the same style.
+	// It is -1 if the backend does not identify styles.
+	StyleID int
 }
 
 type CellStyle struct {
@@ -344,3 +619,456 @@ func FillShadingValues() []FillShading {
 		FillShadingFromCorner,
 	}
 }
+
+// DataValidationType represents the type of data validation
+type DataValidationType string
+
+const (
+	DataValidationTypeList       DataValidationType = "list"
+	DataValidationTypeWhole      DataValidationType = "whole"
+	DataValidationTypeDecimal    DataValidationType = "decimal"
+	DataValidationTypeDate       DataValidationType = "date"
+	DataValidationTypeTime       DataValidationType = "time"
+	DataValidationTypeTextLength DataValidationType = "textLength"
+	DataValidationTypeCustom     DataValidationType = "custom"
+)
+
+func (d DataValidationType) String() string {
+	return string(d)
+}
+
+func (d DataValidationType) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func DataValidationTypeValues() []DataValidationType {
+	return []DataValidationType{
+		DataValidationTypeList,
+		DataValidationTypeWhole,
+		DataValidationTypeDecimal,
+		DataValidationTypeDate,
+		DataValidationTypeTime,
+		DataValidationTypeTextLength,
+		DataValidationTypeCustom,
+	}
+}
+
+// DataValidationOperator represents the comparison operator of data validation
+type DataValidationOperator string
+
+const (
+	DataValidationOperatorBetween            DataValidationOperator = "between"
+	DataValidationOperatorNotBetween         DataValidationOperator = "notBetween"
+	DataValidationOperatorEqual              DataValidationOperator = "equal"
+	DataValidationOperatorNotEqual           DataValidationOperator = "notEqual"
+	DataValidationOperatorGreaterThan        DataValidationOperator = "greaterThan"
+	DataValidationOperatorLessThan           DataValidationOperator = "lessThan"
+	DataValidationOperatorGreaterThanOrEqual DataValidationOperator = "greaterThanOrEqual"
+	DataValidationOperatorLessThanOrEqual    DataValidationOperator = "lessThanOrEqual"
+)
+
+func (d DataValidationOperator) String() string {
+	return string(d)
+}
+
+func (d DataValidationOperator) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func DataValidationOperatorValues() []DataValidationOperator {
+	return []DataValidationOperator{
+		DataValidationOperatorBetween,
+		DataValidationOperatorNotBetween,
+		DataValidationOperatorEqual,
+		DataValidationOperatorNotEqual,
+		DataValidationOperatorGreaterThan,
+		DataValidationOperatorLessThan,
+		DataValidationOperatorGreaterThanOrEqual,
+		DataValidationOperatorLessThanOrEqual,
+	}
+}
+
+// DataValidationErrorStyle represents the alert style shown on invalid input
+type DataValidationErrorStyle string
+
+const (
+	DataValidationErrorStyleStop        DataValidationErrorStyle = "stop"
+	DataValidationErrorStyleWarning     DataValidationErrorStyle = "warning"
+	DataValidationErrorStyleInformation DataValidationErrorStyle = "information"
+)
+
+func (d DataValidationErrorStyle) String() string {
+	return string(d)
+}
+
+func (d DataValidationErrorStyle) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func DataValidationErrorStyleValues() []DataValidationErrorStyle {
+	return []DataValidationErrorStyle{
+		DataValidationErrorStyleStop,
+		DataValidationErrorStyleWarning,
+		DataValidationErrorStyleInformation,
+	}
+}
+
+// ConditionalFormatType represents the type of conditional formatting rule
+type ConditionalFormatType string
+
+const (
+	ConditionalFormatTypeCellValue  ConditionalFormatType = "cellValue"
+	ConditionalFormatTypeFormula    ConditionalFormatType = "formula"
+	ConditionalFormatTypeTop        ConditionalFormatType = "top"
+	ConditionalFormatTypeBottom     ConditionalFormatType = "bottom"
+	ConditionalFormatTypeDuplicate  ConditionalFormatType = "duplicate"
+	ConditionalFormatTypeUnique     ConditionalFormatType = "unique"
+	ConditionalFormatTypeColorScale ConditionalFormatType = "colorScale"
+	ConditionalFormatTypeDataBar    ConditionalFormatType = "dataBar"
+	ConditionalFormatTypeIconSet    ConditionalFormatType = "iconSet"
+)
+
+func (c ConditionalFormatType) String() string {
+	return string(c)
+}
+
+func (c ConditionalFormatType) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func ConditionalFormatTypeValues() []ConditionalFormatType {
+	return []ConditionalFormatType{
+		ConditionalFormatTypeCellValue,
+		ConditionalFormatTypeFormula,
+		ConditionalFormatTypeTop,
+		ConditionalFormatTypeBottom,
+		ConditionalFormatTypeDuplicate,
+		ConditionalFormatTypeUnique,
+		ConditionalFormatTypeColorScale,
+		ConditionalFormatTypeDataBar,
+		ConditionalFormatTypeIconSet,
+	}
+}
+
+// ConditionalFormatOperator represents the comparison operator of cellValue rules
+type ConditionalFormatOperator string
+
+const (
+	ConditionalFormatOperatorBetween            ConditionalFormatOperator = "between"
+	ConditionalFormatOperatorNotBetween         ConditionalFormatOperator = "notBetween"
+	ConditionalFormatOperatorEqual              ConditionalFormatOperator = "equal"
+	ConditionalFormatOperatorNotEqual           ConditionalFormatOperator = "notEqual"
+	ConditionalFormatOperatorGreaterThan        ConditionalFormatOperator = "greaterThan"
+	ConditionalFormatOperatorLessThan           ConditionalFormatOperator = "lessThan"
+	ConditionalFormatOperatorGreaterThanOrEqual ConditionalFormatOperator = "greaterThanOrEqual"
+	ConditionalFormatOperatorLessThanOrEqual    ConditionalFormatOperator = "lessThanOrEqual"
+)
+
+func (c ConditionalFormatOperator) String() string {
+	return string(c)
+}
+
+func (c ConditionalFormatOperator) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func ConditionalFormatOperatorValues() []ConditionalFormatOperator {
+	return []ConditionalFormatOperator{
+		ConditionalFormatOperatorBetween,
+		ConditionalFormatOperatorNotBetween,
+		ConditionalFormatOperatorEqual,
+		ConditionalFormatOperatorNotEqual,
+		ConditionalFormatOperatorGreaterThan,
+		ConditionalFormatOperatorLessThan,
+		ConditionalFormatOperatorGreaterThanOrEqual,
+		ConditionalFormatOperatorLessThanOrEqual,
+	}
+}
+
+// IconStyleValues returns the icon set names in the order of Excel's XlIconSet constants.
+func IconStyleValues() []string {
+	return []string{
+		"3Arrows",
+		"3ArrowsGray",
+		"3Flags",
+		"3TrafficLights1",
+		"3TrafficLights2",
+		"3Signs",
+		"3Symbols",
+		"3Symbols2",
+		"4Arrows",
+		"4ArrowsGray",
+		"4RedToBlack",
+		"4Rating",
+		"4TrafficLights",
+		"5Arrows",
+		"5ArrowsGray",
+		"5Rating",
+		"5Quarters",
+	}
+}
+
+// ChartType represents the type of chart
+type ChartType string
+
+const (
+	ChartTypeColumn  ChartType = "column"
+	ChartTypeBar     ChartType = "bar"
+	ChartTypeLine    ChartType = "line"
+	ChartTypePie     ChartType = "pie"
+	ChartTypeScatter ChartType = "scatter"
+	ChartTypeArea    ChartType = "area"
+	ChartTypeCombo   ChartType = "combo"
+)
+
+func (c ChartType) String() string {
+	return string(c)
+}
+
+func (c ChartType) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func ChartTypeValues() []ChartType {
+	return []ChartType{
+		ChartTypeColumn,
+		ChartTypeBar,
+		ChartTypeLine,
+		ChartTypePie,
+		ChartTypeScatter,
+		ChartTypeArea,
+		ChartTypeCombo,
+	}
+}
+
+// ChartLegendPosition represents the position of chart legend
+type ChartLegendPosition string
+
+const (
+	ChartLegendPositionBottom   ChartLegendPosition = "bottom"
+	ChartLegendPositionTop      ChartLegendPosition = "top"
+	ChartLegendPositionLeft     ChartLegendPosition = "left"
+	ChartLegendPositionRight    ChartLegendPosition = "right"
+	ChartLegendPositionTopRight ChartLegendPosition = "topRight"
+	ChartLegendPositionNone     ChartLegendPosition = "none"
+)
+
+func (c ChartLegendPosition) String() string {
+	return string(c)
+}
+
+func (c ChartLegendPosition) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func ChartLegendPositionValues() []ChartLegendPosition {
+	return []ChartLegendPosition{
+		ChartLegendPositionBottom,
+		ChartLegendPositionTop,
+		ChartLegendPositionLeft,
+		ChartLegendPositionRight,
+		ChartLegendPositionTopRight,
+		ChartLegendPositionNone,
+	}
+}
+
+// PivotFunction represents the aggregation function of pivot table value fields
+type PivotFunction string
+
+const (
+	PivotFunctionSum       PivotFunction = "sum"
+	PivotFunctionCount     PivotFunction = "count"
+	PivotFunctionAverage   PivotFunction = "average"
+	PivotFunctionMax       PivotFunction = "max"
+	PivotFunctionMin       PivotFunction = "min"
+	PivotFunctionProduct   PivotFunction = "product"
+	PivotFunctionCountNums PivotFunction = "countNums"
+	PivotFunctionStdDev    PivotFunction = "stdDev"
+	PivotFunctionStdDevp   PivotFunction = "stdDevp"
+	PivotFunctionVar       PivotFunction = "var"
+	PivotFunctionVarp      PivotFunction = "varp"
+)
+
+func (p PivotFunction) String() string {
+	return string(p)
+}
+
+func (p PivotFunction) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func PivotFunctionValues() []PivotFunction {
+	return []PivotFunction{
+		PivotFunctionSum,
+		PivotFunctionCount,
+		PivotFunctionAverage,
+		PivotFunctionMax,
+		PivotFunctionMin,
+		PivotFunctionProduct,
+		PivotFunctionCountNums,
+		PivotFunctionStdDev,
+		PivotFunctionStdDevp,
+		PivotFunctionVar,
+		PivotFunctionVarp,
+	}
+}
+
+// ClearMode represents what is cleared from a range
+type ClearMode string
+
+const (
+	// ClearModeValue clears values of cells without formula
+	ClearModeValue          ClearMode = "values"
+	ClearModeFormula        ClearMode = "formulas"
+	ClearModeStyle          ClearMode = "styles"
+	ClearModeComment        ClearMode = "comments"
+	ClearModeHyperlink      ClearMode = "hyperlinks"
+	ClearModeDataValidation ClearMode = "dataValidations"
+	// ClearModeAll clears all of the above
+	ClearModeAll ClearMode = "all"
+)
+
+func (c ClearMode) String() string {
+	return string(c)
+}
+
+func (c ClearMode) MarshalText() ([]byte, error) {
+	return []byte(c), nil
+}
+
+func ClearModeValues() []ClearMode {
+	return []ClearMode{
+		ClearModeValue,
+		ClearModeFormula,
+		ClearModeStyle,
+		ClearModeComment,
+		ClearModeHyperlink,
+		ClearModeDataValidation,
+		ClearModeAll,
+	}
+}
+
+// hasClearMode reports whether the modes include the mode, or all
+func hasClearMode(modes []ClearMode, mode ClearMode) bool {
+	for _, m := range modes {
+		if m == mode || m == ClearModeAll {
+			return true
+		}
+	}
+	return false
+}
+
+// CopyMode represents what is copied from a range
+type CopyMode string
+
+const (
+	// CopyModeAll copies values, formulas and formats
+	CopyModeAll CopyMode = "all"
+	// CopyModeValue copies values, and the results of formulas as values
+	CopyModeValue CopyMode = "values"
+	// CopyModeFormula copies values and formulas without formats
+	CopyModeFormula CopyMode = "formulas"
+	CopyModeFormat  CopyMode = "formats"
+)
+
+func (c CopyMode) String() string {
+	return string(c)
+}
+
+func (c CopyMode) MarshalText() ([]byte, error) {
+	return []byte(c), nil
+}
+
+func CopyModeValues() []CopyMode {
+	return []CopyMode{
+		CopyModeAll,
+		CopyModeValue,
+		CopyModeFormula,
+		CopyModeFormat,
+	}
+}
+
+// SortOrder represents the order of a sort key
+type SortOrder string
+
+const (
+	SortOrderAscending  SortOrder = "ascending"
+	SortOrderDescending SortOrder = "descending"
+)
+
+func (s SortOrder) String() string {
+	return string(s)
+}
+
+func (s SortOrder) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+func SortOrderValues() []SortOrder {
+	return []SortOrder{
+		SortOrderAscending,
+		SortOrderDescending,
+	}
+}
+
+// SortDataType represents how values of a sort key are compared
+type SortDataType string
+
+const (
+	// SortDataTypeAuto sorts numbers, text and booleans in this order, as Excel does
+	SortDataTypeAuto SortDataType = "auto"
+	// SortDataTypeNumber also sorts numbers stored as text as numbers
+	SortDataTypeNumber SortDataType = "number"
+	// SortDataTypeText sorts the displayed text of cells
+	SortDataTypeText SortDataType = "text"
+	// SortDataTypeDate also sorts dates stored as text (e.g. "2024-01-31") as dates
+	SortDataTypeDate SortDataType = "date"
+)
+
+func (s SortDataType) String() string {
+	return string(s)
+}
+
+func (s SortDataType) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+func SortDataTypeValues() []SortDataType {
+	return []SortDataType{
+		SortDataTypeAuto,
+		SortDataTypeNumber,
+		SortDataTypeText,
+		SortDataTypeDate,
+	}
+}
+
+// FilterOperator represents the operator of an AutoFilter condition
+type FilterOperator string
+
+const (
+	FilterOperatorEqual              FilterOperator = "equal"
+	FilterOperatorNotEqual           FilterOperator = "notEqual"
+	FilterOperatorGreaterThan        FilterOperator = "greaterThan"
+	FilterOperatorGreaterThanOrEqual FilterOperator = "greaterThanOrEqual"
+	FilterOperatorLessThan           FilterOperator = "lessThan"
+	FilterOperatorLessThanOrEqual    FilterOperator = "lessThanOrEqual"
+)
+
+func (f FilterOperator) String() string {
+	return string(f)
+}
+
+func (f FilterOperator) MarshalText() ([]byte, error) {
+	return []byte(f), nil
+}
+
+func FilterOperatorValues() []FilterOperator {
+	return []FilterOperator{
+		FilterOperatorEqual,
+		FilterOperatorNotEqual,
+		FilterOperatorGreaterThan,
+		FilterOperatorGreaterThanOrEqual,
+		FilterOperatorLessThan,
+		FilterOperatorLessThanOrEqual,
+	}
+}
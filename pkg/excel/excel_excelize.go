@@ -1,10 +1,19 @@
 package excel
 
 import (
+	"encoding/base64"
+	"encoding/xml"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/xuri/excelize/v2"
 )
@@ -62,6 +71,330 @@ func (e *ExcelizeExcel) CopySheet(srcSheetName string, destSheetName string) err
 	return nil
 }
 
+func (e *ExcelizeExcel) CopySheetToWorkbook(srcSheetName string, destWorkbook Excel, destSheetName string) error {
+	dest, ok := destWorkbook.(*ExcelizeExcel)
+	if !ok {
+		return fmt.Errorf("destination workbook is opened by another backend: %s", destWorkbook.GetBackendName())
+	}
+	if index, _ := e.file.GetSheetIndex(srcSheetName); index < 0 {
+		return fmt.Errorf("source sheet not found: %s", srcSheetName)
+	}
+	if index, _ := dest.file.GetSheetIndex(destSheetName); index >= 0 {
+		return fmt.Errorf("destination sheet already exists: %s", destSheetName)
+	}
+	// table names must be unique in the destination workbook
+	tableNames := map[string]bool{}
+	for _, sheetName := range dest.file.GetSheetList() {
+		tables, err := dest.file.GetTables(sheetName)
+		if err != nil {
+			return err
+		}
+		for _, table := range tables {
+			tableNames[strings.ToUpper(table.Name)] = true
+		}
+	}
+	if _, err := dest.file.NewSheet(destSheetName); err != nil {
+		return fmt.Errorf("failed to create destination sheet: %w", err)
+	}
+	if err := e.copySheetContents(srcSheetName, dest, destSheetName, tableNames); err != nil {
+		// do not leave the half-copied sheet in the destination workbook
+		if deleteErr := dest.file.DeleteSheet(destSheetName); deleteErr != nil {
+			return fmt.Errorf("%w (failed to delete destination sheet: %v)", err, deleteErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// copySheetContents copies the contents of the source sheet to the newly created sheet of the destination workbook.
+// tableNames holds the upper-cased table names used in the destination workbook.
+func (e *ExcelizeExcel) copySheetContents(srcSheetName string, dest *ExcelizeExcel, destSheetName string, tableNames map[string]bool) error {
+	src := &ExcelizeWorksheet{file: e.file, sheetName: srcSheetName}
+
+	props, err := e.file.GetSheetProps(srcSheetName)
+	if err != nil {
+		return err
+	}
+	if err := dest.file.SetSheetProps(destSheetName, &props); err != nil {
+		return fmt.Errorf("failed to copy sheet properties: %w", err)
+	}
+
+	// style IDs are indexes into the style sheet of each workbook
+	styleIDs := map[int]int{0: 0}
+	copyStyle := func(styleID int) (int, error) {
+		if destStyleID, ok := styleIDs[styleID]; ok {
+			return destStyleID, nil
+		}
+		style, err := e.file.GetStyle(styleID)
+		if err != nil {
+			return 0, err
+		}
+		destStyleID, err := dest.file.NewStyle(style)
+		if err != nil {
+			return 0, err
+		}
+		styleIDs[styleID] = destStyleID
+		return destStyleID, nil
+	}
+
+	// row and column styles overwrite the styles of the cells, so they are copied first
+	sheetXML, err := src.sheetXMLPath()
+	if err != nil {
+		return err
+	}
+	var worksheet xlsxWorksheetRowsRead
+	if err := xml.Unmarshal(src.readPart(sheetXML), &worksheet); err != nil {
+		return fmt.Errorf("failed to read worksheet: %w", err)
+	}
+	styledCols, styledRows := map[int]bool{}, map[int]bool{}
+	for _, colElement := range worksheet.Cols {
+		if colElement.Style == 0 {
+			continue
+		}
+		destStyleID, err := copyStyle(colElement.Style)
+		if err != nil {
+			return fmt.Errorf("failed to copy style of columns %d:%d: %w", colElement.Min, colElement.Max, err)
+		}
+		minName, err := excelize.ColumnNumberToName(colElement.Min)
+		if err != nil {
+			return err
+		}
+		maxName, err := excelize.ColumnNumberToName(colElement.Max)
+		if err != nil {
+			return err
+		}
+		if err := dest.file.SetColStyle(destSheetName, minName+":"+maxName, destStyleID); err != nil {
+			return err
+		}
+		for col := colElement.Min; col <= colElement.Max; col++ {
+			styledCols[col] = true
+		}
+	}
+	row := 0
+	for _, rowElement := range worksheet.Rows {
+		// a row element without the reference follows the previous one
+		row = max(row+1, rowElement.R)
+		if !rowElement.CustomFormat || rowElement.S == 0 {
+			continue
+		}
+		destStyleID, err := copyStyle(rowElement.S)
+		if err != nil {
+			return fmt.Errorf("failed to copy style of row %d: %w", row, err)
+		}
+		if err := dest.file.SetRowStyle(destSheetName, row, row, destStyleID); err != nil {
+			return err
+		}
+		styledRows[row] = true
+	}
+
+	dimension, err := e.file.GetSheetDimension(srcSheetName)
+	if err != nil {
+		return err
+	}
+	startCol, startRow, endCol, endRow, err := ParseRange(dimension)
+	if err != nil {
+		// empty sheet
+		return nil
+	}
+
+	for row := startRow; row <= endRow; row++ {
+		for col := startCol; col <= endCol; col++ {
+			cell, err := excelize.CoordinatesToCellName(col, row)
+			if err != nil {
+				return err
+			}
+			formula, err := e.file.GetCellFormula(srcSheetName, cell)
+			if err != nil {
+				return err
+			}
+			value, err := src.typedCellValue(cell, formula != "")
+			if err != nil {
+				return err
+			}
+			styleID, err := e.file.GetCellStyle(srcSheetName, cell)
+			if err != nil {
+				return err
+			}
+			destStyleID, err := copyStyle(styleID)
+			if err != nil {
+				return fmt.Errorf("failed to copy style of %s: %w", cell, err)
+			}
+			// the value is kept as the cached result of the formula
+			if value != nil {
+				if err := dest.file.SetCellValue(destSheetName, cell, value); err != nil {
+					return err
+				}
+			}
+			if formula != "" {
+				if err := dest.file.SetCellFormula(destSheetName, cell, formula); err != nil {
+					return err
+				}
+			}
+			// the cell may have the default style in a styled row or column
+			if destStyleID != 0 || styledRows[row] || styledCols[col] {
+				if err := dest.file.SetCellStyle(destSheetName, cell, cell, destStyleID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for col := 1; col <= endCol; col++ {
+		name, err := excelize.ColumnNumberToName(col)
+		if err != nil {
+			return err
+		}
+		width, err := e.file.GetColWidth(srcSheetName, name)
+		if err != nil {
+			return err
+		}
+		destWidth, err := dest.file.GetColWidth(destSheetName, name)
+		if err != nil {
+			return err
+		}
+		if width != destWidth {
+			if err := dest.file.SetColWidth(destSheetName, name, name, width); err != nil {
+				return err
+			}
+		}
+		if visible, err := e.file.GetColVisible(srcSheetName, name); err != nil {
+			return err
+		} else if !visible {
+			if err := dest.file.SetColVisible(destSheetName, name, false); err != nil {
+				return err
+			}
+		}
+	}
+	for row := 1; row <= endRow; row++ {
+		height, err := e.file.GetRowHeight(srcSheetName, row)
+		if err != nil {
+			return err
+		}
+		destHeight, err := dest.file.GetRowHeight(destSheetName, row)
+		if err != nil {
+			return err
+		}
+		if height != destHeight {
+			if err := dest.file.SetRowHeight(destSheetName, row, height); err != nil {
+				return err
+			}
+		}
+		if hidden, err := src.IsRowHidden(row); err != nil {
+			return err
+		} else if hidden {
+			if err := dest.file.SetRowVisible(destSheetName, row, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	mergeCells, err := e.file.GetMergeCells(srcSheetName)
+	if err != nil {
+		return err
+	}
+	for _, mergeCell := range mergeCells {
+		if err := dest.file.MergeCell(destSheetName, mergeCell.GetStartAxis(), mergeCell.GetEndAxis()); err != nil {
+			return err
+		}
+	}
+
+	tables, err := e.file.GetTables(srcSheetName)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		// rename the table if the name is already used, as Excel does
+		name := table.Name
+		for i := 2; tableNames[strings.ToUpper(name)]; i++ {
+			name = fmt.Sprintf("%s_%d", table.Name, i)
+		}
+		tableNames[strings.ToUpper(name)] = true
+		if err := dest.file.AddTable(destSheetName, &excelize.Table{
+			Range:             table.Range,
+			Name:              name,
+			StyleName:         table.StyleName,
+			ShowColumnStripes: table.ShowColumnStripes,
+			ShowFirstColumn:   table.ShowFirstColumn,
+			ShowHeaderRow:     table.ShowHeaderRow,
+			ShowLastColumn:    table.ShowLastColumn,
+			ShowRowStripes:    table.ShowRowStripes,
+		}); err != nil {
+			return fmt.Errorf("failed to copy table %s: %w", table.Name, err)
+		}
+	}
+
+	return dest.file.SetSheetDimension(destSheetName, dimension)
+}
+
+func (e *ExcelizeExcel) GetDefinedNames() ([]DefinedName, error) {
+	definedNames := e.file.GetDefinedName()
+	result := make([]DefinedName, len(definedNames))
+	for i, definedName := range definedNames {
+		scope := definedName.Scope
+		if scope == "Workbook" {
+			scope = ""
+		}
+		result[i] = DefinedName{
+			Name:     definedName.Name,
+			Scope:    scope,
+			RefersTo: strings.TrimPrefix(definedName.RefersTo, "="),
+			Comment:  definedName.Comment,
+		}
+	}
+	return result, nil
+}
+
+func (e *ExcelizeExcel) SetDefinedName(definedName DefinedName) error {
+	// excelize refuses duplicated names, so the existing one is replaced keeping its casing
+	name := definedName.Name
+	if storedName, ok := e.storedDefinedName(definedName.Name, definedName.Scope); ok {
+		name = storedName
+		if err := e.file.DeleteDefinedName(&excelize.DefinedName{
+			Name:  storedName,
+			Scope: definedName.Scope,
+		}); err != nil {
+			return fmt.Errorf("failed to delete defined name: %w", err)
+		}
+	}
+	if err := e.file.SetDefinedName(&excelize.DefinedName{
+		Name:     name,
+		Scope:    definedName.Scope,
+		RefersTo: strings.TrimPrefix(definedName.RefersTo, "="),
+		Comment:  definedName.Comment,
+	}); err != nil {
+		return fmt.Errorf("failed to set defined name: %w", err)
+	}
+	return nil
+}
+
+func (e *ExcelizeExcel) DeleteDefinedName(name, scope string) error {
+	// excelize matches names case-sensitively, unlike Excel
+	if storedName, ok := e.storedDefinedName(name, scope); ok {
+		name = storedName
+	}
+	if err := e.file.DeleteDefinedName(&excelize.DefinedName{
+		Name:  name,
+		Scope: scope,
+	}); err != nil {
+		return fmt.Errorf("failed to delete defined name: %w", err)
+	}
+	return nil
+}
+
+// storedDefinedName returns the name as stored in the workbook, which matches the name case-insensitively in the scope.
+// The scope is a sheet name, or an empty string for the workbook.
+func (e *ExcelizeExcel) storedDefinedName(name, scope string) (string, bool) {
+	if scope == "" {
+		scope = "Workbook"
+	}
+	for _, definedName := range e.file.GetDefinedName() {
+		if strings.EqualFold(definedName.Name, name) && definedName.Scope == scope {
+			return definedName.Name, true
+		}
+	}
+	return "", false
+}
+
 func (e *ExcelizeExcel) GetSheets() ([]Worksheet, error) {
 	sheetList := e.file.GetSheetList()
 	worksheets := make([]Worksheet, len(sheetList))
@@ -81,162 +414,2623 @@ func (w *ExcelizeExcel) Save() error {
 	if err != nil {
 		return err
 	}
-	defer file.Close()
-	return w.file.Write(file)
+	defer file.Close()
+	return w.file.Write(file)
+}
+
+type ExcelizeWorksheet struct {
+	file      *excelize.File
+	sheetName string
+	// lastRow caches the number of the last row element for IsRowHidden, or 0 if it is not read yet.
+	// It is reset when rows are hidden, which may add row elements.
+	lastRow int
+	// regions caches the detected data regions. It is reset when values are written
+	regions dataRegionsCache
+}
+
+func (w *ExcelizeWorksheet) cachedDataRegions() *dataRegionsCache {
+	return &w.regions
+}
+
+func (w *ExcelizeWorksheet) Release() {
+	// No resources to release in excelize
+}
+
+func (w *ExcelizeWorksheet) Name() (string, error) {
+	return w.sheetName, nil
+}
+
+func (w *ExcelizeWorksheet) GetTables() ([]Table, error) {
+	tables, err := w.file.GetTables(w.sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tables: %w", err)
+	}
+	tableList := make([]Table, len(tables))
+	for i, table := range tables {
+		tableList[i] = Table{
+			Name:  table.Name,
+			Range: NormalizeRange(table.Range),
+		}
+	}
+	return tableList, nil
+}
+
+func (w *ExcelizeWorksheet) GetPivotTables() ([]PivotTable, error) {
+	pivotTables, err := w.file.GetPivotTables(w.sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pivot tables: %w", err)
+	}
+	pivotTableList := make([]PivotTable, len(pivotTables))
+	for i, pivotTable := range pivotTables {
+		values := make([]PivotValueField, len(pivotTable.Data))
+		for j, field := range pivotTable.Data {
+			values[j] = PivotValueField{
+				Field:    field.Data,
+				Function: excelizeToPivotFunction(field.Subtotal),
+				Name:     field.Name,
+			}
+		}
+		// excelize qualifies the range of the pivot table with the sheet name
+		_, pivotTableRange := SplitSheetName(pivotTable.PivotTableRange)
+		pivotTableList[i] = PivotTable{
+			Name:        pivotTable.Name,
+			Range:       NormalizeRange(pivotTableRange),
+			SourceRange: normalizeSourceRange(pivotTable.DataRange),
+			Rows:        pivotTableFieldNames(pivotTable.Rows),
+			Columns:     pivotTableFieldNames(pivotTable.Columns),
+			Filters:     pivotTableFieldNames(pivotTable.Filter),
+			Values:      values,
+		}
+	}
+	return pivotTableList, nil
+}
+
+func (w *ExcelizeWorksheet) AddPivotTable(pivotTable *PivotTable) error {
+	sourceSheetName, sourceRange, err := ResolveSourceRange(&ExcelizeExcel{file: w.file}, w.sheetName, pivotTable.SourceRange)
+	if err != nil {
+		return err
+	}
+	// excelize resolves table names by itself, and requires an unquoted sheet name for ranges
+	dataRange := sourceSheetName + "!" + sourceRange
+	if _, _, _, _, _, err := ParseSheetRange(pivotTable.SourceRange); err != nil {
+		if tableName, ok := w.findTableName(pivotTable.SourceRange); ok {
+			dataRange = tableName
+		}
+	}
+
+	// excelize does not compute the pivot table, which Excel refreshes on load,
+	// so the range is estimated from the source data
+	startCol, startRow, _, _, err := ParseRange(pivotTable.Range)
+	if err != nil {
+		return err
+	}
+	if len(pivotTable.Filters) > 0 {
+		// filter fields are placed above the body with a blank row
+		startRow += len(pivotTable.Filters) + 1
+	}
+	rowCount, colCount, err := w.estimatePivotTableSize(sourceSheetName, sourceRange, pivotTable)
+	if err != nil {
+		return err
+	}
+	startCell, err := excelize.CoordinatesToCellName(startCol, startRow)
+	if err != nil {
+		return err
+	}
+	endCell, err := excelize.CoordinatesToCellName(startCol+colCount-1, startRow+rowCount-1)
+	if err != nil {
+		return err
+	}
+
+	toFields := func(names []string) []excelize.PivotTableField {
+		var fields []excelize.PivotTableField
+		for _, name := range names {
+			fields = append(fields, excelize.PivotTableField{Data: name, Compact: true, Outline: true, DefaultSubtotal: true})
+		}
+		return fields
+	}
+	if pivotTable.Name == "" {
+		pivotTable.Name, err = w.newPivotTableName()
+		if err != nil {
+			return err
+		}
+	}
+	var data []excelize.PivotTableField
+	for _, value := range pivotTable.Values {
+		data = append(data, excelize.PivotTableField{Data: value.Field, Name: value.Name, Subtotal: value.Function.String()})
+	}
+	if err := w.file.AddPivotTable(&excelize.PivotTableOptions{
+		DataRange:       dataRange,
+		PivotTableRange: w.sheetName + "!" + startCell + ":" + endCell,
+		Name:            pivotTable.Name,
+		Rows:            toFields(pivotTable.Rows),
+		Columns:         toFields(pivotTable.Columns),
+		Filter:          toFields(pivotTable.Filters),
+		Data:            data,
+		RowGrandTotals:  true,
+		ColGrandTotals:  true,
+		ShowDrill:       true,
+		ShowRowHeaders:  true,
+		ShowColHeaders:  true,
+		ShowLastColumn:  true,
+	}); err != nil {
+		return fmt.Errorf("failed to add pivot table: %w", err)
+	}
+	pivotTable.Range = startCell + ":" + endCell
+	return nil
+}
+
+// estimatePivotTableSize estimates the number of rows and columns of a pivot table in the compact form
+// from the distinct items of the fields in the source data.
+func (w *ExcelizeWorksheet) estimatePivotTableSize(sourceSheetName string, sourceRange string, pivotTable *PivotTable) (int, int, error) {
+	startCol, startRow, endCol, endRow, err := ParseRange(sourceRange)
+	if err != nil {
+		return 0, 0, err
+	}
+	rows, err := w.file.GetRows(sourceSheetName)
+	if err != nil {
+		return 0, 0, err
+	}
+	var records [][]string
+	for row := startRow; row <= endRow && row <= len(rows); row++ {
+		record := make([]string, endCol-startCol+1)
+		for col := startCol; col <= endCol && col <= len(rows[row-1]); col++ {
+			record[col-startCol] = rows[row-1][col-1]
+		}
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return 0, 0, fmt.Errorf("source range is empty: %s", sourceRange)
+	}
+	header := records[0]
+	fieldIndexes := func(names []string) ([]int, error) {
+		var indexes []int
+		for _, name := range names {
+			index := -1
+			for i, h := range header {
+				if h == name {
+					index = i
+					break
+				}
+			}
+			if index < 0 {
+				return nil, fmt.Errorf("field not found in the header of the source: %s", name)
+			}
+			indexes = append(indexes, index)
+		}
+		return indexes, nil
+	}
+	rowFields, err := fieldIndexes(pivotTable.Rows)
+	if err != nil {
+		return 0, 0, err
+	}
+	colFields, err := fieldIndexes(pivotTable.Columns)
+	if err != nil {
+		return 0, 0, err
+	}
+	// items of each level, including the outer ones which show subtotals
+	countItems := func(fields []int) int {
+		count := 0
+		for level := 1; level <= len(fields); level++ {
+			keys := map[string]struct{}{}
+			for _, record := range records[1:] {
+				key := make([]string, level)
+				for i := range key {
+					key[i] = record[fields[i]]
+				}
+				keys[strings.Join(key, "\x00")] = struct{}{}
+			}
+			count += len(keys)
+		}
+		return count
+	}
+	valueCount := max(len(pivotTable.Values), 1)
+
+	rowCount := 1 + max(countItems(rowFields), 1)
+	if len(rowFields) > 0 {
+		rowCount++ // grand total
+	}
+	if len(colFields) > 0 || valueCount > 1 {
+		rowCount++ // column labels
+	}
+	colCount := 1 + valueCount*max(countItems(colFields), 1)
+	if len(colFields) > 0 {
+		colCount += valueCount // grand total
+	}
+	return rowCount, colCount, nil
+}
+
+// newPivotTableName generates a pivot table name (e.g. "PivotTable2") not used in the workbook.
+func (w *ExcelizeWorksheet) newPivotTableName() (string, error) {
+	used := map[string]bool{}
+	for _, sheetName := range w.file.GetSheetList() {
+		pivotTables, err := w.file.GetPivotTables(sheetName)
+		if err != nil {
+			return "", fmt.Errorf("failed to get pivot tables: %w", err)
+		}
+		for _, pivotTable := range pivotTables {
+			used[strings.ToLower(pivotTable.Name)] = true
+		}
+	}
+	for i := 1; ; i++ {
+		name := fmt.Sprintf("PivotTable%d", i)
+		if !used[strings.ToLower(name)] {
+			return name, nil
+		}
+	}
+}
+
+// findTableName finds a table in the workbook case-insensitively, and returns its exact name.
+func (w *ExcelizeWorksheet) findTableName(name string) (string, bool) {
+	for _, sheetName := range w.file.GetSheetList() {
+		tables, err := w.file.GetTables(sheetName)
+		if err != nil {
+			continue
+		}
+		for _, table := range tables {
+			if strings.EqualFold(table.Name, name) {
+				return table.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// pivotTableFieldNames returns the source field names of pivot table fields.
+func pivotTableFieldNames(fields []excelize.PivotTableField) []string {
+	var names []string
+	for _, field := range fields {
+		names = append(names, field.Data)
+	}
+	return names
+}
+
+// excelizeToPivotFunction converts excelize subtotal name (e.g. "Sum", "Countnums") to PivotFunction
+func excelizeToPivotFunction(subtotal string) PivotFunction {
+	for _, function := range PivotFunctionValues() {
+		if strings.EqualFold(function.String(), subtotal) {
+			return function
+		}
+	}
+	return PivotFunctionSum
+}
+
+func (w *ExcelizeWorksheet) SetValue(cell string, value any) error {
+	if err := w.file.SetCellValue(w.sheetName, cell, value); err != nil {
+		return err
+	}
+	if err := w.updateDimension(cell); err != nil {
+		return fmt.Errorf("failed to update dimension: %w", err)
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) SetFormula(cell string, formula string) error {
+	// excelize stores the formula as is, while the file has it without the leading "="
+	if err := w.file.SetCellFormula(w.sheetName, cell, strings.TrimPrefix(formula, "=")); err != nil {
+		return err
+	}
+	if err := w.updateDimension(cell); err != nil {
+		return fmt.Errorf("failed to update dimension: %w", err)
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) SetRangeValues(rangeStr string, values [][]any) error {
+	startCol, startRow, endCol, endRow, err := ParseRange(rangeStr)
+	if err != nil {
+		return err
+	}
+	if len(values) != endRow-startRow+1 {
+		return fmt.Errorf("number of rows in data (%d) does not match range size (%d)", len(values), endRow-startRow+1)
+	}
+	for i, row := range values {
+		if len(row) != endCol-startCol+1 {
+			return fmt.Errorf("number of columns in row %d (%d) does not match range size (%d)", i, len(row), endCol-startCol+1)
+		}
+	}
+	// emptiness is checked before the dimension is updated
+	empty, err := w.isEmpty()
+	if err != nil {
+		return err
+	}
+	// the dimension is updated first, because StreamWriter copies it from the worksheet
+	topLeft, err := excelize.CoordinatesToCellName(startCol, startRow)
+	if err != nil {
+		return err
+	}
+	bottomRight, err := excelize.CoordinatesToCellName(endCol, endRow)
+	if err != nil {
+		return err
+	}
+	if err := w.updateDimension(topLeft); err != nil {
+		return fmt.Errorf("failed to update dimension: %w", err)
+	}
+	if err := w.updateDimension(bottomRight); err != nil {
+		return fmt.Errorf("failed to update dimension: %w", err)
+	}
+
+	if empty {
+		return w.streamRangeValues(startCol, startRow, values)
+	}
+	for i, row := range values {
+		cell, err := excelize.CoordinatesToCellName(startCol, startRow+i)
+		if err != nil {
+			return err
+		}
+		// formulas are set after the values of the row, because SetSheetRow only sets values
+		rowValues := make([]any, len(row))
+		for j, value := range row {
+			if text, ok := value.(string); !ok || !strings.HasPrefix(text, "=") {
+				rowValues[j] = value
+			}
+		}
+		if err := w.file.SetSheetRow(w.sheetName, cell, &rowValues); err != nil {
+			return err
+		}
+		for j, value := range row {
+			if text, ok := value.(string); ok && strings.HasPrefix(text, "=") {
+				cell, err := excelize.CoordinatesToCellName(startCol+j, startRow+i)
+				if err != nil {
+					return err
+				}
+				if err := w.file.SetCellFormula(w.sheetName, cell, text); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isEmpty reports whether the worksheet has no cells, merged cells nor tables, whose XML StreamWriter would discard.
+func (w *ExcelizeWorksheet) isEmpty() (bool, error) {
+	// a dimension spanning cells tells the worksheet has had cells without reading its rows
+	dimension, err := w.file.GetSheetDimension(w.sheetName)
+	if err != nil {
+		return false, err
+	}
+	if strings.Contains(dimension, ":") {
+		return false, nil
+	}
+	mergeCells, err := w.file.GetMergeCells(w.sheetName)
+	if err != nil {
+		return false, err
+	}
+	tables, err := w.file.GetTables(w.sheetName)
+	if err != nil {
+		return false, err
+	}
+	if len(mergeCells) > 0 || len(tables) > 0 {
+		return false, nil
+	}
+	rows, err := w.file.Rows(w.sheetName)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	return !rows.Next(), nil
+}
+
+// streamRangeValues writes values to the empty worksheet with StreamWriter.
+// The worksheet must not be edited by other functions afterwards until the file is saved, because excelize saves
+// the streamed XML instead of the worksheet.
+func (w *ExcelizeWorksheet) streamRangeValues(startCol, startRow int, values [][]any) error {
+	writer, err := w.file.NewStreamWriter(w.sheetName)
+	if err != nil {
+		return err
+	}
+	for i, row := range values {
+		cell, err := excelize.CoordinatesToCellName(startCol, startRow+i)
+		if err != nil {
+			return err
+		}
+		rowValues := make([]any, len(row))
+		for j, value := range row {
+			if text, ok := value.(string); ok && strings.HasPrefix(text, "=") {
+				rowValues[j] = excelize.Cell{Formula: text}
+			} else {
+				rowValues[j] = value
+			}
+		}
+		if err := writer.SetRow(cell, rowValues); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+func (w *ExcelizeWorksheet) GetValue(cell string) (string, error) {
+	value, err := w.file.GetCellValue(w.sheetName, cell)
+	if err != nil {
+		return "", err
+	}
+	if value == "" {
+		// try to get calculated value
+		formula, err := w.file.GetCellFormula(w.sheetName, cell)
+		if err != nil {
+			return "", fmt.Errorf("failed to get formula: %w", err)
+		}
+		if formula != "" {
+			return w.file.CalcCellValue(w.sheetName, cell)
+		}
+	}
+	return value, nil
+}
+
+func (w *ExcelizeWorksheet) GetFormula(cell string) (string, error) {
+	formula, err := w.file.GetCellFormula(w.sheetName, cell)
+	if err != nil {
+		return "", fmt.Errorf("failed to get formula: %w", err)
+	}
+	if formula == "" {
+		// fallback
+		return w.GetValue(cell)
+	}
+	if !strings.HasPrefix(formula, "=") {
+		formula = "=" + formula
+	}
+	return formula, nil
+}
+
+func (w *ExcelizeWorksheet) GetRawValue(cell string) (string, CellValueKind, error) {
+	value, err := w.file.GetCellValue(w.sheetName, cell, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return "", CellValueKindEmpty, err
+	}
+	cellType, err := w.file.GetCellType(w.sheetName, cell)
+	if err != nil {
+		return "", CellValueKindEmpty, err
+	}
+	switch {
+	case value == "":
+		return "", CellValueKindEmpty, nil
+	case cellType == excelize.CellTypeBool:
+		if value == "1" || strings.EqualFold(value, "TRUE") {
+			return "TRUE", CellValueKindBool, nil
+		}
+		return "FALSE", CellValueKindBool, nil
+	case cellType == excelize.CellTypeError:
+		return value, CellValueKindError, nil
+	case cellType == excelize.CellTypeUnset || cellType == excelize.CellTypeNumber:
+		return value, CellValueKindNumber, nil
+	default:
+		return value, CellValueKindString, nil
+	}
+}
+
+// GetRangeValues reads cells of the range in one pass over the row elements of the range, instead of looking up each cell.
+// Only numbers with a style are formatted, and formulas without cached values are calculated, by excelize cell by cell.
+func (w *ExcelizeWorksheet) GetRangeValues(rangeStr string) ([][]CellData, error) {
+	startCol, startRow, endCol, endRow, err := ParseRange(rangeStr)
+	if err != nil {
+		return nil, err
+	}
+	worksheet, err := w.readRows(startRow, endRow)
+	if err != nil {
+		return nil, err
+	}
+	rowStyles := map[int]int{}
+	cells := map[cellCoordinates]*xlsxCRead{}
+	sharedFormulas := map[int]*xlsxCRead{}
+	row := 0
+	for _, rowElement := range worksheet.Rows {
+		// a row or cell element without the reference follows the previous one
+		row = max(row+1, rowElement.R)
+		if row < startRow {
+			continue
+		}
+		if row > endRow {
+			break
+		}
+		rowStyles[row] = rowElement.S
+		col := 0
+		for i := range rowElement.Cells {
+			c := &rowElement.Cells[i]
+			if cellCol, _, err := excelize.CellNameToCoordinates(c.R); err == nil {
+				col = cellCol
+			} else {
+				col++
+			}
+			// the master cell of a shared formula above the range is read by excelize if needed
+			if c.F != nil && c.F.T == "shared" && c.F.Ref != "" && c.F.Si != nil {
+				c.col, c.row = col, row
+				sharedFormulas[*c.F.Si] = c
+			}
+			if startCol <= col && col <= endCol {
+				cells[cellCoordinates{col, row}] = c
+			}
+		}
+	}
+
+	// a cell covered by a merged cell has the value of the top left cell.
+	// Merged cells are read after the cells, as excelize reformats values of the top left cells in memory
+	origins := map[cellCoordinates]cellCoordinates{}
+	mergeCells, err := w.file.GetMergeCells(w.sheetName)
+	if err != nil {
+		return nil, err
+	}
+	for _, mergeCell := range mergeCells {
+		mergeStartCol, mergeStartRow, mergeEndCol, mergeEndRow, err := ParseRange(mergeCell.GetStartAxis() + ":" + mergeCell.GetEndAxis())
+		if err != nil {
+			return nil, err
+		}
+		for row := max(mergeStartRow, startRow); row <= min(mergeEndRow, endRow); row++ {
+			for col := max(mergeStartCol, startCol); col <= min(mergeEndCol, endCol); col++ {
+				origins[cellCoordinates{col, row}] = cellCoordinates{mergeStartCol, mergeStartRow}
+			}
+		}
+	}
+
+	formatted := map[int]bool{}
+	result := make([][]CellData, endRow-startRow+1)
+	for i := range result {
+		result[i] = make([]CellData, endCol-startCol+1)
+		for j := range result[i] {
+			key := cellCoordinates{startCol + j, startRow + i}
+			cell := &result[i][j]
+			// excelize falls back to the row style, then to the column style
+			if c := cells[key]; c != nil && c.S != 0 {
+				cell.StyleID = c.S
+			} else if styleID := rowStyles[key.row]; styleID != 0 {
+				cell.StyleID = styleID
+			} else {
+				for _, colElement := range worksheet.Cols {
+					if colElement.Min <= key.col && key.col <= colElement.Max && colElement.Style != 0 {
+						cell.StyleID = colElement.Style
+						break
+					}
+				}
+			}
+
+			origin, ok := origins[key]
+			if !ok {
+				origin = key
+			}
+			axis, err := excelize.CoordinatesToCellName(origin.col, origin.row)
+			if err != nil {
+				return nil, err
+			}
+			c := cells[origin]
+			if c == nil && (origin.col < startCol || origin.row < startRow) {
+				// the top left cell of the merged cell is out of the range.
+				// The raw value is read first, as excelize reformats the number in memory when it reads the value
+				if cell.RawValue, _, err = w.GetRawValue(axis); err != nil {
+					return nil, err
+				}
+				if cell.Value, cell.Formula, err = w.getValueAndFormula(axis); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if c == nil {
+				continue
+			}
+			if c.F != nil {
+				formula := c.F.Content
+				if c.F.T == "shared" && c.F.Si != nil {
+					if master := sharedFormulas[*c.F.Si]; master != nil {
+						formula = ShiftFormula(master.F.Content, origin.col-master.col, origin.row-master.row)
+					} else if formula, err = w.file.GetCellFormula(w.sheetName, axis); err != nil {
+						return nil, fmt.Errorf("failed to get formula: %w", err)
+					}
+				}
+				if formula != "" {
+					cell.Formula = "=" + strings.TrimPrefix(formula, "=")
+				}
+			}
+			if cell.Value, cell.RawValue, err = w.readCellValue(c, axis, formatted); err != nil {
+				return nil, err
+			}
+			if cell.Value == "" && cell.Formula != "" {
+				// try to get calculated value. A formula which fails to calculate has its error value (e.g. #NAME?),
+				// not to fail reading the whole range
+				cell.Value, _ = w.file.CalcCellValue(w.sheetName, axis)
+			}
+		}
+	}
+	return result, nil
+}
+
+// readRows reads the cols element and the row elements from startRow to endRow of the worksheet.
+// The rows are read from the worksheet parsed by excelize through reflection, as excelize does not export it,
+// and marshaling it to XML takes longer than looking up each cell. The sheet XML is read instead if the structure differs.
+func (w *ExcelizeWorksheet) readRows(startRow, endRow int) (worksheet *xlsxWorksheetCellsRead, err error) {
+	sheetXML, err := w.sheetXMLPath()
+	if err != nil {
+		return nil, err
+	}
+	// excelize parses the worksheet when it is read first
+	if _, err := w.file.GetSheetDimension(w.sheetName); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if recover() != nil {
+			worksheet = &xlsxWorksheetCellsRead{}
+			if err = xml.Unmarshal(w.readPart(sheetXML), worksheet); err != nil {
+				err = fmt.Errorf("failed to read worksheet: %w", err)
+			}
+		}
+	}()
+	parsed, ok := w.file.Sheet.Load(sheetXML)
+	if !ok || parsed == nil {
+		panic("worksheet is not parsed")
+	}
+	value := reflect.ValueOf(parsed).Elem()
+	worksheet = &xlsxWorksheetCellsRead{}
+	if cols := value.FieldByName("Cols"); !cols.IsNil() {
+		colElements := cols.Elem().FieldByName("Col")
+		for i := range colElements.Len() {
+			colElement := colElements.Index(i)
+			worksheet.Cols = append(worksheet.Cols, xlsxColRead{
+				Min:   int(colElement.FieldByName("Min").Int()),
+				Max:   int(colElement.FieldByName("Max").Int()),
+				Style: int(colElement.FieldByName("Style").Int()),
+			})
+		}
+	}
+	// excelize keeps a row element for every row up to the last one.
+	// Fields are looked up by name once, as it takes longer than reading them
+	rowElements := value.FieldByName("SheetData").FieldByName("Row")
+	fieldIndexes := func(structType reflect.Type, names ...string) []int {
+		indexes := make([]int, len(names))
+		for i, name := range names {
+			field, ok := structType.FieldByName(name)
+			if !ok {
+				panic("field not found: " + name)
+			}
+			indexes[i] = field.Index[0]
+		}
+		return indexes
+	}
+	rowFields := fieldIndexes(rowElements.Type().Elem(), "R", "S", "C")
+	cellFields := fieldIndexes(rowElements.Type().Elem().Field(rowFields[2]).Type.Elem(), "R", "S", "T", "V", "IS", "F")
+	var formulaFields []int
+	for i := startRow - 1; i < min(endRow, rowElements.Len()); i++ {
+		rowElement := rowElements.Index(i)
+		cellElements := rowElement.Field(rowFields[2])
+		rowRead := xlsxRowCellsRead{
+			R:     max(int(rowElement.Field(rowFields[0]).Int()), i+1),
+			S:     int(rowElement.Field(rowFields[1]).Int()),
+			Cells: make([]xlsxCRead, cellElements.Len()),
+		}
+		for j := range rowRead.Cells {
+			cellElement := cellElements.Index(j)
+			c := &rowRead.Cells[j]
+			c.R = cellElement.Field(cellFields[0]).String()
+			c.S = int(cellElement.Field(cellFields[1]).Int())
+			c.T = cellElement.Field(cellFields[2]).String()
+			c.V = cellElement.Field(cellFields[3]).String()
+			if !cellElement.Field(cellFields[4]).IsNil() {
+				c.IS = &struct{}{}
+			}
+			if f := cellElement.Field(cellFields[5]); !f.IsNil() {
+				f = f.Elem()
+				if formulaFields == nil {
+					formulaFields = fieldIndexes(f.Type(), "Content", "T", "Ref", "Si")
+				}
+				c.F = &xlsxFRead{
+					Content: f.Field(formulaFields[0]).String(),
+					T:       f.Field(formulaFields[1]).String(),
+					Ref:     f.Field(formulaFields[2]).String(),
+				}
+				if si := f.Field(formulaFields[3]); !si.IsNil() {
+					c.F.Si = new(int)
+					*c.F.Si = int(si.Elem().Int())
+				}
+			}
+		}
+		worksheet.Rows = append(worksheet.Rows, rowRead)
+	}
+	return worksheet, nil
+}
+
+// filledCells tells which cells of the range have values or formulas, from the row elements without reading values.
+// A cell covered by a merged cell is filled if the top left cell is, as GetRangeValues returns its value.
+func (w *ExcelizeWorksheet) filledCells(rangeStr string) ([][]bool, error) {
+	startCol, startRow, endCol, endRow, err := ParseRange(rangeStr)
+	if err != nil {
+		return nil, err
+	}
+	worksheet, err := w.readRows(startRow, endRow)
+	if err != nil {
+		return nil, err
+	}
+	filled := make([][]bool, endRow-startRow+1)
+	for i := range filled {
+		filled[i] = make([]bool, endCol-startCol+1)
+	}
+	row := 0
+	for _, rowElement := range worksheet.Rows {
+		// a row or cell element without the reference follows the previous one
+		row = max(row+1, rowElement.R)
+		if row < startRow {
+			continue
+		}
+		if row > endRow {
+			break
+		}
+		col := 0
+		for _, c := range rowElement.Cells {
+			if cellCol, _, err := excelize.CellNameToCoordinates(c.R); err == nil {
+				col = cellCol
+			} else {
+				col++
+			}
+			if startCol <= col && col <= endCol {
+				hasFormula := c.F != nil && (c.F.Content != "" || c.F.T == "shared")
+				filled[row-startRow][col-startCol] = c.V != "" || c.IS != nil || hasFormula
+			}
+		}
+	}
+
+	mergeCells, err := w.file.GetMergeCells(w.sheetName)
+	if err != nil {
+		return nil, err
+	}
+	for _, mergeCell := range mergeCells {
+		mergeStartCol, mergeStartRow, mergeEndCol, mergeEndRow, err := ParseRange(mergeCell.GetStartAxis() + ":" + mergeCell.GetEndAxis())
+		if err != nil {
+			return nil, err
+		}
+		originFilled := mergeCell.GetCellValue() != ""
+		if startCol <= mergeStartCol && mergeStartCol <= endCol && startRow <= mergeStartRow && mergeStartRow <= endRow {
+			originFilled = filled[mergeStartRow-startRow][mergeStartCol-startCol]
+		}
+		if !originFilled {
+			continue
+		}
+		for row := max(mergeStartRow, startRow); row <= min(mergeEndRow, endRow); row++ {
+			for col := max(mergeStartCol, startCol); col <= min(mergeEndCol, endCol); col++ {
+				filled[row-startRow][col-startCol] = true
+			}
+		}
+	}
+	return filled, nil
+}
+
+// readCellValue returns the value as GetValue does and the value as GetRawValue does, of the cell element.
+// Numbers of a cell with a style are formatted by excelize, as even the General format rounds them.
+// formatted caches whether the number format of each style changes text.
+func (w *ExcelizeWorksheet) readCellValue(c *xlsxCRead, axis string, formatted map[int]bool) (string, string, error) {
+	if c.V == "" && c.IS == nil {
+		return "", "", nil
+	}
+	if _, ok := formatted[c.S]; !ok && c.S != 0 {
+		style, err := w.file.GetStyle(c.S)
+		if err != nil {
+			return "", "", err
+		}
+		formatted[c.S] = style.NumFmt != 0 || style.CustomNumFmt != nil
+	}
+	switch c.T {
+	case "b":
+		if c.V == "1" || strings.EqualFold(c.V, "TRUE") {
+			return "TRUE", "TRUE", nil
+		}
+		return "FALSE", "FALSE", nil
+	case "e", "str":
+		return c.V, c.V, nil
+	case "s":
+		// shared strings are loaded by excelize when a cell of them is read first
+		if sharedStrings := w.file.SharedStrings; sharedStrings != nil && !formatted[c.S] {
+			if index, err := strconv.Atoi(strings.TrimSpace(c.V)); err == nil && 0 <= index && index < len(sharedStrings.SI) {
+				value := sharedStrings.SI[index].String()
+				return value, value, nil
+			}
+		}
+	case "", "n":
+		if c.S == 0 {
+			return formatGeneralNumber(c.V), c.V, nil
+		}
+		value, err := w.file.GetCellValue(w.sheetName, axis)
+		return value, c.V, err
+	}
+	value, err := w.file.GetCellValue(w.sheetName, axis)
+	if err != nil {
+		return "", "", err
+	}
+	rawValue, _, err := w.GetRawValue(axis)
+	if err != nil {
+		return "", "", err
+	}
+	return value, rawValue, nil
+}
+
+// getValueAndFormula returns the value as GetValue does, and the formula with "=" or an empty string.
+// Unlike GetValue, a formula which fails to calculate does not return an error.
+func (w *ExcelizeWorksheet) getValueAndFormula(cell string) (string, string, error) {
+	value, err := w.file.GetCellValue(w.sheetName, cell)
+	if err != nil {
+		return "", "", err
+	}
+	formula, err := w.file.GetCellFormula(w.sheetName, cell)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get formula: %w", err)
+	}
+	if formula == "" {
+		return value, "", nil
+	}
+	if value == "" {
+		// try to get calculated value. A formula which fails to calculate has its error value (e.g. #NAME?),
+		// not to fail reading the whole range
+		value, _ = w.file.CalcCellValue(w.sheetName, cell)
+	}
+	return value, "=" + strings.TrimPrefix(formula, "="), nil
+}
+
+// formatGeneralNumber formats the number as excelize does for a cell without a number format.
+// A number with more than 15 digits is rounded to 15 significant digits.
+func formatGeneralNumber(value string) string {
+	if strings.Contains(value, "_") {
+		return value
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+	formatted := strconv.FormatFloat(number, 'f', -1, 64)
+	if len(strings.ReplaceAll(formatted, ".", "")) > 15 {
+		return strconv.FormatFloat(number, 'G', 15, 64)
+	}
+	return formatted
+}
+
+func (w *ExcelizeWorksheet) GetDimention() (string, error) {
+	return w.file.GetSheetDimension(w.sheetName)
+}
+
+func (w *ExcelizeWorksheet) GetPagingStrategy(pageSize int) (PagingStrategy, error) {
+	printArea, err := w.PrintArea()
+	if err != nil {
+		return nil, err
+	}
+	if printArea != "" {
+		return NewPrintAreaPagingStrategy(pageSize, w)
+	}
+	regionStrategy, err := newRegionPagingStrategy(pageSize, w)
+	if err != nil {
+		return nil, err
+	}
+	if regionStrategy != nil {
+		return regionStrategy, nil
+	}
+	dimension, err := w.GetDimention()
+	if err != nil {
+		return nil, err
+	}
+	if IsWideForPaging(dimension, pageSize) {
+		return NewTiledPagingStrategy(pageSize, w)
+	}
+	return NewExcelizeFixedSizePagingStrategy(pageSize, w)
+}
+
+// PrintArea returns the areas of the _xlnm.Print_Area defined name of the worksheet without the sheet name
+// (e.g. $A$1:$D$20), or an empty string if the print area is not set.
+func (w *ExcelizeWorksheet) PrintArea() (string, error) {
+	for _, definedName := range w.file.GetDefinedName() {
+		if definedName.Name != "_xlnm.Print_Area" || !strings.EqualFold(definedName.Scope, w.sheetName) {
+			continue
+		}
+		var areas []string
+		for _, area := range SplitRangeAreas(strings.TrimPrefix(definedName.RefersTo, "=")) {
+			_, ref := SplitSheetName(area)
+			areas = append(areas, ref)
+		}
+		return strings.Join(areas, ","), nil
+	}
+	return "", nil
+}
+
+// HPageBreaks returns the first rows of the pages after the row breaks in the sheet XML.
+// A row break has the number of the row above it.
+func (w *ExcelizeWorksheet) HPageBreaks() ([]int, error) {
+	sheetXML, err := w.sheetXMLPath()
+	if err != nil {
+		return nil, err
+	}
+	var worksheet xlsxWorksheetRowBreaksRead
+	if err := xml.Unmarshal(w.readPart(sheetXML), &worksheet); err != nil {
+		return nil, fmt.Errorf("failed to read worksheet: %w", err)
+	}
+	if worksheet.RowBreaks == nil {
+		return nil, nil
+	}
+	var pageBreaks []int
+	for _, brk := range worksheet.RowBreaks.Brk {
+		pageBreaks = append(pageBreaks, brk.ID+1)
+	}
+	slices.Sort(pageBreaks)
+	return pageBreaks, nil
+}
+
+func (w *ExcelizeWorksheet) CapturePicture(captureRange string) (string, error) {
+	data, err := renderRange(w.file, w.sheetName, captureRange)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+func (w *ExcelizeWorksheet) AddTable(tableRange, tableName string) error {
+	enable := true
+	if err := w.file.AddTable(w.sheetName, &excelize.Table{
+		Range:             tableRange,
+		Name:              tableName,
+		StyleName:         "TableStyleMedium2",
+		ShowColumnStripes: true,
+		ShowFirstColumn:   false,
+		ShowHeaderRow:     &enable,
+		ShowLastColumn:    false,
+		ShowRowStripes:    &enable,
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) GetCellStyle(cell string) (*CellStyle, error) {
+	styleID, err := w.file.GetCellStyle(w.sheetName, cell)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cell style: %w", err)
+	}
+
+	style, err := w.file.GetStyle(styleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get style details: %w", err)
+	}
+
+	return convertExcelizeStyleToCellStyle(style), nil
+}
+
+func (w *ExcelizeWorksheet) SetCellStyle(cell string, style *CellStyle) error {
+	excelizeStyle := convertCellStyleToExcelizeStyle(style)
+
+	styleID, err := w.file.NewStyle(excelizeStyle)
+	if err != nil {
+		return fmt.Errorf("failed to create style: %w", err)
+	}
+
+	if err := w.file.SetCellStyle(w.sheetName, cell, cell, styleID); err != nil {
+		return fmt.Errorf("failed to set cell style: %w", err)
+	}
+
+	return nil
+}
+
+func (w *ExcelizeWorksheet) GetDataValidations() ([]DataValidation, error) {
+	dataValidations, err := w.file.GetDataValidations(w.sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data validations: %w", err)
+	}
+	result := make([]DataValidation, len(dataValidations))
+	for i, dv := range dataValidations {
+		validation := DataValidation{
+			Range:      sqrefToRange(dv.Sqref),
+			Type:       DataValidationType(dv.Type),
+			Operator:   DataValidationOperator(dv.Operator),
+			Formula1:   dv.Formula1,
+			Formula2:   dv.Formula2,
+			AllowBlank: dv.AllowBlank,
+		}
+		if validation.Type == DataValidationTypeList {
+			validation.Operator = ""
+			if list, ok := parseListFormula(dv.Formula1); ok {
+				validation.List = list
+				validation.Formula1 = ""
+			}
+		}
+		if dv.ShowInputMessage {
+			validation.InputTitle = derefString(dv.PromptTitle)
+			validation.InputMessage = derefString(dv.Prompt)
+		}
+		if dv.ShowErrorMessage {
+			validation.ErrorStyle = DataValidationErrorStyle(derefString(dv.ErrorStyle))
+			validation.ErrorTitle = derefString(dv.ErrorTitle)
+			validation.ErrorMessage = derefString(dv.Error)
+		}
+		result[i] = validation
+	}
+	return result, nil
+}
+
+func (w *ExcelizeWorksheet) AddDataValidation(validation *DataValidation) error {
+	dv := excelize.NewDataValidation(validation.AllowBlank)
+	dv.Sqref = rangeToSqref(validation.Range)
+	if validation.Type == DataValidationTypeList && len(validation.List) > 0 {
+		if err := dv.SetDropList(validation.List); err != nil {
+			return fmt.Errorf("failed to set list: %w", err)
+		}
+	} else {
+		dv.Type = validation.Type.String()
+		dv.Operator = validation.Operator.String()
+		dv.Formula1 = dataValidationFormulaEscaper.Replace(strings.TrimPrefix(validation.Formula1, "="))
+		dv.Formula2 = dataValidationFormulaEscaper.Replace(strings.TrimPrefix(validation.Formula2, "="))
+	}
+	if validation.InputTitle != "" || validation.InputMessage != "" {
+		dv.SetInput(validation.InputTitle, validation.InputMessage)
+	}
+	if validation.ErrorStyle != "" || validation.ErrorTitle != "" || validation.ErrorMessage != "" {
+		errorStyle := excelize.DataValidationErrorStyleStop
+		switch validation.ErrorStyle {
+		case DataValidationErrorStyleWarning:
+			errorStyle = excelize.DataValidationErrorStyleWarning
+		case DataValidationErrorStyleInformation:
+			errorStyle = excelize.DataValidationErrorStyleInformation
+		}
+		dv.SetError(errorStyle, validation.ErrorTitle, validation.ErrorMessage)
+	}
+	if err := w.file.AddDataValidation(w.sheetName, dv); err != nil {
+		return fmt.Errorf("failed to add data validation: %w", err)
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) DeleteDataValidation(rangeStr string) error {
+	if err := w.file.DeleteDataValidation(w.sheetName, rangeToSqref(rangeStr)); err != nil {
+		return fmt.Errorf("failed to delete data validation: %w", err)
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) GetConditionalFormats() ([]ConditionalFormat, error) {
+	conditionalFormats, err := w.file.GetConditionalFormats(w.sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conditional formats: %w", err)
+	}
+	sqrefs := make([]string, 0, len(conditionalFormats))
+	for sqref := range conditionalFormats {
+		sqrefs = append(sqrefs, sqref)
+	}
+	sort.Strings(sqrefs)
+
+	var result []ConditionalFormat
+	for _, sqref := range sqrefs {
+		for _, opts := range conditionalFormats[sqref] {
+			format := ConditionalFormat{
+				Range:      sqrefToRange(sqref),
+				StopIfTrue: opts.StopIfTrue,
+			}
+			switch opts.Type {
+			case "cell":
+				format.Type = ConditionalFormatTypeCellValue
+				format.Operator = excelizeCriteriaToConditionalFormatOperator[opts.Criteria]
+				if opts.Value != "" {
+					format.Formula1 = opts.Value
+				} else {
+					format.Formula1, format.Formula2 = opts.MinValue, opts.MaxValue
+				}
+			case "formula":
+				format.Type = ConditionalFormatTypeFormula
+				format.Formula1 = opts.Criteria
+			case "top", "bottom":
+				format.Type = ConditionalFormatType(opts.Type)
+				format.Rank, _ = strconv.Atoi(opts.Value)
+				format.Percent = opts.Percent
+			case "duplicate", "unique":
+				format.Type = ConditionalFormatType(opts.Type)
+			case "2_color_scale", "3_color_scale":
+				format.Type = ConditionalFormatTypeColorScale
+				format.MinColor, format.MidColor, format.MaxColor = opts.MinColor, opts.MidColor, opts.MaxColor
+			case "data_bar":
+				format.Type = ConditionalFormatTypeDataBar
+				format.BarColor = opts.BarColor
+			case "icon_set":
+				format.Type = ConditionalFormatTypeIconSet
+				format.IconStyle = opts.IconStyle
+				format.ReverseIcons = opts.ReverseIcons
+			default:
+				// other rules (e.g. average, text, blanks) are reported with the type name of excelize
+				format.Type = ConditionalFormatType(opts.Type)
+				format.Formula1 = opts.Value
+			}
+			if opts.Format != nil {
+				style, err := w.file.GetConditionalStyle(*opts.Format)
+				if err == nil && style != nil {
+					format.Style = convertExcelizeStyleToCellStyle(style)
+				}
+			}
+			result = append(result, format)
+		}
+	}
+	return result, nil
+}
+
+func (w *ExcelizeWorksheet) AddConditionalFormat(format *ConditionalFormat) error {
+	opts := excelize.ConditionalFormatOptions{
+		Criteria:   "=",
+		StopIfTrue: format.StopIfTrue,
+	}
+	switch format.Type {
+	case ConditionalFormatTypeCellValue:
+		opts.Type = "cell"
+		opts.Criteria = conditionalFormatOperatorToExcelizeCriteria[format.Operator]
+		if format.Operator == ConditionalFormatOperatorBetween || format.Operator == ConditionalFormatOperatorNotBetween {
+			opts.MinValue = strings.TrimPrefix(format.Formula1, "=")
+			opts.MaxValue = strings.TrimPrefix(format.Formula2, "=")
+		} else {
+			opts.Value = strings.TrimPrefix(format.Formula1, "=")
+		}
+	case ConditionalFormatTypeFormula:
+		opts.Type = "formula"
+		opts.Criteria = strings.TrimPrefix(format.Formula1, "=")
+	case ConditionalFormatTypeTop, ConditionalFormatTypeBottom:
+		opts.Type = format.Type.String()
+		opts.Value = strconv.Itoa(format.Rank)
+		opts.Percent = format.Percent
+	case ConditionalFormatTypeDuplicate, ConditionalFormatTypeUnique:
+		opts.Type = format.Type.String()
+	case ConditionalFormatTypeColorScale:
+		opts.Type = "2_color_scale"
+		opts.MinType, opts.MinColor = "min", format.MinColor
+		opts.MaxType, opts.MaxColor = "max", format.MaxColor
+		if format.MidColor != "" {
+			opts.Type = "3_color_scale"
+			opts.MidType, opts.MidColor = "percentile", format.MidColor
+		}
+	case ConditionalFormatTypeDataBar:
+		opts.Type = "data_bar"
+		opts.MinType, opts.MaxType = "min", "max"
+		opts.BarColor = format.BarColor
+	case ConditionalFormatTypeIconSet:
+		opts.Type = "icon_set"
+		opts.IconStyle = format.IconStyle
+		opts.ReverseIcons = format.ReverseIcons
+	default:
+		return fmt.Errorf("unsupported conditional format type: %s", format.Type)
+	}
+	if format.Style != nil {
+		styleID, err := w.file.NewConditionalStyle(convertCellStyleToExcelizeStyle(format.Style))
+		if err != nil {
+			return fmt.Errorf("failed to create conditional style: %w", err)
+		}
+		opts.Format = &styleID
+	}
+	// Rules for the same range are merged into one block, as excelize reads the rules by range
+	sqref := rangeToSqref(format.Range)
+	conditionalFormats, err := w.file.GetConditionalFormats(w.sheetName)
+	if err != nil {
+		return fmt.Errorf("failed to get conditional formats: %w", err)
+	}
+	rules := append(conditionalFormats[sqref], opts)
+	if err := w.file.UnsetConditionalFormat(w.sheetName, sqref); err != nil {
+		return fmt.Errorf("failed to add conditional format: %w", err)
+	}
+	if err := w.file.SetConditionalFormat(w.sheetName, sqref, rules); err != nil {
+		return fmt.Errorf("failed to add conditional format: %w", err)
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) GetComments() ([]Comment, error) {
+	comments, err := w.file.GetComments(w.sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	result := make([]Comment, len(comments))
+	for i, comment := range comments {
+		text := comment.Text
+		for _, run := range comment.Paragraph {
+			text += run.Text
+		}
+		result[i] = Comment{
+			Cell:   comment.Cell,
+			Author: comment.Author,
+			Text:   trimCommentAuthor(text, comment.Author),
+		}
+	}
+	return result, nil
+}
+
+func (w *ExcelizeWorksheet) SetComment(comment Comment) error {
+	if err := w.file.DeleteComment(w.sheetName, comment.Cell); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	// Excel shows the author in bold at the beginning of notes
+	var paragraph []excelize.RichTextRun
+	if comment.Author != "" {
+		paragraph = append(paragraph, excelize.RichTextRun{Text: comment.Author + ":\n", Font: &excelize.Font{Bold: true}})
+	}
+	paragraph = append(paragraph, excelize.RichTextRun{Text: comment.Text})
+	if err := w.file.AddComment(w.sheetName, excelize.Comment{
+		Cell:      comment.Cell,
+		Author:    comment.Author,
+		Paragraph: paragraph,
+	}); err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) DeleteComment(cell string) error {
+	if err := w.file.DeleteComment(w.sheetName, cell); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) GetHyperlinks(rangeStr string) ([]Hyperlink, error) {
+	startCol, startRow, endCol, endRow, err := ParseRange(rangeStr)
+	if err != nil {
+		return nil, err
+	}
+	var hyperlinks []Hyperlink
+	for row := startRow; row <= endRow; row++ {
+		for col := startCol; col <= endCol; col++ {
+			cell, err := excelize.CoordinatesToCellName(col, row)
+			if err != nil {
+				return nil, err
+			}
+			ok, target, err := w.file.GetCellHyperLink(w.sheetName, cell)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get hyperlink: %w", err)
+			}
+			if !ok {
+				continue
+			}
+			// excelize returns either the external target or the location
+			hyperlink := Hyperlink{Cell: cell}
+			if isWorkbookLocation(target) {
+				hyperlink.Location = target
+			} else {
+				hyperlink.Address, hyperlink.Location, _ = strings.Cut(target, "#")
+			}
+			hyperlinks = append(hyperlinks, hyperlink)
+		}
+	}
+	return hyperlinks, nil
+}
+
+func (w *ExcelizeWorksheet) SetHyperlink(hyperlink Hyperlink) error {
+	link, linkType := hyperlink.Location, "Location"
+	if hyperlink.Address != "" {
+		link, linkType = hyperlink.Address, "External"
+		if hyperlink.Location != "" {
+			link += "#" + hyperlink.Location
+		}
+	}
+	var opts excelize.HyperlinkOpts
+	if hyperlink.Display != "" {
+		opts.Display = &hyperlink.Display
+	}
+	if hyperlink.Tooltip != "" {
+		opts.Tooltip = &hyperlink.Tooltip
+	}
+	if err := w.DeleteHyperlink(hyperlink.Cell); err != nil {
+		return err
+	}
+	if err := w.file.SetCellHyperLink(w.sheetName, hyperlink.Cell, link, linkType, opts); err != nil {
+		return fmt.Errorf("failed to set hyperlink: %w", err)
+	}
+	// The display text is the value of the cell
+	if hyperlink.Display != "" {
+		if err := w.SetValue(hyperlink.Cell, hyperlink.Display); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) DeleteHyperlink(cell string) error {
+	if err := w.file.SetCellHyperLink(w.sheetName, cell, "", "None"); err != nil {
+		return fmt.Errorf("failed to delete hyperlink: %w", err)
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) GetCharts() ([]Chart, error) {
+	// excelize has no API to read charts, so the drawing and chart parts of the package are parsed
+	sheetXML, err := w.sheetXMLPath()
+	if err != nil {
+		return nil, err
+	}
+	var charts []Chart
+	for _, sheetRel := range w.readRelationships(sheetXML) {
+		if !strings.HasSuffix(sheetRel.Type, "/drawing") {
+			continue
+		}
+		drawingXML := resolvePartPath(sheetXML, sheetRel.Target)
+		drawingRels := w.readRelationships(drawingXML)
+		var drawing xlsxDrawingRead
+		if err := xml.Unmarshal(w.readPart(drawingXML), &drawing); err != nil {
+			return nil, fmt.Errorf("failed to read drawing: %w", err)
+		}
+		for _, drawingAnchor := range drawing.Anchors {
+			if drawingAnchor.GraphicFrame == nil || drawingAnchor.GraphicFrame.Chart.RID == "" {
+				continue
+			}
+			var chartXML string
+			for _, rel := range drawingRels {
+				if rel.ID == drawingAnchor.GraphicFrame.Chart.RID {
+					chartXML = resolvePartPath(drawingXML, rel.Target)
+				}
+			}
+			var chartSpace xlsxChartSpaceRead
+			if err := xml.Unmarshal(w.readPart(chartXML), &chartSpace); err != nil {
+				return nil, fmt.Errorf("failed to read chart: %w", err)
+			}
+			chart := chartSpace.toChart()
+			chart.Name = drawingAnchor.GraphicFrame.NvGraphicFramePr.CNvPr.Name
+			chart.Anchor = drawingAnchor.anchorRange()
+			charts = append(charts, chart)
+		}
+	}
+	return charts, nil
+}
+
+func (w *ExcelizeWorksheet) AddChart(chart *Chart) error {
+	// Series are grouped by the chart type. The first group is the primary chart and the others are combined to it.
+	var groups []*excelize.Chart
+	groupByType := map[ChartType]*excelize.Chart{}
+	for _, series := range chart.Series {
+		seriesType := chart.Type
+		if chart.Type == ChartTypeCombo {
+			seriesType = series.Type
+			if seriesType == "" || seriesType == ChartTypeCombo {
+				seriesType = ChartTypeColumn
+			}
+		}
+		group, exists := groupByType[seriesType]
+		if !exists {
+			group = &excelize.Chart{Type: chartTypeToExcelize(seriesType)}
+			groupByType[seriesType] = group
+			groups = append(groups, group)
+		}
+		group.Series = append(group.Series, excelize.ChartSeries{
+			Name:       series.Name,
+			Categories: series.Categories,
+			Values:     series.Values,
+		})
+	}
+	if len(groups) == 0 {
+		return fmt.Errorf("chart requires at least one series")
+	}
+	primary := groups[0]
+	if chart.Title != "" {
+		primary.Title = []excelize.RichTextRun{{Text: chart.Title}}
+	}
+	if chart.XAxisTitle != "" {
+		primary.XAxis.Title = []excelize.RichTextRun{{Text: chart.XAxisTitle}}
+	}
+	if chart.YAxisTitle != "" {
+		primary.YAxis.Title = []excelize.RichTextRun{{Text: chart.YAxisTitle}}
+	}
+	primary.Legend.Position = chart.LegendPosition.String()
+	if chart.LegendPosition == ChartLegendPositionTopRight {
+		primary.Legend.Position = "top_right"
+	}
+	primary.Dimension = excelize.ChartDimension{Width: uint(chart.Width), Height: uint(chart.Height)}
+	if err := w.file.AddChart(w.sheetName, chart.Anchor, primary, groups[1:]...); err != nil {
+		return fmt.Errorf("failed to add chart: %w", err)
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) GetPictures(rangeStr string) ([]Picture, error) {
+	cells, err := w.file.GetPictureCells(w.sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get picture cells: %w", err)
+	}
+	var areas [][4]int
+	if rangeStr != "" {
+		for _, area := range SplitRangeAreas(rangeStr) {
+			startCol, startRow, endCol, endRow, err := ParseRange(area)
+			if err != nil {
+				return nil, err
+			}
+			areas = append(areas, [4]int{startCol, startRow, endCol, endRow})
+		}
+	}
+	type pictureCell struct {
+		name     string
+		col, row int
+	}
+	var targets []pictureCell
+	for _, cell := range cells {
+		col, row, err := excelize.CellNameToCoordinates(cell)
+		if err != nil {
+			return nil, err
+		}
+		inRange := len(areas) == 0
+		for _, area := range areas {
+			if area[0] <= col && col <= area[2] && area[1] <= row && row <= area[3] {
+				inRange = true
+				break
+			}
+		}
+		if inRange {
+			targets = append(targets, pictureCell{cell, col, row})
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].row != targets[j].row {
+			return targets[i].row < targets[j].row
+		}
+		return targets[i].col < targets[j].col
+	})
+
+	var pictures []Picture
+	for _, target := range targets {
+		pics, err := w.file.GetPictures(w.sheetName, target.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pictures: %w", err)
+		}
+		for _, pic := range pics {
+			picture := Picture{
+				Cell:      target.name,
+				Extension: strings.ToLower(pic.Extension),
+				Data:      pic.File,
+			}
+			if pic.Format != nil {
+				picture.AltText = pic.Format.AltText
+			}
+			pictures = append(pictures, picture)
+		}
+	}
+	return pictures, nil
+}
+
+func (w *ExcelizeWorksheet) AddPicture(picture *Picture) error {
+	if err := w.file.AddPictureFromBytes(w.sheetName, picture.Cell, &excelize.Picture{
+		Extension: picture.Extension,
+		File:      picture.Data,
+		Format: &excelize.GraphicOptions{
+			AltText:         picture.AltText,
+			ScaleX:          picture.ScaleX,
+			ScaleY:          picture.ScaleY,
+			LockAspectRatio: picture.ScaleX == picture.ScaleY,
+			Positioning:     "oneCell",
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to add picture: %w", err)
+	}
+	return nil
+}
+
+// ClearRange clears values and formulas of the cells within the dimension, styles of the existing cells, unmerges
+// merged cells, and deletes comments, hyperlinks and data validations in the range, as the modes select.
+func (w *ExcelizeWorksheet) ClearRange(rangeStr string, modes []ClearMode) error {
+	startCol, startRow, endCol, endRow, err := ParseRange(rangeStr)
+	if err != nil {
+		return err
+	}
+	// cells out of the dimension have nothing to clear
+	dimension, err := w.GetDimention()
+	if err != nil {
+		return err
+	}
+	dimStartCol, dimStartRow, dimEndCol, dimEndRow, err := ParseRange(dimension)
+	cellStartCol, cellStartRow := max(startCol, dimStartCol), max(startRow, dimStartRow)
+	cellEndCol, cellEndRow := min(endCol, dimEndCol), min(endRow, dimEndRow)
+	hasCells := err == nil && cellStartCol <= cellEndCol && cellStartRow <= cellEndRow
+
+	clearValues, clearFormulas := hasClearMode(modes, ClearModeValue), hasClearMode(modes, ClearModeFormula)
+	if hasCells && (clearValues || clearFormulas) {
+		for row := cellStartRow; row <= cellEndRow; row++ {
+			for col := cellStartCol; col <= cellEndCol; col++ {
+				cell, err := excelize.CoordinatesToCellName(col, row)
+				if err != nil {
+					return err
+				}
+				formula, err := w.file.GetCellFormula(w.sheetName, cell)
+				if err != nil {
+					return err
+				}
+				if formula != "" && !clearFormulas || formula == "" && !clearValues {
+					continue
+				}
+				if err := w.clearCell(cell, formula != ""); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if hasClearMode(modes, ClearModeStyle) {
+		// merged cells within the range are unmerged as Excel does
+		mergeCells, err := w.file.GetMergeCells(w.sheetName)
+		if err != nil {
+			return err
+		}
+		for _, mergeCell := range mergeCells {
+			mStartCol, mStartRow, mEndCol, mEndRow, err := ParseRange(mergeCell.GetStartAxis() + ":" + mergeCell.GetEndAxis())
+			if err != nil {
+				continue
+			}
+			if startCol <= mStartCol && mEndCol <= endCol && startRow <= mStartRow && mEndRow <= endRow {
+				if err := w.file.UnmergeCell(w.sheetName, mergeCell.GetStartAxis(), mergeCell.GetEndAxis()); err != nil {
+					return err
+				}
+			}
+		}
+		// styled cells may be out of the dimension, while cells which do not exist have no style to clear
+		lastCol, lastRow, err := w.cellExtent()
+		if err != nil {
+			return err
+		}
+		if styleEndCol, styleEndRow := min(endCol, lastCol), min(endRow, lastRow); startCol <= styleEndCol && startRow <= styleEndRow {
+			topLeft, err := excelize.CoordinatesToCellName(startCol, startRow)
+			if err != nil {
+				return err
+			}
+			bottomRight, err := excelize.CoordinatesToCellName(styleEndCol, styleEndRow)
+			if err != nil {
+				return err
+			}
+			if err := w.file.SetCellStyle(w.sheetName, topLeft, bottomRight, 0); err != nil {
+				return err
+			}
+		}
+	}
+
+	if hasClearMode(modes, ClearModeComment) {
+		comments, err := w.GetComments()
+		if err != nil {
+			return err
+		}
+		for _, comment := range comments {
+			col, row, err := excelize.CellNameToCoordinates(comment.Cell)
+			if err != nil {
+				continue
+			}
+			if startCol <= col && col <= endCol && startRow <= row && row <= endRow {
+				if err := w.DeleteComment(comment.Cell); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if hasClearMode(modes, ClearModeHyperlink) {
+		hyperlinks, err := w.GetHyperlinks(rangeStr)
+		if err != nil {
+			return err
+		}
+		for _, hyperlink := range hyperlinks {
+			if err := w.DeleteHyperlink(hyperlink.Cell); err != nil {
+				return err
+			}
+		}
+	}
+
+	if hasClearMode(modes, ClearModeDataValidation) {
+		if err := w.DeleteDataValidation(rangeStr); err != nil {
+			return err
+		}
+	}
+
+	if hasCells && (clearValues || clearFormulas) {
+		return w.shrinkDimension()
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) CopyRange(rangeStr string, destSheetName string, destCell string, options CopyRangeOptions) error {
+	startCol, startRow, endCol, endRow, err := ParseRange(rangeStr)
+	if err != nil {
+		return err
+	}
+	destCol, destRow, err := excelize.CellNameToCoordinates(destCell)
+	if err != nil {
+		return err
+	}
+	dest := &ExcelizeWorksheet{file: w.file, sheetName: destSheetName}
+	// the destination may be this sheet
+	w.regions = dataRegionsCache{}
+	// target returns the destination of the source cell
+	target := func(col, row int) (int, int) {
+		if options.Transpose {
+			return destCol + row - startRow, destRow + col - startCol
+		}
+		return destCol + col - startCol, destRow + row - startRow
+	}
+	destEndCol, destEndRow := target(endCol, endRow)
+	if destEndCol > excelize.MaxColumns || destEndRow > excelize.TotalRows {
+		return fmt.Errorf("destination exceeds the sheet: %s", destCell)
+	}
+	copyContents := options.Mode != CopyModeFormat
+	copyStyles := options.Mode == CopyModeAll || options.Mode == CopyModeFormat
+
+	// read all cells before writing, as the destination may overlap the source
+	type sourceCell struct {
+		col, row int
+		value    any
+		formula  string
+		styleID  int
+	}
+	var cells []sourceCell
+	for row := startRow; row <= endRow; row++ {
+		for col := startCol; col <= endCol; col++ {
+			cell, err := excelize.CoordinatesToCellName(col, row)
+			if err != nil {
+				return err
+			}
+			source := sourceCell{col: col, row: row}
+			if copyContents {
+				if source.formula, err = w.file.GetCellFormula(w.sheetName, cell); err != nil {
+					return err
+				}
+				if source.value, err = w.typedCellValue(cell, source.formula != ""); err != nil {
+					return err
+				}
+			}
+			if copyStyles {
+				if source.styleID, err = w.file.GetCellStyle(w.sheetName, cell); err != nil {
+					return err
+				}
+			}
+			cells = append(cells, source)
+		}
+	}
+	var merges [][4]int
+	if copyStyles {
+		mergeCells, err := w.file.GetMergeCells(w.sheetName)
+		if err != nil {
+			return err
+		}
+		for _, mergeCell := range mergeCells {
+			mStartCol, mStartRow, mEndCol, mEndRow, err := ParseRange(mergeCell.GetStartAxis() + ":" + mergeCell.GetEndAxis())
+			if err != nil {
+				continue
+			}
+			if startCol <= mStartCol && mEndCol <= endCol && startRow <= mStartRow && mEndRow <= endRow {
+				merges = append(merges, [4]int{mStartCol, mStartRow, mEndCol, mEndRow})
+				if options.Move {
+					if err := w.file.UnmergeCell(w.sheetName, mergeCell.GetStartAxis(), mergeCell.GetEndAxis()); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	if options.Move {
+		// clear the source first, so that the overlapping destination is kept
+		for _, source := range cells {
+			cell, err := excelize.CoordinatesToCellName(source.col, source.row)
+			if err != nil {
+				return err
+			}
+			if err := w.clearCell(cell, source.formula != ""); err != nil {
+				return err
+			}
+			if err := w.file.SetCellStyle(w.sheetName, cell, cell, 0); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, source := range cells {
+		col, row := target(source.col, source.row)
+		cell, err := excelize.CoordinatesToCellName(col, row)
+		if err != nil {
+			return err
+		}
+		if copyContents {
+			formula, err := dest.file.GetCellFormula(dest.sheetName, cell)
+			if err != nil {
+				return err
+			}
+			if err := dest.clearCell(cell, formula != ""); err != nil {
+				return err
+			}
+			if source.formula != "" && options.Mode != CopyModeValue {
+				formula := source.formula
+				if !options.Move {
+					formula = ShiftFormula(formula, col-source.col, row-source.row)
+				}
+				if err := dest.file.SetCellFormula(dest.sheetName, cell, formula); err != nil {
+					return err
+				}
+			} else if source.value != nil {
+				if err := dest.file.SetCellValue(dest.sheetName, cell, source.value); err != nil {
+					return err
+				}
+			}
+		}
+		if copyStyles {
+			if err := dest.file.SetCellStyle(dest.sheetName, cell, cell, source.styleID); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, merge := range merges {
+		mStartCol, mStartRow := target(merge[0], merge[1])
+		mEndCol, mEndRow := target(merge[2], merge[3])
+		topLeft, err := excelize.CoordinatesToCellName(mStartCol, mStartRow)
+		if err != nil {
+			return err
+		}
+		bottomRight, err := excelize.CoordinatesToCellName(mEndCol, mEndRow)
+		if err != nil {
+			return err
+		}
+		if err := dest.file.MergeCell(dest.sheetName, topLeft, bottomRight); err != nil {
+			return err
+		}
+	}
+
+	if options.Move {
+		if err := w.shrinkDimension(); err != nil {
+			return err
+		}
+	}
+	if copyContents {
+		destTopLeft, err := excelize.CoordinatesToCellName(destCol, destRow)
+		if err != nil {
+			return err
+		}
+		destBottomRight, err := excelize.CoordinatesToCellName(destEndCol, destEndRow)
+		if err != nil {
+			return err
+		}
+		if err := dest.updateDimension(destTopLeft); err != nil {
+			return fmt.Errorf("failed to update dimension: %w", err)
+		}
+		if err := dest.updateDimension(destBottomRight); err != nil {
+			return fmt.Errorf("failed to update dimension: %w", err)
+		}
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) SortRange(rangeStr string, keys []SortKey, hasHeader bool) error {
+	startCol, startRow, endCol, endRow, err := ParseRange(rangeStr)
+	if err != nil {
+		return err
+	}
+	w.regions = dataRegionsCache{}
+	if hasHeader {
+		startRow++
+	}
+	if startRow >= endRow {
+		return nil
+	}
+	mergeCells, err := w.file.GetMergeCells(w.sheetName)
+	if err != nil {
+		return err
+	}
+	for _, mergeCell := range mergeCells {
+		mStartCol, mStartRow, mEndCol, mEndRow, err := ParseRange(mergeCell.GetStartAxis() + ":" + mergeCell.GetEndAxis())
+		if err != nil {
+			continue
+		}
+		if mStartCol <= endCol && startCol <= mEndCol && mStartRow <= endRow && startRow <= mEndRow {
+			return fmt.Errorf("cannot sort a range which contains merged cells: %s:%s", mergeCell.GetStartAxis(), mergeCell.GetEndAxis())
+		}
+	}
+
+	type sortCell struct {
+		value   any
+		formula string
+		styleID int
+	}
+	type sortRow struct {
+		row   int
+		cells []sortCell
+		keys  []sortValue
+	}
+	rows := make([]sortRow, 0, endRow-startRow+1)
+	for row := startRow; row <= endRow; row++ {
+		sorted := sortRow{row: row}
+		for col := startCol; col <= endCol; col++ {
+			cell, err := excelize.CoordinatesToCellName(col, row)
+			if err != nil {
+				return err
+			}
+			var source sortCell
+			if source.formula, err = w.file.GetCellFormula(w.sheetName, cell); err != nil {
+				return err
+			}
+			if source.value, err = w.typedCellValue(cell, source.formula != ""); err != nil {
+				return err
+			}
+			if source.styleID, err = w.file.GetCellStyle(w.sheetName, cell); err != nil {
+				return err
+			}
+			sorted.cells = append(sorted.cells, source)
+		}
+		for _, key := range keys {
+			if key.Column < startCol || endCol < key.Column {
+				return fmt.Errorf("sort key column is out of the range: %d", key.Column)
+			}
+			cell, err := excelize.CoordinatesToCellName(key.Column, row)
+			if err != nil {
+				return err
+			}
+			text, err := w.file.GetCellValue(w.sheetName, cell)
+			if err != nil {
+				return err
+			}
+			sorted.keys = append(sorted.keys, sortValue{value: sorted.cells[key.Column-startCol].value, text: text})
+		}
+		rows = append(rows, sorted)
+	}
+
+	slices.SortStableFunc(rows, func(a, b sortRow) int {
+		for i, key := range keys {
+			if result := compareSortValues(a.keys[i], b.keys[i], key); result != 0 {
+				return result
+			}
+		}
+		return 0
+	})
+
+	for i, sorted := range rows {
+		row := startRow + i
+		if sorted.row == row {
+			continue
+		}
+		for j, source := range sorted.cells {
+			cell, err := excelize.CoordinatesToCellName(startCol+j, row)
+			if err != nil {
+				return err
+			}
+			formula, err := w.file.GetCellFormula(w.sheetName, cell)
+			if err != nil {
+				return err
+			}
+			if err := w.clearCell(cell, formula != ""); err != nil {
+				return err
+			}
+			// relative references move with the row, as Excel does
+			if source.formula != "" {
+				if err := w.file.SetCellFormula(w.sheetName, cell, ShiftFormula(source.formula, 0, row-sorted.row)); err != nil {
+					return err
+				}
+			} else if source.value != nil {
+				if err := w.file.SetCellValue(w.sheetName, cell, source.value); err != nil {
+					return err
+				}
+			}
+			if err := w.file.SetCellStyle(w.sheetName, cell, cell, source.styleID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) GetAutoFilter() (*AutoFilter, error) {
+	sheetXML, err := w.sheetXMLPath()
+	if err != nil {
+		return nil, err
+	}
+	var worksheet xlsxWorksheetAutoFilterRead
+	if err := xml.Unmarshal(w.readPart(sheetXML), &worksheet); err != nil {
+		return nil, fmt.Errorf("failed to read worksheet: %w", err)
+	}
+	if worksheet.AutoFilter == nil {
+		return nil, nil
+	}
+	return worksheet.AutoFilter.toAutoFilter()
+}
+
+func (w *ExcelizeWorksheet) SetAutoFilter(autoFilter *AutoFilter) error {
+	current, err := w.GetAutoFilter()
+	if err != nil {
+		return err
+	}
+	// show the rows hidden by the current AutoFilter
+	if current != nil {
+		_, startRow, _, endRow, err := ParseRange(current.Range)
+		if err != nil {
+			return err
+		}
+		for row := startRow + 1; row <= endRow; row++ {
+			if err := w.file.SetRowVisible(w.sheetName, row, true); err != nil {
+				return err
+			}
+		}
+	}
+	if autoFilter == nil {
+		if current == nil {
+			return nil
+		}
+		if err := w.patchSheetPart(func(content []byte) []byte {
+			content = autoFilterElementRegexp.ReplaceAll(content, nil)
+			return sheetPrFilterModeRegexp.ReplaceAll(content, []byte("$1"))
+		}); err != nil {
+			return err
+		}
+		if err := w.file.DeleteDefinedName(&excelize.DefinedName{Name: "_xlnm._FilterDatabase", Scope: w.sheetName}); err != nil && err != excelize.ErrDefinedNameScope {
+			return fmt.Errorf("failed to delete filter database: %w", err)
+		}
+		return nil
+	}
+
+	startCol, startRow, endCol, endRow, err := ParseRange(autoFilter.Range)
+	if err != nil {
+		return err
+	}
+	for _, column := range autoFilter.Columns {
+		if column.Column < startCol || endCol < column.Column {
+			return fmt.Errorf("filter column is out of the range: %d", column.Column)
+		}
+	}
+	filterXML, err := newAutoFilterRead(autoFilter, startCol)
+	if err != nil {
+		return err
+	}
+	// excelize.AutoFilter replaces the sheet properties
+	props, err := w.file.GetSheetProps(w.sheetName)
+	if err != nil {
+		return err
+	}
+	options, ok := autoFilterOptions(autoFilter)
+	if err := w.file.AutoFilter(w.sheetName, autoFilter.Range, options); err != nil {
+		return fmt.Errorf("failed to set AutoFilter: %w", err)
+	}
+	if err := w.file.SetSheetProps(w.sheetName, &props); err != nil {
+		return err
+	}
+	if !ok {
+		// excelize accepts filter expressions with at most two values which cannot contain spaces,
+		// so the columns are written to the sheet XML
+		content, err := xml.Marshal(filterXML)
+		if err != nil {
+			return err
+		}
+		if err := w.patchSheetPart(func(sheet []byte) []byte {
+			return autoFilterElementRegexp.ReplaceAllLiteral(sheet, content)
+		}); err != nil {
+			return err
+		}
+	}
+
+	// rows are hidden as Excel does, comparing the stored values of cells
+	filterRange, err := w.GetRangeValues(autoFilter.Range)
+	if err != nil {
+		return err
+	}
+	for row := startRow + 1; row <= endRow; row++ {
+		visible := true
+		for _, column := range autoFilter.Columns {
+			cell := filterRange[row-startRow][column.Column-startCol]
+			if !column.Match(cell.RawValue, cell.Value) {
+				visible = false
+				break
+			}
+		}
+		if !visible {
+			w.lastRow = 0
+			if err := w.file.SetRowVisible(w.sheetName, row, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var (
+	// autoFilterElementRegexp matches the autoFilter element of a sheet XML
+	autoFilterElementRegexp = regexp.MustCompile(`(?s)<(\w+:)?autoFilter\b[^>]*?(/>|>.*?</(\w+:)?autoFilter>)`)
+	// sheetPrFilterModeRegexp matches the filterMode attribute of the sheetPr element, which shows a filter is applied
+	sheetPrFilterModeRegexp = regexp.MustCompile(`(<(\w+:)?sheetPr\b[^>]*?)\s+filterMode="(true|1)"`)
+)
+
+// autoFilterOptions converts the AutoFilter to options of excelize, and reports whether excelize can express it:
+// a list of at most two values, or at most two conditions, whose values do not contain spaces, quotes or wildcards.
+func autoFilterOptions(autoFilter *AutoFilter) ([]excelize.AutoFilterOptions, bool) {
+	operators := map[FilterOperator]string{
+		FilterOperatorEqual:              "==",
+		FilterOperatorNotEqual:           "!=",
+		FilterOperatorGreaterThan:        ">",
+		FilterOperatorGreaterThanOrEqual: ">=",
+		FilterOperatorLessThan:           "<",
+		FilterOperatorLessThanOrEqual:    "<=",
+	}
+	isToken := func(value string) bool {
+		return value != "" && !strings.ContainsAny(value, " \t\r\n\"*?~") && !blankTokenRegexp.MatchString(value)
+	}
+	var options []excelize.AutoFilterOptions
+	for _, column := range autoFilter.Columns {
+		name, err := excelize.ColumnNumberToName(column.Column)
+		if err != nil {
+			return nil, false
+		}
+		var expressions []string
+		switch {
+		case column.Blank || len(column.Values) > 2:
+			return nil, false
+		case len(column.Values) > 0:
+			for _, value := range column.Values {
+				if !isToken(value) {
+					return nil, false
+				}
+				expressions = append(expressions, "x == "+value)
+			}
+		case len(column.Conditions) > 0 && len(column.Conditions) <= 2:
+			for _, condition := range column.Conditions {
+				operator, ok := operators[condition.Operator]
+				if !ok || !isToken(condition.Value) {
+					return nil, false
+				}
+				expressions = append(expressions, "x "+operator+" "+condition.Value)
+			}
+			// excelize writes equalities joined by or as a list of values, not as conditions
+			equalities := 0
+			for _, condition := range column.Conditions {
+				if condition.Operator == FilterOperatorEqual {
+					equalities++
+				}
+			}
+			if equalities == len(column.Conditions) && (equalities == 1 || !column.And) {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+		joint := " or "
+		if column.And {
+			joint = " and "
+		}
+		options = append(options, excelize.AutoFilterOptions{Column: name, Expression: strings.Join(expressions, joint)})
+	}
+	return options, true
+}
+
+// blankTokenRegexp matches the words which excelize reads as blank cells in filter expressions
+var blankTokenRegexp = regexp.MustCompile(`(?i)^(blanks|nonblanks)$`)
+
+// patchSheetPart rewrites the sheet XML part with the function, for elements which excelize cannot write.
+// The worksheet parsed by excelize is dropped, so that excelize parses the rewritten part again.
+func (w *ExcelizeWorksheet) patchSheetPart(patch func(content []byte) []byte) error {
+	sheetXML, err := w.sheetXMLPath()
+	if err != nil {
+		return err
+	}
+	content := w.readPart(sheetXML)
+	if content == nil {
+		return fmt.Errorf("worksheet part not found: %s", w.sheetName)
+	}
+	w.file.Pkg.Store(sheetXML, patch(content))
+	w.file.Sheet.Delete(sheetXML)
+	return nil
+}
+
+func (w *ExcelizeWorksheet) IsRowHidden(row int) (bool, error) {
+	visible, err := w.file.GetRowVisible(w.sheetName, row)
+	if err != nil || visible {
+		return !visible, err
+	}
+	// excelize reports rows below the last row element as hidden, while they are shown with the default height
+	if w.lastRow == 0 {
+		if _, w.lastRow, err = w.cellExtent(); err != nil {
+			return false, err
+		}
+	}
+	return row <= w.lastRow, nil
+}
+
+// cellExtent returns the last column of the cell elements and the last row of the row elements in the sheet XML,
+// which may be beyond the dimension for styled cells and hidden rows.
+func (w *ExcelizeWorksheet) cellExtent() (int, int, error) {
+	sheetXML, err := w.sheetXMLPath()
+	if err != nil {
+		return 0, 0, err
+	}
+	var worksheet xlsxWorksheetRowsRead
+	if err := xml.Unmarshal(w.readPart(sheetXML), &worksheet); err != nil {
+		return 0, 0, fmt.Errorf("failed to read worksheet: %w", err)
+	}
+	lastCol, lastRow := 0, 0
+	for _, rowElement := range worksheet.Rows {
+		// a row or cell element without the reference follows the previous one
+		lastRow = max(lastRow+1, rowElement.R)
+		col := 0
+		for _, cellElement := range rowElement.Cells {
+			if cellCol, _, err := excelize.CellNameToCoordinates(cellElement.R); err == nil {
+				col = cellCol
+			} else {
+				col++
+			}
+			lastCol = max(lastCol, col)
+		}
+	}
+	return lastCol, lastRow, nil
+}
+
+func (w *ExcelizeWorksheet) IsColumnHidden(col int) (bool, error) {
+	name, err := excelize.ColumnNumberToName(col)
+	if err != nil {
+		return false, err
+	}
+	visible, err := w.file.GetColVisible(w.sheetName, name)
+	return !visible, err
+}
+
+func (w *ExcelizeWorksheet) SetRowsHidden(startRow, endRow int, hidden bool) error {
+	w.lastRow = 0
+	for row := startRow; row <= endRow; row++ {
+		if err := w.file.SetRowVisible(w.sheetName, row, !hidden); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) SetColumnsHidden(startCol, endCol int, hidden bool) error {
+	startName, err := excelize.ColumnNumberToName(startCol)
+	if err != nil {
+		return err
+	}
+	endName, err := excelize.ColumnNumberToName(endCol)
+	if err != nil {
+		return err
+	}
+	return w.file.SetColVisible(w.sheetName, startName+":"+endName, !hidden)
 }
 
-type ExcelizeWorksheet struct {
-	file      *excelize.File
-	sheetName string
+func (w *ExcelizeWorksheet) GetRowOutlineLevel(row int) (int, error) {
+	level, err := w.file.GetRowOutlineLevel(w.sheetName, row)
+	return int(level), err
 }
 
-func (w *ExcelizeWorksheet) Release() {
-	// No resources to release in excelize
+func (w *ExcelizeWorksheet) GetColumnOutlineLevel(col int) (int, error) {
+	name, err := excelize.ColumnNumberToName(col)
+	if err != nil {
+		return 0, err
+	}
+	level, err := w.file.GetColOutlineLevel(w.sheetName, name)
+	return int(level), err
 }
 
-func (w *ExcelizeWorksheet) Name() (string, error) {
-	return w.sheetName, nil
+func (w *ExcelizeWorksheet) SetRowsOutlineLevel(startRow, endRow int, level int) error {
+	if level < 0 || MaxOutlineLevel < level {
+		return fmt.Errorf("outline level must be between 0 and %d: %d", MaxOutlineLevel, level)
+	}
+	ungrouped := make(map[int]bool)
+	for row := startRow; row <= endRow; row++ {
+		if level > 0 {
+			if err := w.file.SetRowOutlineLevel(w.sheetName, row, uint8(level)); err != nil {
+				return err
+			}
+			continue
+		}
+		current, err := w.file.GetRowOutlineLevel(w.sheetName, row)
+		if err != nil {
+			return err
+		}
+		if current != 0 {
+			ungrouped[row] = true
+		}
+	}
+	if len(ungrouped) == 0 {
+		return nil
+	}
+	// excelize does not accept level 0, so the row elements are edited in the sheet XML
+	return w.patchSheetPart(func(content []byte) []byte {
+		return rowElementRegexp.ReplaceAllFunc(content, func(element []byte) []byte {
+			row, ok := attrNumber(rowRefAttrRegexp, element)
+			if !ok || !ungrouped[row] {
+				return element
+			}
+			return outlineLevelAttrRegexp.ReplaceAll(element, nil)
+		})
+	})
 }
 
-func (w *ExcelizeWorksheet) GetTables() ([]Table, error) {
-	tables, err := w.file.GetTables(w.sheetName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get tables: %w", err)
+func (w *ExcelizeWorksheet) SetColumnsOutlineLevel(startCol, endCol int, level int) error {
+	if level < 0 || MaxOutlineLevel < level {
+		return fmt.Errorf("outline level must be between 0 and %d: %d", MaxOutlineLevel, level)
 	}
-	tableList := make([]Table, len(tables))
-	for i, table := range tables {
-		tableList[i] = Table{
-			Name:  table.Name,
-			Range: NormalizeRange(table.Range),
+	ungrouped := make(map[int]bool)
+	for col := startCol; col <= endCol; col++ {
+		name, err := excelize.ColumnNumberToName(col)
+		if err != nil {
+			return err
+		}
+		if level > 0 {
+			if err := w.file.SetColOutlineLevel(w.sheetName, name, uint8(level)); err != nil {
+				return err
+			}
+			continue
+		}
+		current, err := w.file.GetColOutlineLevel(w.sheetName, name)
+		if err != nil {
+			return err
+		}
+		if current == 0 {
+			continue
 		}
+		// the column is split into its own element by setting level 1, to edit the element alone
+		if err := w.file.SetColOutlineLevel(w.sheetName, name, 1); err != nil {
+			return err
+		}
+		ungrouped[col] = true
 	}
-	return tableList, nil
+	if len(ungrouped) == 0 {
+		return nil
+	}
+	// excelize does not accept level 0, so the column elements are edited in the sheet XML
+	return w.patchSheetPart(func(content []byte) []byte {
+		return colElementRegexp.ReplaceAllFunc(content, func(element []byte) []byte {
+			colMin, okMin := attrNumber(colMinAttrRegexp, element)
+			colMax, okMax := attrNumber(colMaxAttrRegexp, element)
+			if !okMin || !okMax || colMin != colMax || !ungrouped[colMin] {
+				return element
+			}
+			return outlineLevelAttrRegexp.ReplaceAll(element, nil)
+		})
+	})
 }
 
-func (w *ExcelizeWorksheet) GetPivotTables() ([]PivotTable, error) {
-	pivotTables, err := w.file.GetPivotTables(w.sheetName)
+var (
+	// rowElementRegexp and colElementRegexp match the start tags of row and col elements of a sheet XML
+	rowElementRegexp = regexp.MustCompile(`<(\w+:)?row\b[^>]*>`)
+	colElementRegexp = regexp.MustCompile(`<(\w+:)?col\b[^>]*>`)
+	// outlineLevelAttrRegexp matches the outlineLevel attribute in a start tag
+	outlineLevelAttrRegexp = regexp.MustCompile(`\s+outlineLevel="\d+"`)
+	// rowRefAttrRegexp, colMinAttrRegexp and colMaxAttrRegexp match the numbers of rows and columns in start tags
+	rowRefAttrRegexp = regexp.MustCompile(`\sr="(\d+)"`)
+	colMinAttrRegexp = regexp.MustCompile(`\smin="(\d+)"`)
+	colMaxAttrRegexp = regexp.MustCompile(`\smax="(\d+)"`)
+)
+
+// attrNumber returns the number which the regular expression captures in the start tag
+func attrNumber(re *regexp.Regexp, element []byte) (int, bool) {
+	matches := re.FindSubmatch(element)
+	if matches == nil {
+		return 0, false
+	}
+	number, err := strconv.Atoi(string(matches[1]))
+	return number, err == nil
+}
+
+// typedCellValue returns the value of the cell as a number, a boolean or a string, or nil for an empty cell.
+// For a cell with formula, it returns the result of the formula.
+func (w *ExcelizeWorksheet) typedCellValue(cell string, hasFormula bool) (any, error) {
+	raw, err := w.file.GetCellValue(w.sheetName, cell, excelize.Options{RawCellValue: true})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get pivot tables: %w", err)
+		return nil, err
 	}
-	pivotTableList := make([]PivotTable, len(pivotTables))
-	for i, pivotTable := range pivotTables {
-		pivotTableList[i] = PivotTable{
-			Name:  pivotTable.Name,
-			Range: NormalizeRange(pivotTable.PivotTableRange),
+	if raw == "" && hasFormula {
+		if raw, err = w.file.CalcCellValue(w.sheetName, cell, excelize.Options{RawCellValue: true}); err != nil {
+			return nil, nil
 		}
 	}
-	return pivotTableList, nil
-}
-
-func (w *ExcelizeWorksheet) SetValue(cell string, value any) error {
-	if err := w.file.SetCellValue(w.sheetName, cell, value); err != nil {
-		return err
+	if raw == "" {
+		return nil, nil
 	}
-	if err := w.updateDimension(cell); err != nil {
-		return fmt.Errorf("failed to update dimension: %w", err)
+	cellType, err := w.file.GetCellType(w.sheetName, cell)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	switch cellType {
+	case excelize.CellTypeBool:
+		return raw == "1" || strings.EqualFold(raw, "TRUE"), nil
+	case excelize.CellTypeSharedString, excelize.CellTypeInlineString:
+		return raw, nil
+	}
+	if number, err := strconv.ParseFloat(raw, 64); err == nil {
+		return number, nil
+	}
+	return raw, nil
 }
 
-func (w *ExcelizeWorksheet) SetFormula(cell string, formula string) error {
-	if err := w.file.SetCellFormula(w.sheetName, cell, formula); err != nil {
+// clearCell clears the value and the formula of the cell, without creating an empty cell.
+func (w *ExcelizeWorksheet) clearCell(cell string, hasFormula bool) error {
+	if hasFormula {
+		if err := w.file.SetCellFormula(w.sheetName, cell, ""); err != nil {
+			return err
+		}
+	} else if value, err := w.file.GetCellValue(w.sheetName, cell, excelize.Options{RawCellValue: true}); err != nil {
 		return err
+	} else if value == "" {
+		return nil
 	}
-	if err := w.updateDimension(cell); err != nil {
-		return fmt.Errorf("failed to update dimension: %w", err)
-	}
-	return nil
+	return w.file.SetCellDefault(w.sheetName, cell, "")
 }
 
-func (w *ExcelizeWorksheet) GetValue(cell string) (string, error) {
-	value, err := w.file.GetCellValue(w.sheetName, cell)
-	if err != nil {
-		return "", err
+// chartTypeToExcelize converts ChartType to the chart type of excelize
+func chartTypeToExcelize(chartType ChartType) excelize.ChartType {
+	switch chartType {
+	case ChartTypeBar:
+		return excelize.Bar
+	case ChartTypeLine:
+		return excelize.Line
+	case ChartTypePie:
+		return excelize.Pie
+	case ChartTypeScatter:
+		return excelize.Scatter
+	case ChartTypeArea:
+		return excelize.Area
+	default:
+		return excelize.Col
 	}
-	if value == "" {
-		// try to get calculated value
-		formula, err := w.file.GetCellFormula(w.sheetName, cell)
-		if err != nil {
-			return "", fmt.Errorf("failed to get formula: %w", err)
+}
+
+// sheetXMLPath returns the path of the worksheet part in the package (e.g. "xl/worksheets/sheet1.xml")
+func (w *ExcelizeWorksheet) sheetXMLPath() (string, error) {
+	for _, sheet := range w.file.WorkBook.Sheets.Sheet {
+		if sheet.Name != w.sheetName {
+			continue
 		}
-		if formula != "" {
-			return w.file.CalcCellValue(w.sheetName, cell)
+		for _, rel := range w.readRelationships("xl/workbook.xml") {
+			if rel.ID == sheet.ID {
+				return resolvePartPath("xl/workbook.xml", rel.Target), nil
+			}
 		}
 	}
-	return value, nil
+	return "", fmt.Errorf("sheet part not found: %s", w.sheetName)
 }
 
-func (w *ExcelizeWorksheet) GetFormula(cell string) (string, error) {
-	formula, err := w.file.GetCellFormula(w.sheetName, cell)
-	if err != nil {
-		return "", fmt.Errorf("failed to get formula: %w", err)
+// readPart returns the XML of the part in the package. Parts which excelize has already parsed are marshaled again.
+func (w *ExcelizeWorksheet) readPart(name string) []byte {
+	for _, parsed := range []*sync.Map{&w.file.Relationships, &w.file.Drawings, &w.file.Sheet} {
+		if value, ok := parsed.Load(name); ok && value != nil {
+			if content, err := xml.Marshal(value); err == nil {
+				return content
+			}
+		}
 	}
-	if formula == "" {
-		// fallback
-		return w.GetValue(cell)
+	if content, ok := w.file.Pkg.Load(name); ok {
+		if bytes, ok := content.([]byte); ok {
+			return bytes
+		}
 	}
-	if !strings.HasPrefix(formula, "=") {
-		formula = "=" + formula
+	return nil
+}
+
+// readRelationships returns the relationships of the part
+func (w *ExcelizeWorksheet) readRelationships(name string) []xlsxRelationshipRead {
+	dir, file := path.Split(name)
+	var rels xlsxRelationshipsRead
+	if err := xml.Unmarshal(w.readPart(dir+"_rels/"+file+".rels"), &rels); err != nil {
+		return nil
 	}
-	return formula, nil
+	return rels.Relationships
 }
 
-func (w *ExcelizeWorksheet) GetDimention() (string, error) {
-	return w.file.GetSheetDimension(w.sheetName)
+// resolvePartPath resolves the target of a relationship from the source part
+func resolvePartPath(source string, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(path.Dir(source), target)
 }
 
-func (w *ExcelizeWorksheet) GetPagingStrategy(pageSize int) (PagingStrategy, error) {
-	return NewExcelizeFixedSizePagingStrategy(pageSize, w)
+type xlsxRelationshipsRead struct {
+	Relationships []xlsxRelationshipRead `xml:"Relationship"`
 }
 
-func (w *ExcelizeWorksheet) CapturePicture(captureRange string) (string, error) {
-	return "", fmt.Errorf("CapturePicture is not supported in Excelize")
+type xlsxRelationshipRead struct {
+	ID     string `xml:"Id,attr"`
+	Target string `xml:"Target,attr"`
+	Type   string `xml:"Type,attr"`
 }
 
-func (w *ExcelizeWorksheet) AddTable(tableRange, tableName string) error {
-	enable := true
-	if err := w.file.AddTable(w.sheetName, &excelize.Table{
-		Range:             tableRange,
-		Name:              tableName,
-		StyleName:         "TableStyleMedium2",
-		ShowColumnStripes: true,
-		ShowFirstColumn:   false,
-		ShowHeaderRow:     &enable,
-		ShowLastColumn:    false,
-		ShowRowStripes:    &enable,
-	}); err != nil {
-		return err
+type xlsxDrawingRead struct {
+	Anchors []xlsxDrawingAnchorRead `xml:",any"`
+}
+
+type xlsxDrawingAnchorRead struct {
+	From         *xlsxDrawingMarkerRead `xml:"from"`
+	To           *xlsxDrawingMarkerRead `xml:"to"`
+	GraphicFrame *struct {
+		NvGraphicFramePr struct {
+			CNvPr struct {
+				Name string `xml:"name,attr"`
+			} `xml:"cNvPr"`
+		} `xml:"nvGraphicFramePr"`
+		Chart struct {
+			RID string `xml:"id,attr"`
+		} `xml:"graphic>graphicData>chart"`
+	} `xml:"graphicFrame"`
+}
+
+type xlsxDrawingMarkerRead struct {
+	Col int `xml:"col"`
+	Row int `xml:"row"`
+}
+
+// anchorRange returns the range of cells covered by the drawing object
+func (a xlsxDrawingAnchorRead) anchorRange() string {
+	if a.From == nil {
+		return ""
 	}
-	return nil
+	from, _ := excelize.CoordinatesToCellName(a.From.Col+1, a.From.Row+1)
+	if a.To == nil {
+		return from
+	}
+	to, _ := excelize.CoordinatesToCellName(a.To.Col+1, a.To.Row+1)
+	return from + ":" + to
 }
 
-func (w *ExcelizeWorksheet) GetCellStyle(cell string) (*CellStyle, error) {
-	styleID, err := w.file.GetCellStyle(w.sheetName, cell)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get cell style: %w", err)
+type xlsxChartSpaceRead struct {
+	Chart struct {
+		Title    *xlsxChartTitleRead `xml:"title"`
+		PlotArea struct {
+			Elements []xlsxPlotAreaElementRead `xml:",any"`
+		} `xml:"plotArea"`
+		Legend *struct {
+			LegendPos *struct {
+				Val string `xml:"val,attr"`
+			} `xml:"legendPos"`
+		} `xml:"legend"`
+	} `xml:"chart"`
+}
+
+type xlsxChartTitleRead struct {
+	Texts []string `xml:"tx>rich>p>r>t"`
+}
+
+// xlsxPlotAreaElementRead is a chart group (e.g. barChart) or an axis (e.g. catAx) in the plot area
+type xlsxPlotAreaElementRead struct {
+	XMLName xml.Name
+	BarDir  *struct {
+		Val string `xml:"val,attr"`
+	} `xml:"barDir"`
+	Series []struct {
+		Tx struct {
+			F string `xml:"strRef>f"`
+			V string `xml:"v"`
+		} `xml:"tx"`
+		Cat  xlsxChartDataRefRead `xml:"cat"`
+		Val  xlsxChartDataRefRead `xml:"val"`
+		XVal xlsxChartDataRefRead `xml:"xVal"`
+		YVal xlsxChartDataRefRead `xml:"yVal"`
+	} `xml:"ser"`
+	Title *xlsxChartTitleRead `xml:"title"`
+	AxPos *struct {
+		Val string `xml:"val,attr"`
+	} `xml:"axPos"`
+}
+
+type xlsxChartDataRefRead struct {
+	NumRef string `xml:"numRef>f"`
+	StrRef string `xml:"strRef>f"`
+}
+
+func (r xlsxChartDataRefRead) ref() string {
+	if r.NumRef != "" {
+		return r.NumRef
 	}
+	return r.StrRef
+}
 
-	style, err := w.file.GetStyle(styleID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get style details: %w", err)
+func (c xlsxChartSpaceRead) toChart() Chart {
+	chart := Chart{}
+	if c.Chart.Title != nil {
+		chart.Title = strings.Join(c.Chart.Title.Texts, "")
+	}
+	chart.LegendPosition = ChartLegendPositionNone
+	if c.Chart.Legend != nil {
+		chart.LegendPosition = ChartLegendPositionRight
+		if c.Chart.Legend.LegendPos != nil {
+			switch c.Chart.Legend.LegendPos.Val {
+			case "b":
+				chart.LegendPosition = ChartLegendPositionBottom
+			case "t":
+				chart.LegendPosition = ChartLegendPositionTop
+			case "l":
+				chart.LegendPosition = ChartLegendPositionLeft
+			case "tr":
+				chart.LegendPosition = ChartLegendPositionTopRight
+			}
+		}
+	}
+	for _, element := range c.Chart.PlotArea.Elements {
+		name := element.XMLName.Local
+		if strings.HasSuffix(name, "Ax") {
+			if element.Title == nil {
+				continue
+			}
+			title := strings.Join(element.Title.Texts, "")
+			if element.AxPos != nil && (element.AxPos.Val == "l" || element.AxPos.Val == "r") {
+				chart.YAxisTitle = title
+			} else {
+				chart.XAxisTitle = title
+			}
+			continue
+		}
+		if !strings.HasSuffix(name, "Chart") {
+			continue
+		}
+		var chartType ChartType
+		switch strings.TrimSuffix(strings.TrimSuffix(name, "Chart"), "3D") {
+		case "bar":
+			chartType = ChartTypeColumn
+			if element.BarDir != nil && element.BarDir.Val == "bar" {
+				chartType = ChartTypeBar
+			}
+		case "line":
+			chartType = ChartTypeLine
+		case "pie":
+			chartType = ChartTypePie
+		case "scatter":
+			chartType = ChartTypeScatter
+		case "area":
+			chartType = ChartTypeArea
+		default:
+			// other types (e.g. doughnut, radar, bubble) are reported with the element name
+			chartType = ChartType(strings.TrimSuffix(name, "Chart"))
+		}
+		if chart.Type == "" {
+			chart.Type = chartType
+		} else if chart.Type != chartType {
+			chart.Type = ChartTypeCombo
+		}
+		for _, ser := range element.Series {
+			series := ChartSeries{
+				Name:       ser.Tx.F,
+				Categories: ser.Cat.ref(),
+				Values:     ser.Val.ref(),
+				Type:       chartType,
+			}
+			if series.Name == "" {
+				series.Name = ser.Tx.V
+			}
+			if chartType == ChartTypeScatter {
+				series.Categories, series.Values = ser.XVal.ref(), ser.YVal.ref()
+			}
+			chart.Series = append(chart.Series, series)
+		}
+	}
+	if chart.Type != ChartTypeCombo {
+		for i := range chart.Series {
+			chart.Series[i].Type = ""
+		}
 	}
+	return chart
+}
 
-	return convertExcelizeStyleToCellStyle(style), nil
+// conditionalFormatOperatorToExcelizeCriteria maps operators to the criteria of excelize
+var conditionalFormatOperatorToExcelizeCriteria = map[ConditionalFormatOperator]string{
+	ConditionalFormatOperatorBetween:            "between",
+	ConditionalFormatOperatorNotBetween:         "not between",
+	ConditionalFormatOperatorEqual:              "==",
+	ConditionalFormatOperatorNotEqual:           "!=",
+	ConditionalFormatOperatorGreaterThan:        ">",
+	ConditionalFormatOperatorLessThan:           "<",
+	ConditionalFormatOperatorGreaterThanOrEqual: ">=",
+	ConditionalFormatOperatorLessThanOrEqual:    "<=",
 }
 
-func (w *ExcelizeWorksheet) SetCellStyle(cell string, style *CellStyle) error {
-	excelizeStyle := convertCellStyleToExcelizeStyle(style)
+// excelizeCriteriaToConditionalFormatOperator maps the criteria returned by excelize to operators
+var excelizeCriteriaToConditionalFormatOperator = map[string]ConditionalFormatOperator{
+	"between":                  ConditionalFormatOperatorBetween,
+	"not between":              ConditionalFormatOperatorNotBetween,
+	"equal to":                 ConditionalFormatOperatorEqual,
+	"not equal to":             ConditionalFormatOperatorNotEqual,
+	"greater than":             ConditionalFormatOperatorGreaterThan,
+	"less than":                ConditionalFormatOperatorLessThan,
+	"greater than or equal to": ConditionalFormatOperatorGreaterThanOrEqual,
+	"less than or equal to":    ConditionalFormatOperatorLessThanOrEqual,
+}
 
-	styleID, err := w.file.NewStyle(excelizeStyle)
-	if err != nil {
-		return fmt.Errorf("failed to create style: %w", err)
+// dataValidationFormulaEscaper escapes formulas of data validation as excelize writes them as inner XML
+var dataValidationFormulaEscaper = strings.NewReplacer(`&`, `&amp;`, `<`, `&lt;`, `>`, `&gt;`)
+
+// sqrefToRange converts space separated sqref (e.g. "A1 B2:C3") to a comma separated range (e.g. "A1:A1,B2:C3").
+func sqrefToRange(sqref string) string {
+	areas := strings.Fields(sqref)
+	for i, area := range areas {
+		areas[i] = NormalizeRange(area)
 	}
+	return strings.Join(areas, ",")
+}
 
-	if err := w.file.SetCellStyle(w.sheetName, cell, cell, styleID); err != nil {
-		return fmt.Errorf("failed to set cell style: %w", err)
+// rangeToSqref converts comma separated range to space separated sqref.
+func rangeToSqref(rangeStr string) string {
+	return strings.Join(SplitRangeAreas(rangeStr), " ")
+}
+
+// parseListFormula parses literal list formula of data validation (e.g. "a,b,c").
+func parseListFormula(formula string) ([]string, bool) {
+	if len(formula) < 2 || !strings.HasPrefix(formula, `"`) || !strings.HasSuffix(formula, `"`) {
+		return nil, false
 	}
+	content := strings.ReplaceAll(formula[1:len(formula)-1], `""`, `"`)
+	return strings.Split(content, ","), true
+}
 
-	return nil
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
 }
 
 func convertCellStyleToExcelizeStyle(style *CellStyle) *excelize.Style {
@@ -546,6 +3340,7 @@ func fillShadingNameToInt(shading FillShading) int {
 
 // updateDimention updates the dimension of the worksheet after a cell is updated.
 func (w *ExcelizeWorksheet) updateDimension(updatedCell string) error {
+	w.regions = dataRegionsCache{}
 	dimension, err := w.file.GetSheetDimension(w.sheetName)
 	if err != nil {
 		return err
@@ -581,3 +3376,244 @@ func (w *ExcelizeWorksheet) updateDimension(updatedCell string) error {
 	updatedDimension := fmt.Sprintf("%s:%s", startRange, endRange)
 	return w.file.SetSheetDimension(w.sheetName, updatedDimension)
 }
+
+// shrinkDimension updates the dimension to the cells which have values or formulas.
+func (w *ExcelizeWorksheet) shrinkDimension() error {
+	w.regions = dataRegionsCache{}
+	dimension, err := w.file.GetSheetDimension(w.sheetName)
+	if err != nil {
+		return err
+	}
+	startCol, startRow, endCol, endRow, err := ParseRange(dimension)
+	if err != nil {
+		return err
+	}
+	usedStartCol, usedStartRow, usedEndCol, usedEndRow := endCol+1, endRow+1, 0, 0
+	for row := startRow; row <= endRow; row++ {
+		for col := startCol; col <= endCol; col++ {
+			cell, err := excelize.CoordinatesToCellName(col, row)
+			if err != nil {
+				return err
+			}
+			value, err := w.file.GetCellValue(w.sheetName, cell, excelize.Options{RawCellValue: true})
+			if err != nil {
+				return err
+			}
+			if value == "" {
+				formula, err := w.file.GetCellFormula(w.sheetName, cell)
+				if err != nil {
+					return err
+				}
+				if formula == "" {
+					continue
+				}
+			}
+			usedStartCol, usedStartRow = min(usedStartCol, col), min(usedStartRow, row)
+			usedEndCol, usedEndRow = max(usedEndCol, col), max(usedEndRow, row)
+		}
+	}
+	if usedEndCol == 0 {
+		return w.file.SetSheetDimension(w.sheetName, "A1")
+	}
+	startRange, err := excelize.CoordinatesToCellName(usedStartCol, usedStartRow)
+	if err != nil {
+		return err
+	}
+	endRange, err := excelize.CoordinatesToCellName(usedEndCol, usedEndRow)
+	if err != nil {
+		return err
+	}
+	return w.file.SetSheetDimension(w.sheetName, fmt.Sprintf("%s:%s", startRange, endRange))
+}
+
+type xlsxWorksheetRowBreaksRead struct {
+	RowBreaks *struct {
+		Brk []struct {
+			ID int `xml:"id,attr"`
+		} `xml:"brk"`
+	} `xml:"rowBreaks"`
+}
+
+type xlsxWorksheetRowsRead struct {
+	Cols []xlsxColRead `xml:"cols>col"`
+	Rows []struct {
+		R            int  `xml:"r,attr"`
+		S            int  `xml:"s,attr"`
+		CustomFormat bool `xml:"customFormat,attr"`
+		Cells        []struct {
+			R string `xml:"r,attr"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// cellCoordinates is the column and row number of a cell.
+type cellCoordinates struct {
+	col, row int
+}
+
+type xlsxColRead struct {
+	Min   int `xml:"min,attr"`
+	Max   int `xml:"max,attr"`
+	Style int `xml:"style,attr"`
+}
+
+type xlsxWorksheetCellsRead struct {
+	Cols []xlsxColRead      `xml:"cols>col"`
+	Rows []xlsxRowCellsRead `xml:"sheetData>row"`
+}
+
+type xlsxRowCellsRead struct {
+	R     int         `xml:"r,attr"`
+	S     int         `xml:"s,attr"`
+	Cells []xlsxCRead `xml:"c"`
+}
+
+type xlsxCRead struct {
+	R  string     `xml:"r,attr"`
+	S  int        `xml:"s,attr"`
+	T  string     `xml:"t,attr"`
+	F  *xlsxFRead `xml:"f"`
+	V  string     `xml:"v"`
+	IS *struct{}  `xml:"is"`
+	// col and row are set for the master cell of a shared formula
+	col, row int
+}
+
+type xlsxFRead struct {
+	Content string `xml:",chardata"`
+	T       string `xml:"t,attr"`
+	Ref     string `xml:"ref,attr"`
+	Si      *int   `xml:"si,attr"`
+}
+
+type xlsxWorksheetAutoFilterRead struct {
+	AutoFilter *xlsxAutoFilterRead `xml:"autoFilter"`
+}
+
+// xlsxAutoFilterRead maps the autoFilter element. It is also marshaled to write AutoFilters.
+type xlsxAutoFilterRead struct {
+	XMLName      xml.Name               `xml:"autoFilter"`
+	Ref          string                 `xml:"ref,attr"`
+	FilterColumn []xlsxFilterColumnRead `xml:"filterColumn"`
+}
+
+type xlsxFilterColumnRead struct {
+	ColID         int                    `xml:"colId,attr"`
+	Filters       *xlsxFiltersRead       `xml:"filters"`
+	CustomFilters *xlsxCustomFiltersRead `xml:"customFilters"`
+	Top10         *struct {
+		Top     string  `xml:"top,attr"`
+		Percent bool    `xml:"percent,attr"`
+		Val     float64 `xml:"val,attr"`
+	} `xml:"top10"`
+	DynamicFilter *struct {
+		Type string `xml:"type,attr"`
+	} `xml:"dynamicFilter"`
+	ColorFilter *struct{} `xml:"colorFilter"`
+	IconFilter  *struct{} `xml:"iconFilter"`
+}
+
+type xlsxFiltersRead struct {
+	Blank  bool `xml:"blank,attr,omitempty"`
+	Filter []struct {
+		Val string `xml:"val,attr"`
+	} `xml:"filter"`
+	DateGroupItem []struct{} `xml:"dateGroupItem"`
+}
+
+type xlsxCustomFiltersRead struct {
+	And          bool `xml:"and,attr,omitempty"`
+	CustomFilter []struct {
+		Operator string `xml:"operator,attr,omitempty"`
+		Val      string `xml:"val,attr"`
+	} `xml:"customFilter"`
+}
+
+// newAutoFilterRead converts the AutoFilter to the autoFilter element. startCol is the first column of its range.
+func newAutoFilterRead(autoFilter *AutoFilter, startCol int) (*xlsxAutoFilterRead, error) {
+	result := &xlsxAutoFilterRead{Ref: NormalizeRange(autoFilter.Range)}
+	for _, column := range autoFilter.Columns {
+		filterColumn := xlsxFilterColumnRead{ColID: column.Column - startCol}
+		if len(column.Values) > 0 || column.Blank {
+			filterColumn.Filters = &xlsxFiltersRead{Blank: column.Blank}
+			for _, value := range column.Values {
+				filterColumn.Filters.Filter = append(filterColumn.Filters.Filter, struct {
+					Val string `xml:"val,attr"`
+				}{Val: value})
+			}
+		} else if len(column.Conditions) > 0 {
+			if len(column.Conditions) > 2 {
+				return nil, fmt.Errorf("a filter column accepts at most 2 conditions: %d", len(column.Conditions))
+			}
+			filterColumn.CustomFilters = &xlsxCustomFiltersRead{And: column.And}
+			for _, condition := range column.Conditions {
+				operator := condition.Operator.String()
+				if condition.Operator == FilterOperatorEqual {
+					// equal is the default operator
+					operator = ""
+				}
+				filterColumn.CustomFilters.CustomFilter = append(filterColumn.CustomFilters.CustomFilter, struct {
+					Operator string `xml:"operator,attr,omitempty"`
+					Val      string `xml:"val,attr"`
+				}{Operator: operator, Val: condition.Value})
+			}
+		} else {
+			continue
+		}
+		result.FilterColumn = append(result.FilterColumn, filterColumn)
+	}
+	return result, nil
+}
+
+func (a xlsxAutoFilterRead) toAutoFilter() (*AutoFilter, error) {
+	startCol, _, _, _, err := ParseRange(a.Ref)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AutoFilter range: %s", a.Ref)
+	}
+	autoFilter := &AutoFilter{Range: NormalizeRange(a.Ref)}
+	for _, filterColumn := range a.FilterColumn {
+		column := FilterColumn{Column: startCol + filterColumn.ColID}
+		var others []string
+		if filters := filterColumn.Filters; filters != nil {
+			column.Blank = filters.Blank
+			for _, filter := range filters.Filter {
+				column.Values = append(column.Values, filter.Val)
+			}
+			if len(filters.DateGroupItem) > 0 {
+				others = append(others, "date groups")
+			}
+		}
+		if customFilters := filterColumn.CustomFilters; customFilters != nil {
+			column.And = customFilters.And
+			for _, customFilter := range customFilters.CustomFilter {
+				operator := FilterOperator(customFilter.Operator)
+				if operator == "" {
+					operator = FilterOperatorEqual
+				}
+				column.Conditions = append(column.Conditions, FilterCondition{Operator: operator, Value: customFilter.Val})
+			}
+		}
+		if top10 := filterColumn.Top10; top10 != nil {
+			direction, unit := "top", "items"
+			if top10.Top == "0" || top10.Top == "false" {
+				direction = "bottom"
+			}
+			if top10.Percent {
+				unit = "percent"
+			}
+			others = append(others, fmt.Sprintf("%s %g %s", direction, top10.Val, unit))
+		}
+		if filterColumn.DynamicFilter != nil {
+			others = append(others, "dynamic filter: "+filterColumn.DynamicFilter.Type)
+		}
+		if filterColumn.ColorFilter != nil {
+			others = append(others, "color filter")
+		}
+		if filterColumn.IconFilter != nil {
+			others = append(others, "icon filter")
+		}
+		column.Other = strings.Join(others, ", ")
+		autoFilter.Columns = append(autoFilter.Columns, column)
+	}
+	return autoFilter, nil
+}
@@ -0,0 +1,1463 @@
+package excel
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"image"
+	"image/png"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExcelizeExcelDefinedNameCasing(t *testing.T) {
+	tests := []struct {
+		name   string
+		action string
+		target string
+		scope  string
+		want   []DefinedName
+	}{
+		{
+			name:   "update with another casing",
+			action: "set",
+			target: "revenue",
+			want: []DefinedName{
+				{Name: "Revenue", RefersTo: "Sheet1!$B$1:$B$5"},
+				{Name: "Cost", Scope: "Sheet1", RefersTo: "Sheet1!$C$1:$C$5"},
+			},
+		},
+		{
+			name:   "update sheet scoped with another casing",
+			action: "set",
+			target: "COST",
+			scope:  "Sheet1",
+			want: []DefinedName{
+				{Name: "Revenue", RefersTo: "Sheet1!$A$1:$A$5"},
+				{Name: "Cost", Scope: "Sheet1", RefersTo: "Sheet1!$B$1:$B$5"},
+			},
+		},
+		{
+			name:   "delete with another casing",
+			action: "delete",
+			target: "REVENUE",
+			want: []DefinedName{
+				{Name: "Cost", Scope: "Sheet1", RefersTo: "Sheet1!$C$1:$C$5"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := excelize.NewFile()
+			defer file.Close()
+			if err := file.SetDefinedName(&excelize.DefinedName{Name: "Revenue", RefersTo: "Sheet1!$A$1:$A$5"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := file.SetDefinedName(&excelize.DefinedName{Name: "Cost", RefersTo: "Sheet1!$C$1:$C$5", Scope: "Sheet1"}); err != nil {
+				t.Fatal(err)
+			}
+			workbook := NewExcelizeExcel(file)
+			var err error
+			if tt.action == "set" {
+				err = workbook.SetDefinedName(DefinedName{Name: tt.target, Scope: tt.scope, RefersTo: "Sheet1!$B$1:$B$5"})
+			} else {
+				err = workbook.DeleteDefinedName(tt.target, tt.scope)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := workbook.GetDefinedNames()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for _, want := range tt.want {
+				found := false
+				for _, definedName := range got {
+					if definedName.Name == want.Name && definedName.Scope == want.Scope && definedName.RefersTo == want.RefersTo {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("got %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExcelizeWorksheetSetAutoFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		column     FilterColumn
+		wantHidden []int
+	}{
+		{
+			name:       "condition on percentages",
+			column:     FilterColumn{Column: 2, Conditions: []FilterCondition{{Operator: FilterOperatorGreaterThan, Value: "0.1"}}},
+			wantHidden: []int{2, 4},
+		},
+		{
+			name:       "values with spaces",
+			column:     FilterColumn{Column: 1, Values: []string{"North East", "South", "West"}},
+			wantHidden: []int{2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "filter.xlsx")
+			file := excelize.NewFile()
+			rows := [][]any{{"Region", "Rate"}, {"East", 0.05}, {"North East", 0.25}, {"South", 0.08}, {"West", 0.5}}
+			for i, row := range rows {
+				cell, _ := excelize.CoordinatesToCellName(1, i+1)
+				if err := file.SetSheetRow("Sheet1", cell, &row); err != nil {
+					t.Fatal(err)
+				}
+			}
+			percent, err := file.NewStyle(&excelize.Style{NumFmt: 10})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := file.SetCellStyle("Sheet1", "B2", "B5", percent); err != nil {
+				t.Fatal(err)
+			}
+			worksheet := &ExcelizeWorksheet{file: file, sheetName: "Sheet1"}
+			autoFilter := &AutoFilter{Range: "A1:B5", Columns: []FilterColumn{tt.column}}
+			if err := worksheet.SetAutoFilter(autoFilter); err != nil {
+				t.Fatal(err)
+			}
+			if err := file.SaveAs(path); err != nil {
+				t.Fatal(err)
+			}
+
+			saved, err := excelize.OpenFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer saved.Close()
+			worksheet = &ExcelizeWorksheet{file: saved, sheetName: "Sheet1"}
+			got, err := worksheet.GetAutoFilter()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got == nil || got.Range != "A1:B5" || len(got.Columns) != 1 || got.Columns[0].Column != tt.column.Column {
+				t.Fatalf("GetAutoFilter() = %+v", got)
+			}
+			for row := 2; row <= 5; row++ {
+				hidden, err := worksheet.IsRowHidden(row)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := slices.Contains(tt.wantHidden, row); hidden != want {
+					t.Errorf("row %d hidden = %v, want %v", row, hidden, want)
+				}
+			}
+
+			// removing the AutoFilter shows the rows again
+			if err := worksheet.SetAutoFilter(nil); err != nil {
+				t.Fatal(err)
+			}
+			if got, err := worksheet.GetAutoFilter(); err != nil || got != nil {
+				t.Fatalf("GetAutoFilter() after removal = %+v, %v", got, err)
+			}
+			for row := 2; row <= 5; row++ {
+				if hidden, _ := worksheet.IsRowHidden(row); hidden {
+					t.Errorf("row %d is hidden after removal", row)
+				}
+			}
+			if err := saved.Save(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestExcelizeWorksheetOutlineLevelZero(t *testing.T) {
+	tests := []struct {
+		name      string
+		rows      bool
+		groupEnd  int
+		ungroup   [2]int
+		wantLevel map[int]int
+	}{
+		{name: "rows", rows: true, groupEnd: 5, ungroup: [2]int{3, 4}, wantLevel: map[int]int{2: 1, 3: 0, 4: 0, 5: 1}},
+		{name: "columns", groupEnd: 5, ungroup: [2]int{3, 3}, wantLevel: map[int]int{2: 1, 3: 0, 4: 1, 5: 1}},
+		{name: "ungrouped already", rows: true, groupEnd: 2, ungroup: [2]int{4, 5}, wantLevel: map[int]int{2: 1, 3: 0, 4: 0, 5: 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "outline.xlsx")
+			file := excelize.NewFile()
+			for row := 1; row <= 6; row++ {
+				values := []any{row, row * 2, row * 3, row * 4, row * 5, row * 6}
+				cell, _ := excelize.CoordinatesToCellName(1, row)
+				if err := file.SetSheetRow("Sheet1", cell, &values); err != nil {
+					t.Fatal(err)
+				}
+			}
+			worksheet := &ExcelizeWorksheet{file: file, sheetName: "Sheet1"}
+			set := worksheet.SetColumnsOutlineLevel
+			get := worksheet.GetColumnOutlineLevel
+			if tt.rows {
+				set, get = worksheet.SetRowsOutlineLevel, worksheet.GetRowOutlineLevel
+			}
+			if err := set(2, tt.groupEnd, 1); err != nil {
+				t.Fatal(err)
+			}
+			if err := set(tt.ungroup[0], tt.ungroup[1], 0); err != nil {
+				t.Fatal(err)
+			}
+			if err := file.SaveAs(path); err != nil {
+				t.Fatal(err)
+			}
+
+			saved, err := excelize.OpenFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer saved.Close()
+			worksheet = &ExcelizeWorksheet{file: saved, sheetName: "Sheet1"}
+			get = worksheet.GetColumnOutlineLevel
+			if tt.rows {
+				get = worksheet.GetRowOutlineLevel
+			}
+			for line, want := range tt.wantLevel {
+				got, err := get(line)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Errorf("outline level of %d = %d, want %d", line, got, want)
+				}
+			}
+			if value, err := saved.GetCellValue("Sheet1", "C3"); err != nil || value != "9" {
+				t.Errorf("C3 = %q, %v, want 9", value, err)
+			}
+		})
+	}
+}
+
+func TestExcelizeWorksheetGetRangeValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "values.xlsx")
+	file := excelize.NewFile()
+	values := map[string]any{"A1": "Name", "B1": 0.123456, "C1": 45306, "D1": true, "A3": "Merged"}
+	for cell, value := range values {
+		if err := file.SetCellValue("Sheet1", cell, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for cell, formula := range map[string]string{"A2": "B1*2", "B2": "UNKNOWNFUNC()"} {
+		if err := file.SetCellFormula("Sheet1", cell, formula); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for cell, numFmt := range map[string]int{"B1": 2, "C1": 14} {
+		style, err := file.NewStyle(&excelize.Style{NumFmt: numFmt})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := file.SetCellStyle("Sheet1", cell, cell, style); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := file.MergeCell("Sheet1", "A3", "B3"); err != nil {
+		t.Fatal(err)
+	}
+	sharedType, sharedRef := "shared", "A5:A7"
+	if err := file.SetCellFormula("Sheet1", "A5", "C1*2", excelize.FormulaOpts{Type: &sharedType, Ref: &sharedRef}); err != nil {
+		t.Fatal(err)
+	}
+	bold, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := file.SetColStyle("Sheet1", "F", bold); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.SetRowStyle("Sheet1", 6, 6, bold); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.SetCellValue("Sheet1", "E7", 1234.5); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.SetCellStyle("Sheet1", "E7", "E7", bold); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer saved.Close()
+	worksheet := &ExcelizeWorksheet{file: saved, sheetName: "Sheet1"}
+	cells, err := worksheet.GetRangeValues("A1:F7")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		cell         string
+		wantValue    string
+		wantRawValue string
+		wantFormula  string
+		// the range is read from the cell, to read the cell at the edge of the range
+		fromCell bool
+	}{
+		{cell: "A1", wantValue: "Name", wantRawValue: "Name"},
+		{cell: "B1", wantValue: "0.12", wantRawValue: "0.123456"},
+		{cell: "C1", wantValue: "01-15-24", wantRawValue: "45306"},
+		{cell: "D1", wantValue: "TRUE", wantRawValue: "TRUE"},
+		{cell: "A2", wantValue: "0.246912", wantRawValue: "", wantFormula: "=B1*2"},
+		// excelize calculates unknown functions as #VALUE!
+		{cell: "B2", wantValue: "#VALUE!", wantRawValue: "", wantFormula: "=UNKNOWNFUNC()"},
+		{cell: "A3", wantValue: "Merged", wantRawValue: "Merged"},
+		{cell: "B3", wantValue: "Merged", wantRawValue: "Merged"},
+		{cell: "D3", wantValue: "", wantRawValue: ""},
+		{cell: "B3", wantValue: "Merged", wantRawValue: "Merged", fromCell: true},
+		{cell: "A5", wantValue: "90612", wantRawValue: "", wantFormula: "=C1*2"},
+		{cell: "A7", wantValue: "0", wantRawValue: "", wantFormula: "=C3*2"},
+		{cell: "A7", wantValue: "0", wantRawValue: "", wantFormula: "=C3*2", fromCell: true},
+		{cell: "C6", wantValue: "", wantRawValue: ""},
+		{cell: "F2", wantValue: "", wantRawValue: ""},
+		{cell: "E7", wantValue: "1234.5", wantRawValue: "1234.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cell, func(t *testing.T) {
+			col, row, _ := excelize.CellNameToCoordinates(tt.cell)
+			got := cells[row-1][col-1]
+			if tt.fromCell {
+				cells, err := worksheet.GetRangeValues(tt.cell + ":" + tt.cell)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = cells[0][0]
+			}
+			if got.Value != tt.wantValue || got.RawValue != tt.wantRawValue || got.Formula != tt.wantFormula {
+				t.Errorf("GetRangeValues() %s = %+v, want value %q, raw value %q, formula %q", tt.cell, got, tt.wantValue, tt.wantRawValue, tt.wantFormula)
+			}
+			// values read at once are the same as ones read cell by cell
+			if value, err := worksheet.GetValue(tt.cell); err == nil && value != got.Value {
+				t.Errorf("GetValue(%s) = %q, GetRangeValues() = %q", tt.cell, value, got.Value)
+			}
+			wantStyle, err := saved.GetCellStyle("Sheet1", tt.cell)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.StyleID != wantStyle {
+				t.Errorf("GetRangeValues() %s style = %d, want %d", tt.cell, got.StyleID, wantStyle)
+			}
+		})
+	}
+}
+
+func TestExcelizeWorksheetIsEmpty(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(file *excelize.File) error
+		want  bool
+	}{
+		{
+			name:  "new sheet",
+			setup: func(file *excelize.File) error { return nil },
+			want:  true,
+		},
+		{
+			name:  "a cell with the default dimension",
+			setup: func(file *excelize.File) error { return file.SetCellValue("Sheet1", "C3", "value") },
+			want:  false,
+		},
+		{
+			name: "cells with their dimension",
+			setup: func(file *excelize.File) error {
+				if err := file.SetCellValue("Sheet1", "B2", 1); err != nil {
+					return err
+				}
+				return file.SetSheetDimension("Sheet1", "A1:B2")
+			},
+			want: false,
+		},
+		{
+			name:  "merged cells",
+			setup: func(file *excelize.File) error { return file.MergeCell("Sheet1", "A1", "B2") },
+			want:  false,
+		},
+		{
+			name:  "table",
+			setup: func(file *excelize.File) error { return file.AddTable("Sheet1", &excelize.Table{Range: "A1:B3"}) },
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := excelize.NewFile()
+			defer file.Close()
+			if err := tt.setup(file); err != nil {
+				t.Fatal(err)
+			}
+			worksheet := &ExcelizeWorksheet{file: file, sheetName: "Sheet1"}
+			got, err := worksheet.isEmpty()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("isEmpty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcelizeWorksheetHPageBreaks(t *testing.T) {
+	tests := []struct {
+		name   string
+		breaks []string
+		want   []int
+	}{
+		{name: "no breaks", want: nil},
+		{name: "breaks in order", breaks: []string{"A11", "A21"}, want: []int{11, 21}},
+		{name: "breaks out of order", breaks: []string{"B30", "A5"}, want: []int{5, 30}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "breaks.xlsx")
+			file := excelize.NewFile()
+			for _, cell := range tt.breaks {
+				if err := file.InsertPageBreak("Sheet1", cell); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := file.SetDefinedName(&excelize.DefinedName{Name: "_xlnm.Print_Area", RefersTo: "Sheet1!$A$1:$C$40", Scope: "Sheet1"}); err != nil {
+				t.Fatal(err)
+			}
+			// the worksheet loaded by excelize, and the one saved and opened again
+			worksheet := &ExcelizeWorksheet{file: file, sheetName: "Sheet1"}
+			if got, err := worksheet.HPageBreaks(); err != nil || !slices.Equal(got, tt.want) {
+				t.Errorf("HPageBreaks() = %v, %v, want %v", got, err, tt.want)
+			}
+			if err := file.SaveAs(path); err != nil {
+				t.Fatal(err)
+			}
+			saved, err := excelize.OpenFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer saved.Close()
+			worksheet = &ExcelizeWorksheet{file: saved, sheetName: "Sheet1"}
+			if got, err := worksheet.HPageBreaks(); err != nil || !slices.Equal(got, tt.want) {
+				t.Errorf("HPageBreaks() after saving = %v, %v, want %v", got, err, tt.want)
+			}
+			if got, err := worksheet.PrintArea(); err != nil || got != "$A$1:$C$40" {
+				t.Errorf("PrintArea() = %q, %v, want $A$1:$C$40", got, err)
+			}
+		})
+	}
+}
+
+// saveAndOpen saves the file and opens it again, to test what is written to the file.
+func saveAndOpen(t *testing.T, file *excelize.File) *excelize.File {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "saved.xlsx")
+	if err := file.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { saved.Close() })
+	return saved
+}
+
+func TestExcelizeWorksheetDataValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		validation DataValidation
+	}{
+		{
+			name:       "list items",
+			validation: DataValidation{Range: "B2:B10", Type: DataValidationTypeList, List: []string{"Open", "Closed"}, AllowBlank: true},
+		},
+		{
+			name:       "list reference",
+			validation: DataValidation{Range: "C2:C10", Type: DataValidationTypeList, Formula1: "$H$1:$H$3"},
+		},
+		{
+			name: "whole number between with messages",
+			validation: DataValidation{
+				Range: "D2:D10,F2:F10", Type: DataValidationTypeWhole, Operator: DataValidationOperatorBetween, Formula1: "1", Formula2: "100",
+				InputTitle: "Quantity", InputMessage: "1 to 100",
+				ErrorStyle: DataValidationErrorStyleWarning, ErrorTitle: "Invalid", ErrorMessage: "Out of range",
+			},
+		},
+		{
+			name:       "custom formula",
+			validation: DataValidation{Range: "E2:E10", Type: DataValidationTypeCustom, Formula1: `AND(E2>0,E2<>"x")`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := excelize.NewFile()
+			defer file.Close()
+			worksheet := &ExcelizeWorksheet{file: file, sheetName: "Sheet1"}
+			validation := tt.validation
+			if err := worksheet.AddDataValidation(&validation); err != nil {
+				t.Fatal(err)
+			}
+
+			worksheet = &ExcelizeWorksheet{file: saveAndOpen(t, file), sheetName: "Sheet1"}
+			got, err := worksheet.GetDataValidations()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 || !reflect.DeepEqual(got[0], tt.validation) {
+				t.Fatalf("GetDataValidations() = %+v, want %+v", got, tt.validation)
+			}
+			if err := worksheet.DeleteDataValidation(tt.validation.Range); err != nil {
+				t.Fatal(err)
+			}
+			if got, err := worksheet.GetDataValidations(); err != nil || len(got) != 0 {
+				t.Errorf("GetDataValidations() after deletion = %+v, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestExcelizeWorksheetConditionalFormat(t *testing.T) {
+	color := "#FF0000"
+	red := &CellStyle{Font: &FontStyle{Color: &color}}
+	tests := []struct {
+		name    string
+		formats []ConditionalFormat
+	}{
+		{
+			name:    "cell value with style",
+			formats: []ConditionalFormat{{Range: "B2:B10", Type: ConditionalFormatTypeCellValue, Operator: ConditionalFormatOperatorGreaterThan, Formula1: "100", Style: red}},
+		},
+		{
+			name:    "cell value between",
+			formats: []ConditionalFormat{{Range: "B2:B10", Type: ConditionalFormatTypeCellValue, Operator: ConditionalFormatOperatorBetween, Formula1: "1", Formula2: "10"}},
+		},
+		{
+			name: "rules for the same range",
+			formats: []ConditionalFormat{
+				{Range: "C2:C10,E2:E10", Type: ConditionalFormatTypeFormula, Formula1: "$C2>$D2", StopIfTrue: true},
+				{Range: "C2:C10,E2:E10", Type: ConditionalFormatTypeTop, Rank: 10, Percent: true},
+			},
+		},
+		{
+			name:    "color scale",
+			formats: []ConditionalFormat{{Range: "D2:D10", Type: ConditionalFormatTypeColorScale, MinColor: "#F8696B", MidColor: "#FFEB84", MaxColor: "#63BE7B"}},
+		},
+		{
+			name:    "icon set",
+			formats: []ConditionalFormat{{Range: "D2:D10", Type: ConditionalFormatTypeIconSet, IconStyle: "3Arrows", ReverseIcons: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := excelize.NewFile()
+			defer file.Close()
+			worksheet := &ExcelizeWorksheet{file: file, sheetName: "Sheet1"}
+			for _, format := range tt.formats {
+				if err := worksheet.AddConditionalFormat(&format); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			worksheet = &ExcelizeWorksheet{file: saveAndOpen(t, file), sheetName: "Sheet1"}
+			got, err := worksheet.GetConditionalFormats()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.formats) {
+				t.Fatalf("GetConditionalFormats() = %+v, want %+v", got, tt.formats)
+			}
+			for i, want := range tt.formats {
+				gotStyle, wantStyle := got[i].Style, want.Style
+				got[i].Style, want.Style = nil, nil
+				if !reflect.DeepEqual(got[i], want) {
+					t.Errorf("rule %d = %+v, want %+v", i, got[i], want)
+				}
+				if wantStyle != nil && (gotStyle == nil || gotStyle.Font == nil || gotStyle.Font.Color == nil || *gotStyle.Font.Color != color) {
+					t.Errorf("style of rule %d = %+v, want %+v", i, gotStyle, wantStyle)
+				}
+			}
+		})
+	}
+}
+
+func TestExcelizeWorksheetComment(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments []Comment
+		delete   string
+		want     []Comment
+	}{
+		{
+			name:     "with author",
+			comments: []Comment{{Cell: "B2", Author: "Alice", Text: "Check this\nvalue"}},
+			want:     []Comment{{Cell: "B2", Author: "Alice", Text: "Check this\nvalue"}},
+		},
+		{
+			// excelize records the default author name, as the file requires an author
+			name:     "without author",
+			comments: []Comment{{Cell: "A1", Text: "Note"}},
+			want:     []Comment{{Cell: "A1", Author: "Author", Text: "Note"}},
+		},
+		{
+			name:     "replace",
+			comments: []Comment{{Cell: "C3", Author: "Alice", Text: "old"}, {Cell: "C3", Author: "Bob", Text: "new"}},
+			want:     []Comment{{Cell: "C3", Author: "Bob", Text: "new"}},
+		},
+		{
+			name:     "delete",
+			comments: []Comment{{Cell: "A1", Author: "Alice", Text: "keep"}, {Cell: "D4", Author: "Alice", Text: "remove"}},
+			delete:   "D4",
+			want:     []Comment{{Cell: "A1", Author: "Alice", Text: "keep"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := excelize.NewFile()
+			defer file.Close()
+			worksheet := &ExcelizeWorksheet{file: file, sheetName: "Sheet1"}
+			for _, comment := range tt.comments {
+				if err := worksheet.SetComment(comment); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.delete != "" {
+				if err := worksheet.DeleteComment(tt.delete); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			worksheet = &ExcelizeWorksheet{file: saveAndOpen(t, file), sheetName: "Sheet1"}
+			got, err := worksheet.GetComments()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetComments() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcelizeWorksheetHyperlink(t *testing.T) {
+	tests := []struct {
+		name      string
+		hyperlink Hyperlink
+		want      Hyperlink
+	}{
+		{
+			name:      "external URL",
+			hyperlink: Hyperlink{Cell: "A1", Address: "https://example.com/", Display: "Example", Tooltip: "Open"},
+			want:      Hyperlink{Cell: "A1", Address: "https://example.com/"},
+		},
+		{
+			name:      "location in the workbook",
+			hyperlink: Hyperlink{Cell: "B2", Location: "'My Sheet'!A1:B2"},
+			want:      Hyperlink{Cell: "B2", Location: "'My Sheet'!A1:B2"},
+		},
+		{
+			name:      "location in another file",
+			hyperlink: Hyperlink{Cell: "C3", Address: "other.xlsx", Location: "Sheet1!A1"},
+			want:      Hyperlink{Cell: "C3", Address: "other.xlsx", Location: "Sheet1!A1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := excelize.NewFile()
+			defer file.Close()
+			worksheet := &ExcelizeWorksheet{file: file, sheetName: "Sheet1"}
+			// replaces the hyperlink set before
+			if err := worksheet.SetHyperlink(Hyperlink{Cell: tt.hyperlink.Cell, Address: "https://example.org/"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := worksheet.SetHyperlink(tt.hyperlink); err != nil {
+				t.Fatal(err)
+			}
+			if err := worksheet.SetHyperlink(Hyperlink{Cell: "D4", Address: "https://example.org/"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := worksheet.DeleteHyperlink("D4"); err != nil {
+				t.Fatal(err)
+			}
+
+			saved := saveAndOpen(t, file)
+			worksheet = &ExcelizeWorksheet{file: saved, sheetName: "Sheet1"}
+			got, err := worksheet.GetHyperlinks("A1:E5")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 || got[0] != tt.want {
+				t.Errorf("GetHyperlinks() = %+v, want %+v", got, tt.want)
+			}
+			if tt.hyperlink.Display != "" {
+				if value, err := saved.GetCellValue("Sheet1", tt.hyperlink.Cell); err != nil || value != tt.hyperlink.Display {
+					t.Errorf("value of %s = %q, %v, want %q", tt.hyperlink.Cell, value, err, tt.hyperlink.Display)
+				}
+			}
+		})
+	}
+}
+
+func TestExcelizeWorksheetChart(t *testing.T) {
+	tests := []struct {
+		name  string
+		chart Chart
+	}{
+		{
+			name: "column chart with titles",
+			chart: Chart{
+				Type: ChartTypeColumn, Title: "Sales", XAxisTitle: "Month", YAxisTitle: "Amount", LegendPosition: ChartLegendPositionBottom,
+				Series: []ChartSeries{
+					{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4"},
+					{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$C$2:$C$4"},
+				},
+			},
+		},
+		{
+			name: "combo chart",
+			chart: Chart{
+				Type: ChartTypeCombo, LegendPosition: ChartLegendPositionTopRight,
+				Series: []ChartSeries{
+					{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4", Type: ChartTypeColumn},
+					{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$C$2:$C$4", Type: ChartTypeLine},
+				},
+			},
+		},
+		{
+			name: "pie chart without legend",
+			chart: Chart{
+				Type: ChartTypePie, Title: "Share", LegendPosition: ChartLegendPositionNone,
+				Series: []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := excelize.NewFile()
+			defer file.Close()
+			rows := [][]any{{"Month", "Sales", "Cost"}, {"Jan", 10, 7}, {"Feb", 20, 12}, {"Mar", 30, 18}}
+			for i, row := range rows {
+				cell, _ := excelize.CoordinatesToCellName(1, i+1)
+				if err := file.SetSheetRow("Sheet1", cell, &row); err != nil {
+					t.Fatal(err)
+				}
+			}
+			worksheet := &ExcelizeWorksheet{file: file, sheetName: "Sheet1"}
+			chart := tt.chart
+			chart.Anchor = "E2"
+			if err := worksheet.AddChart(&chart); err != nil {
+				t.Fatal(err)
+			}
+
+			worksheet = &ExcelizeWorksheet{file: saveAndOpen(t, file), sheetName: "Sheet1"}
+			got, err := worksheet.GetCharts()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("GetCharts() = %+v, want 1 chart", got)
+			}
+			if !strings.HasPrefix(got[0].Anchor, "E2:") || got[0].Name == "" {
+				t.Errorf("anchor and name = %q, %q, want E2:... and a name", got[0].Anchor, got[0].Name)
+			}
+			got[0].Name, got[0].Anchor = "", ""
+			if !reflect.DeepEqual(got[0], tt.chart) {
+				t.Errorf("GetCharts() = %+v, want %+v", got[0], tt.chart)
+			}
+		})
+	}
+}
+
+func TestExcelizeWorksheetPivotTable(t *testing.T) {
+	tests := []struct {
+		name       string
+		pivotTable PivotTable
+		want       PivotTable
+	}{
+		{
+			name: "rows and values",
+			pivotTable: PivotTable{
+				Range: "G2", SourceRange: "Data!A1:C7", Rows: []string{"Region"},
+				Values: []PivotValueField{{Field: "Sales", Function: PivotFunctionSum, Name: "Sum of Sales"}},
+			},
+			// 3 regions, the header and the grand total
+			want: PivotTable{
+				Name: "PivotTable1", Range: "G2:H6", SourceRange: "Data!A1:C7", Rows: []string{"Region"},
+				Values: []PivotValueField{{Field: "Sales", Function: PivotFunctionSum, Name: "Sum of Sales"}},
+			},
+		},
+		{
+			name: "columns and filters",
+			pivotTable: PivotTable{
+				Name: "ByProduct", Range: "G2", SourceRange: "Data!$A$1:$C$7", Rows: []string{"Region"}, Columns: []string{"Product"},
+				Filters: []string{"Sales"}, Values: []PivotValueField{{Field: "Sales", Function: PivotFunctionCount, Name: "Count"}},
+			},
+			// placed below the filter and a blank row, with 2 products and the grand total column
+			want: PivotTable{
+				Name: "ByProduct", Range: "G4:J9", SourceRange: "Data!A1:C7", Rows: []string{"Region"}, Columns: []string{"Product"},
+				Filters: []string{"Sales"}, Values: []PivotValueField{{Field: "Sales", Function: PivotFunctionCount, Name: "Count"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := excelize.NewFile()
+			defer file.Close()
+			if _, err := file.NewSheet("Data"); err != nil {
+				t.Fatal(err)
+			}
+			rows := [][]any{{"Region", "Product", "Sales"}, {"East", "A", 10}, {"West", "B", 20}, {"East", "B", 30}, {"North", "A", 40}, {"West", "A", 50}, {"North", "B", 60}}
+			for i, row := range rows {
+				cell, _ := excelize.CoordinatesToCellName(1, i+1)
+				if err := file.SetSheetRow("Data", cell, &row); err != nil {
+					t.Fatal(err)
+				}
+			}
+			worksheet := &ExcelizeWorksheet{file: file, sheetName: "Sheet1"}
+			pivotTable := tt.pivotTable
+			if err := worksheet.AddPivotTable(&pivotTable); err != nil {
+				t.Fatal(err)
+			}
+			if pivotTable.Name != tt.want.Name || pivotTable.Range != tt.want.Range {
+				t.Errorf("added pivot table = %q %q, want %q %q", pivotTable.Name, pivotTable.Range, tt.want.Name, tt.want.Range)
+			}
+
+			worksheet = &ExcelizeWorksheet{file: saveAndOpen(t, file), sheetName: "Sheet1"}
+			got, err := worksheet.GetPivotTables()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 || !reflect.DeepEqual(got[0], tt.want) {
+				t.Errorf("GetPivotTables() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcelizeWorksheetPicture(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 3))); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		rangeStr string
+		want     []string
+	}{
+		{name: "whole sheet", want: []string{"B2", "D2", "C5"}},
+		{name: "range", rangeStr: "A1:C3", want: []string{"B2"}},
+		{name: "multiple areas", rangeStr: "C5,D1:D2", want: []string{"D2", "C5"}},
+		{name: "no picture", rangeStr: "A10:B20"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := excelize.NewFile()
+			defer file.Close()
+			worksheet := &ExcelizeWorksheet{file: file, sheetName: "Sheet1"}
+			for _, cell := range []string{"C5", "D2", "B2"} {
+				if err := worksheet.AddPicture(&Picture{Cell: cell, Extension: ".png", Data: buf.Bytes(), AltText: "at " + cell, ScaleX: 1, ScaleY: 1}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			worksheet = &ExcelizeWorksheet{file: saveAndOpen(t, file), sheetName: "Sheet1"}
+			got, err := worksheet.GetPictures(tt.rangeStr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var cells []string
+			for _, picture := range got {
+				cells = append(cells, picture.Cell)
+				if picture.Extension != ".png" || !bytes.Equal(picture.Data, buf.Bytes()) || picture.AltText != "at "+picture.Cell {
+					t.Errorf("picture at %s = %q, %d bytes, %q", picture.Cell, picture.Extension, len(picture.Data), picture.AltText)
+				}
+			}
+			// pictures are sorted by rows, then columns
+			if !slices.Equal(cells, tt.want) {
+				t.Errorf("GetPictures(%q) cells = %v, want %v", tt.rangeStr, cells, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcelizeWorksheetCopyRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		destSheet  string
+		destCell   string
+		options    CopyRangeOptions
+		want       map[string]string
+		wantStyled []string
+		wantMerged []string
+	}{
+		{
+			name:     "copy shifts formulas",
+			destCell: "E1", options: CopyRangeOptions{Mode: CopyModeAll},
+			want:       map[string]string{"A1": "1", "C1": "=A1*2", "E1": "1", "F1": "2", "G1": "=E1*2", "E2": "x", "F2": "TRUE", "E3": "merged"},
+			wantStyled: []string{"A1", "E1"},
+			wantMerged: []string{"A3:B3", "E3:F3"},
+		},
+		{
+			name:      "copy to another sheet",
+			destSheet: "Sheet2", destCell: "B2", options: CopyRangeOptions{Mode: CopyModeAll},
+			want:       map[string]string{"Sheet2!B2": "1", "Sheet2!D2": "=B2*2", "Sheet2!B3": "x"},
+			wantStyled: []string{"A1", "Sheet2!B2"},
+			wantMerged: []string{"A3:B3", "Sheet2!B4:C4"},
+		},
+		{
+			name:     "move keeps formulas",
+			destCell: "B5", options: CopyRangeOptions{Mode: CopyModeAll, Move: true},
+			want:       map[string]string{"A1": "", "C1": "", "A3": "", "B5": "1", "D5": "=A1*2", "B6": "x", "B7": "merged"},
+			wantStyled: []string{"B5"},
+			wantMerged: []string{"B7:C7"},
+		},
+		{
+			name:     "move overlapping the source",
+			destCell: "B2", options: CopyRangeOptions{Mode: CopyModeAll, Move: true},
+			want:       map[string]string{"A1": "", "B1": "", "A2": "", "B2": "1", "C2": "2", "D2": "=A1*2", "B3": "x", "C3": "TRUE", "B4": "merged"},
+			wantStyled: []string{"B2"},
+			wantMerged: []string{"B4:C4"},
+		},
+		{
+			name:     "transpose",
+			destCell: "E1", options: CopyRangeOptions{Mode: CopyModeAll, Transpose: true},
+			want:       map[string]string{"E1": "1", "E2": "2", "E3": "=C3*2", "F1": "x", "F2": "TRUE", "G1": "merged"},
+			wantStyled: []string{"A1", "E1"},
+			wantMerged: []string{"A3:B3", "G1:G2"},
+		},
+		{
+			name:     "values",
+			destCell: "E1", options: CopyRangeOptions{Mode: CopyModeValue},
+			want:       map[string]string{"E1": "1", "G1": "2", "E2": "x"},
+			wantStyled: []string{"A1"},
+			wantMerged: []string{"A3:B3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := excelize.NewFile()
+			defer file.Close()
+			if _, err := file.NewSheet("Sheet2"); err != nil {
+				t.Fatal(err)
+			}
+			for cell, value := range map[string]any{"A1": 1, "B1": 2, "A2": "x", "B2": true, "A3": "merged"} {
+				if err := file.SetCellValue("Sheet1", cell, value); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := file.SetCellFormula("Sheet1", "C1", "A1*2"); err != nil {
+				t.Fatal(err)
+			}
+			if err := file.MergeCell("Sheet1", "A3", "B3"); err != nil {
+				t.Fatal(err)
+			}
+			bold, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := file.SetCellStyle("Sheet1", "A1", "A1", bold); err != nil {
+				t.Fatal(err)
+			}
+			destSheet := cmp.Or(tt.destSheet, "Sheet1")
+			worksheet := &ExcelizeWorksheet{file: file, sheetName: "Sheet1"}
+			if err := worksheet.CopyRange("A1:C3", destSheet, tt.destCell, tt.options); err != nil {
+				t.Fatal(err)
+			}
+
+			saved := saveAndOpen(t, file)
+			split := func(ref string) (string, string) {
+				sheetName, cell := SplitSheetName(ref)
+				return cmp.Or(sheetName, "Sheet1"), cell
+			}
+			for ref, want := range tt.want {
+				sheetName, cell := split(ref)
+				got, err := saved.GetCellValue(sheetName, cell)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if formula, _ := saved.GetCellFormula(sheetName, cell); formula != "" {
+					got = "=" + formula
+				}
+				if got != want {
+					t.Errorf("%s = %q, want %q", ref, got, want)
+				}
+			}
+			for _, ref := range []string{"A1", "E1", "B2", "B5", "Sheet2!B2"} {
+				sheetName, cell := split(ref)
+				styleID, err := saved.GetCellStyle(sheetName, cell)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := slices.Contains(tt.wantStyled, ref); (styleID == bold) != want {
+					t.Errorf("%s is styled = %v, want %v", ref, styleID == bold, want)
+				}
+			}
+			var merged []string
+			for _, sheetName := range []string{"Sheet1", "Sheet2"} {
+				mergeCells, err := saved.GetMergeCells(sheetName)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, mergeCell := range mergeCells {
+					ref := mergeCell.GetStartAxis() + ":" + mergeCell.GetEndAxis()
+					if sheetName != "Sheet1" {
+						ref = sheetName + "!" + ref
+					}
+					merged = append(merged, ref)
+				}
+			}
+			if !slices.Equal(merged, tt.wantMerged) {
+				t.Errorf("merged cells = %v, want %v", merged, tt.wantMerged)
+			}
+		})
+	}
+}
+
+func TestExcelizeWorksheetSortRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		rangeStr  string
+		keys      []SortKey
+		hasHeader bool
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name:      "header and a number key",
+			rangeStr:  "A1:D5",
+			keys:      []SortKey{{Column: 3, Order: SortOrderAscending}},
+			hasHeader: true,
+			want:      []string{"Name", "a", "c", "b", "d"},
+		},
+		{
+			name:     "custom order and a descending key",
+			rangeStr: "A1:D5",
+			keys: []SortKey{
+				{Column: 2, Order: SortOrderAscending, CustomOrder: []string{"High", "Mid", "Low"}},
+				{Column: 3, Order: SortOrderDescending},
+			},
+			hasHeader: true,
+			want:      []string{"Name", "d", "a", "c", "b"},
+		},
+		{
+			name:     "without header",
+			rangeStr: "A1:D5",
+			keys:     []SortKey{{Column: 1, Order: SortOrderDescending}},
+			want:     []string{"Name", "d", "c", "b", "a"},
+		},
+		{
+			name:     "key out of the range",
+			rangeStr: "A1:C5",
+			keys:     []SortKey{{Column: 4, Order: SortOrderAscending}},
+			wantErr:  true,
+		},
+		{
+			name:     "merged cells",
+			rangeStr: "A1:F5",
+			keys:     []SortKey{{Column: 1, Order: SortOrderAscending}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := excelize.NewFile()
+			defer file.Close()
+			rows := [][]any{{"Name", "Priority", "Amount"}, {"b", "Low", 3}, {"a", "High", 1}, {"c", "Mid", 2}, {"d", "High", 5}}
+			for i, row := range rows {
+				cell, _ := excelize.CoordinatesToCellName(1, i+1)
+				if err := file.SetSheetRow("Sheet1", cell, &row); err != nil {
+					t.Fatal(err)
+				}
+				if i > 0 {
+					if err := file.SetCellFormula("Sheet1", fmt.Sprintf("D%d", i+1), fmt.Sprintf("C%d*10", i+1)); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			if err := file.MergeCell("Sheet1", "E2", "F3"); err != nil {
+				t.Fatal(err)
+			}
+			bold, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the row of "c"
+			if err := file.SetCellStyle("Sheet1", "A4", "D4", bold); err != nil {
+				t.Fatal(err)
+			}
+			worksheet := &ExcelizeWorksheet{file: file, sheetName: "Sheet1"}
+			err = worksheet.SortRange(tt.rangeStr, tt.keys, tt.hasHeader)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("SortRange() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			saved := saveAndOpen(t, file)
+			var got []string
+			for row := 1; row <= 5; row++ {
+				name, err := saved.GetCellValue("Sheet1", fmt.Sprintf("A%d", row))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, name)
+				if row == 1 {
+					continue
+				}
+				// formulas refer to the same row, and styles move with the row
+				if formula, _ := saved.GetCellFormula("Sheet1", fmt.Sprintf("D%d", row)); formula != fmt.Sprintf("C%d*10", row) {
+					t.Errorf("formula of row %d = %q", row, formula)
+				}
+				for _, col := range []string{"A", "D"} {
+					styleID, err := saved.GetCellStyle("Sheet1", fmt.Sprintf("%s%d", col, row))
+					if err != nil {
+						t.Fatal(err)
+					}
+					if want := name == "c"; (styleID == bold) != want {
+						t.Errorf("%s%d (%s) is styled = %v, want %v", col, row, name, styleID == bold, want)
+					}
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("sorted names = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcelizeWorksheetIsRowHidden(t *testing.T) {
+	tests := []struct {
+		name string
+		// reopen reads the rows from the saved file instead of the worksheet in memory
+		reopen bool
+	}{
+		{name: "in memory"},
+		{name: "saved", reopen: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := excelize.NewFile()
+			defer file.Close()
+			for _, cell := range []string{"A1", "A3", "A5"} {
+				if err := file.SetCellValue("Sheet1", cell, cell); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := file.SetRowVisible("Sheet1", 3, false); err != nil {
+				t.Fatal(err)
+			}
+			if tt.reopen {
+				file = saveAndOpen(t, file)
+			}
+			worksheet := &ExcelizeWorksheet{file: file, sheetName: "Sheet1"}
+			// rows without row element (2, 6 and below) are shown
+			for row, want := range map[int]bool{1: false, 2: false, 3: true, 4: false, 5: false, 6: false, 10: false} {
+				if got, err := worksheet.IsRowHidden(row); err != nil || got != want {
+					t.Errorf("IsRowHidden(%d) = %v, %v, want %v", row, got, err, want)
+				}
+			}
+			// rows hidden below the last row element
+			if err := worksheet.SetRowsHidden(8, 9, true); err != nil {
+				t.Fatal(err)
+			}
+			for row, want := range map[int]bool{7: false, 8: true, 9: true, 10: false} {
+				if got, err := worksheet.IsRowHidden(row); err != nil || got != want {
+					t.Errorf("IsRowHidden(%d) after hiding = %v, %v, want %v", row, got, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExcelizeWorksheetClearRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		rangeStr string
+		modes    []ClearMode
+		// want lists the values and formulas of A1, B1, C1 and A2
+		want           [4]string
+		wantStyled     []string
+		wantMerged     bool
+		wantComment    bool
+		wantHyperlink  bool
+		wantValidation bool
+		wantDimension  string
+	}{
+		{
+			name: "values", rangeStr: "A1:E5", modes: []ClearMode{ClearModeValue},
+			want: [4]string{"", "", "=B1*2", ""}, wantStyled: []string{"A1", "E5"}, wantMerged: true,
+			wantComment: true, wantHyperlink: true, wantValidation: true, wantDimension: "C1:C1",
+		},
+		{
+			name: "formulas", rangeStr: "A1:E5", modes: []ClearMode{ClearModeFormula},
+			want: [4]string{"a", "1", "", "b"}, wantStyled: []string{"A1", "E5"}, wantMerged: true,
+			wantComment: true, wantHyperlink: true, wantValidation: true, wantDimension: "A1:B2",
+		},
+		{
+			name: "styles out of the dimension", rangeStr: "A1:F6", modes: []ClearMode{ClearModeStyle},
+			want:        [4]string{"a", "1", "=B1*2", "b"},
+			wantComment: true, wantHyperlink: true, wantValidation: true, wantDimension: "A1:C2",
+		},
+		{
+			name: "styles in a part", rangeStr: "E5:E5", modes: []ClearMode{ClearModeStyle},
+			want: [4]string{"a", "1", "=B1*2", "b"}, wantStyled: []string{"A1"}, wantMerged: true,
+			wantComment: true, wantHyperlink: true, wantValidation: true, wantDimension: "A1:C2",
+		},
+		{
+			name: "comments", rangeStr: "A1:E5", modes: []ClearMode{ClearModeComment},
+			want: [4]string{"a", "1", "=B1*2", "b"}, wantStyled: []string{"A1", "E5"}, wantMerged: true,
+			wantHyperlink: true, wantValidation: true, wantDimension: "A1:C2",
+		},
+		{
+			name: "hyperlinks", rangeStr: "A1:E5", modes: []ClearMode{ClearModeHyperlink},
+			want: [4]string{"a", "1", "=B1*2", "b"}, wantStyled: []string{"A1", "E5"}, wantMerged: true,
+			wantComment: true, wantValidation: true, wantDimension: "A1:C2",
+		},
+		{
+			name: "data validations", rangeStr: "A1:E5", modes: []ClearMode{ClearModeDataValidation},
+			want: [4]string{"a", "1", "=B1*2", "b"}, wantStyled: []string{"A1", "E5"}, wantMerged: true,
+			wantComment: true, wantHyperlink: true, wantDimension: "A1:C2",
+		},
+		{
+			name: "all", rangeStr: "A1:E5", modes: []ClearMode{ClearModeAll},
+			wantDimension: "A1",
+		},
+		{
+			name: "dimension shrinks to the remaining cells", rangeStr: "B1:C2", modes: []ClearMode{ClearModeValue, ClearModeFormula},
+			want: [4]string{"a", "", "", "b"}, wantStyled: []string{"A1", "E5"}, wantMerged: true,
+			wantComment: true, wantHyperlink: true, wantValidation: true, wantDimension: "A1:A2",
+		},
+		{
+			name: "dimension is kept by the cells around", rangeStr: "B1:B2", modes: []ClearMode{ClearModeValue},
+			want: [4]string{"a", "", "=B1*2", "b"}, wantStyled: []string{"A1", "E5"}, wantMerged: true,
+			wantComment: true, wantHyperlink: true, wantValidation: true, wantDimension: "A1:C2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := excelize.NewFile()
+			defer file.Close()
+			for cell, value := range map[string]any{"A1": "a", "B1": 1, "A2": "b", "B2": 2} {
+				if err := file.SetCellValue("Sheet1", cell, value); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := file.SetCellFormula("Sheet1", "C1", "B1*2"); err != nil {
+				t.Fatal(err)
+			}
+			bold, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			// E5 is a styled cell out of the dimension
+			for _, cell := range []string{"A1", "E5"} {
+				if err := file.SetCellStyle("Sheet1", cell, cell, bold); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := file.MergeCell("Sheet1", "A4", "B4"); err != nil {
+				t.Fatal(err)
+			}
+			if err := file.SetSheetDimension("Sheet1", "A1:C2"); err != nil {
+				t.Fatal(err)
+			}
+			worksheet := &ExcelizeWorksheet{file: file, sheetName: "Sheet1"}
+			if err := worksheet.SetComment(Comment{Cell: "A1", Author: "Alice", Text: "note"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := worksheet.SetHyperlink(Hyperlink{Cell: "A2", Address: "https://example.com/"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := worksheet.AddDataValidation(&DataValidation{Range: "B1:B2", Type: DataValidationTypeWhole, Operator: DataValidationOperatorGreaterThan, Formula1: "0"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := worksheet.ClearRange(tt.rangeStr, tt.modes); err != nil {
+				t.Fatal(err)
+			}
+
+			saved := saveAndOpen(t, file)
+			worksheet = &ExcelizeWorksheet{file: saved, sheetName: "Sheet1"}
+			for i, cell := range []string{"A1", "B1", "C1", "A2"} {
+				got, err := saved.GetCellValue("Sheet1", cell)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if formula, _ := saved.GetCellFormula("Sheet1", cell); formula != "" {
+					got = "=" + formula
+				}
+				if got != tt.want[i] {
+					t.Errorf("%s = %q, want %q", cell, got, tt.want[i])
+				}
+			}
+			for _, cell := range []string{"A1", "E5"} {
+				styleID, err := saved.GetCellStyle("Sheet1", cell)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := slices.Contains(tt.wantStyled, cell); (styleID != 0) != want {
+					t.Errorf("%s is styled = %v, want %v", cell, styleID != 0, want)
+				}
+			}
+			if mergeCells, err := saved.GetMergeCells("Sheet1"); err != nil || (len(mergeCells) > 0) != tt.wantMerged {
+				t.Errorf("merged cells = %v, %v, want merged %v", mergeCells, err, tt.wantMerged)
+			}
+			if comments, err := worksheet.GetComments(); err != nil || (len(comments) > 0) != tt.wantComment {
+				t.Errorf("comments = %v, %v, want comment %v", comments, err, tt.wantComment)
+			}
+			if hyperlinks, err := worksheet.GetHyperlinks("A1:E5"); err != nil || (len(hyperlinks) > 0) != tt.wantHyperlink {
+				t.Errorf("hyperlinks = %v, %v, want hyperlink %v", hyperlinks, err, tt.wantHyperlink)
+			}
+			if validations, err := worksheet.GetDataValidations(); err != nil || (len(validations) > 0) != tt.wantValidation {
+				t.Errorf("data validations = %v, %v, want validation %v", validations, err, tt.wantValidation)
+			}
+			if dimension, err := worksheet.GetDimention(); err != nil || dimension != tt.wantDimension {
+				t.Errorf("GetDimention() = %q, %v, want %q", dimension, err, tt.wantDimension)
+			}
+		})
+	}
+}
+
+func TestExcelizeExcelCopySheetToWorkbook(t *testing.T) {
+	longName := "T" + strings.Repeat("a", 253)
+	tests := []struct {
+		name          string
+		srcTableName  string
+		destTableName string
+		wantTableName string
+		wantErr       bool
+	}{
+		{name: "copy styles and table", srcTableName: "Data", destTableName: "Other", wantTableName: "Data"},
+		{name: "rename table", srcTableName: "Data", destTableName: "data", wantTableName: "Data_2"},
+		{name: "delete sheet on failure", srcTableName: longName, destTableName: longName, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := excelize.NewFile()
+			defer src.Close()
+			bold, err := src.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			red, err := src.NewStyle(&excelize.Style{Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"FF0000"}}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			blue, err := src.NewStyle(&excelize.Style{Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"0000FF"}}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := src.SetColStyle("Sheet1", "D", red); err != nil {
+				t.Fatal(err)
+			}
+			if err := src.SetRowStyle("Sheet1", 5, 5, blue); err != nil {
+				t.Fatal(err)
+			}
+			if err := src.SetSheetRow("Sheet1", "A1", &[]any{"Name", "Value"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := src.SetSheetRow("Sheet1", "A2", &[]any{"a", 1}); err != nil {
+				t.Fatal(err)
+			}
+			if err := src.SetCellStyle("Sheet1", "A1", "B1", bold); err != nil {
+				t.Fatal(err)
+			}
+			if err := src.AddTable("Sheet1", &excelize.Table{Range: "A1:B2", Name: tt.srcTableName}); err != nil {
+				t.Fatal(err)
+			}
+			if err := src.SetSheetDimension("Sheet1", "A1:B2"); err != nil {
+				t.Fatal(err)
+			}
+
+			dest := excelize.NewFile()
+			defer dest.Close()
+			// the destination has its own styles, so the style IDs differ
+			if _, err := dest.NewStyle(&excelize.Style{Font: &excelize.Font{Italic: true}}); err != nil {
+				t.Fatal(err)
+			}
+			if err := dest.SetSheetRow("Sheet1", "A1", &[]any{"Key", "Count"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := dest.AddTable("Sheet1", &excelize.Table{Range: "A1:B2", Name: tt.destTableName}); err != nil {
+				t.Fatal(err)
+			}
+
+			err = NewExcelizeExcel(src).CopySheetToWorkbook("Sheet1", NewExcelizeExcel(dest), "Copied")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("CopySheetToWorkbook() succeeded, want error")
+				}
+				if index, _ := dest.GetSheetIndex("Copied"); index >= 0 {
+					t.Errorf("sheets = %v, want the copied sheet to be deleted", dest.GetSheetList())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			saved := saveAndOpen(t, dest)
+			assertStyle := func(name string, styleID int, check func(*excelize.Style) bool) {
+				t.Helper()
+				style, err := saved.GetStyle(styleID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !check(style) {
+					t.Errorf("style of %s = %+v", name, style)
+				}
+			}
+			isBold := func(style *excelize.Style) bool { return style.Font != nil && style.Font.Bold }
+			filled := func(color string) func(*excelize.Style) bool {
+				return func(style *excelize.Style) bool {
+					return slices.Equal(style.Fill.Color, []string{color})
+				}
+			}
+			cellStyle, err := saved.GetCellStyle("Copied", "A1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertStyle("A1", cellStyle, isBold)
+			colStyle, err := saved.GetColStyle("Copied", "D")
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertStyle("column D", colStyle, filled("FF0000"))
+			rowStyle, err := saved.GetCellStyle("Copied", "F5")
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertStyle("row 5", rowStyle, filled("0000FF"))
+			if value, err := saved.GetCellValue("Copied", "B2"); err != nil {
+				t.Fatal(err)
+			} else if value != "1" {
+				t.Errorf("B2 = %q, want %q", value, "1")
+			}
+
+			tables, err := saved.GetTables("Copied")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tables) != 1 || tables[0].Name != tt.wantTableName {
+				t.Errorf("tables = %+v, want %s", tables, tt.wantTableName)
+			}
+		})
+	}
+}
@@ -6,14 +6,21 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"math"
+	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+	"unsafe"
 
 	"github.com/go-ole/go-ole"
 	"github.com/go-ole/go-ole/oleutil"
 	"github.com/skanehira/clipboard-image"
+	"github.com/xuri/excelize/v2"
 )
 
 type OleExcel struct {
@@ -24,6 +31,8 @@ type OleExcel struct {
 type OleWorksheet struct {
 	excel     *OleExcel
 	worksheet *ole.IDispatch
+	// regions caches the detected data regions. It is reset when values are written
+	regions dataRegionsCache
 }
 
 func NewExcelOle(absolutePath string) (*OleExcel, func(), error) {
@@ -202,6 +211,135 @@ func (o *OleExcel) CopySheet(srcSheetName string, dstSheetName string) error {
 	return nil
 }
 
+func (o *OleExcel) CopySheetToWorkbook(srcSheetName string, destWorkbook Excel, destSheetName string) error {
+	dest, ok := destWorkbook.(*OleExcel)
+	if !ok {
+		return fmt.Errorf("destination workbook is opened by another backend: %s", destWorkbook.GetBackendName())
+	}
+	worksheets := oleutil.MustGetProperty(o.workbook, "Worksheets").ToIDispatch()
+	defer worksheets.Release()
+	destWorksheets := oleutil.MustGetProperty(dest.workbook, "Worksheets").ToIDispatch()
+	defer destWorksheets.Release()
+
+	srcSheetVariant, err := oleutil.GetProperty(worksheets, "Item", srcSheetName)
+	if err != nil {
+		return fmt.Errorf("failed to get sheet: %w", err)
+	}
+	srcSheet := srcSheetVariant.ToIDispatch()
+	defer srcSheet.Release()
+
+	destCount := int(oleutil.MustGetProperty(destWorksheets, "Count").Val)
+	lastSheet := oleutil.MustGetProperty(destWorksheets, "Item", destCount).ToIDispatch()
+	defer lastSheet.Release()
+
+	// Excel copies styles and tables across workbooks, and renames tables whose names are already used
+	if _, err := oleutil.CallMethod(srcSheet, "Copy", nil, lastSheet); err != nil {
+		return fmt.Errorf("failed to copy sheet: %w", err)
+	}
+
+	destSheetVariant, err := oleutil.GetProperty(destWorksheets, "Item", destCount+1)
+	if err != nil {
+		return fmt.Errorf("failed to get copied sheet: %w", err)
+	}
+	destSheet := destSheetVariant.ToIDispatch()
+	defer destSheet.Release()
+
+	if _, err := oleutil.PutProperty(destSheet, "Name", destSheetName); err != nil {
+		return fmt.Errorf("failed to rename copied sheet: %w", err)
+	}
+	return nil
+}
+
+func (o *OleExcel) GetDefinedNames() ([]DefinedName, error) {
+	names := oleutil.MustGetProperty(o.workbook, "Names").ToIDispatch()
+	defer names.Release()
+
+	count := int(oleutil.MustGetProperty(names, "Count").Val)
+	definedNames := make([]DefinedName, count)
+	for i := 1; i <= count; i++ {
+		name := oleutil.MustGetProperty(names, "Item", i).ToIDispatch()
+		defer name.Release()
+		// Sheet-scoped names are returned as "Sheet1!Name"
+		scope, localName := SplitSheetName(oleutil.MustGetProperty(name, "Name").ToString())
+		definedNames[i-1] = DefinedName{
+			Name:     localName,
+			Scope:    scope,
+			RefersTo: strings.TrimPrefix(oleutil.MustGetProperty(name, "RefersTo").ToString(), "="),
+			Comment:  oleutil.MustGetProperty(name, "Comment").ToString(),
+		}
+	}
+	return definedNames, nil
+}
+
+func (o *OleExcel) SetDefinedName(definedName DefinedName) error {
+	refersTo := "=" + strings.TrimPrefix(definedName.RefersTo, "=")
+	name, err := o.findName(definedName.Name, definedName.Scope)
+	if err != nil {
+		return err
+	}
+	if name == nil {
+		var names *ole.IDispatch
+		if definedName.Scope == "" {
+			names = oleutil.MustGetProperty(o.workbook, "Names").ToIDispatch()
+		} else {
+			worksheet, err := o.FindSheet(definedName.Scope)
+			if err != nil {
+				return err
+			}
+			defer worksheet.Release()
+			names = oleutil.MustGetProperty(worksheet.(*OleWorksheet).worksheet, "Names").ToIDispatch()
+		}
+		defer names.Release()
+		// https://learn.microsoft.com/ja-jp/office/vba/api/excel.names.add
+		nameVar, err := oleutil.CallMethod(names, "Add", definedName.Name, refersTo)
+		if err != nil {
+			return fmt.Errorf("failed to add defined name: %w", err)
+		}
+		name = nameVar.ToIDispatch()
+	} else {
+		if _, err := oleutil.PutProperty(name, "RefersTo", refersTo); err != nil {
+			return fmt.Errorf("failed to update defined name: %w", err)
+		}
+	}
+	defer name.Release()
+	if _, err := oleutil.PutProperty(name, "Comment", definedName.Comment); err != nil {
+		return fmt.Errorf("failed to set comment of defined name: %w", err)
+	}
+	return nil
+}
+
+func (o *OleExcel) DeleteDefinedName(name, scope string) error {
+	definedName, err := o.findName(name, scope)
+	if err != nil {
+		return err
+	}
+	if definedName == nil {
+		return fmt.Errorf("defined name not found: %s", name)
+	}
+	defer definedName.Release()
+	if _, err := oleutil.CallMethod(definedName, "Delete"); err != nil {
+		return fmt.Errorf("failed to delete defined name: %w", err)
+	}
+	return nil
+}
+
+// findName returns the Name object matching the name and scope, or nil if it does not exist.
+func (o *OleExcel) findName(name, scope string) (*ole.IDispatch, error) {
+	names := oleutil.MustGetProperty(o.workbook, "Names").ToIDispatch()
+	defer names.Release()
+
+	count := int(oleutil.MustGetProperty(names, "Count").Val)
+	for i := 1; i <= count; i++ {
+		item := oleutil.MustGetProperty(names, "Item", i).ToIDispatch()
+		itemScope, itemName := SplitSheetName(oleutil.MustGetProperty(item, "Name").ToString())
+		if strings.EqualFold(itemName, name) && itemScope == scope {
+			return item, nil
+		}
+		item.Release()
+	}
+	return nil, nil
+}
+
 func (o *OleExcel) Save() error {
 	_, err := oleutil.CallMethod(o.workbook, "Save")
 	if err != nil {
@@ -210,6 +348,10 @@ func (o *OleExcel) Save() error {
 	return nil
 }
 
+func (o *OleWorksheet) cachedDataRegions() *dataRegionsCache {
+	return &o.regions
+}
+
 func (o *OleWorksheet) Release() {
 	o.worksheet.Release()
 }
@@ -250,15 +392,114 @@ func (o *OleWorksheet) GetPivotTables() ([]PivotTable, error) {
 		name := oleutil.MustGetProperty(pivotTable, "Name").ToString()
 		pivotTableRange := oleutil.MustGetProperty(pivotTable, "TableRange1").ToIDispatch()
 		defer pivotTableRange.Release()
+		// SourceData is in R1C1 format (e.g. "Sheet1!R1C1:R31C5") or a name
+		sourceData, _ := oleutil.MustGetProperty(pivotTable, "SourceData").Value().(string)
+		dataFields := oleutil.MustGetProperty(pivotTable, "DataFields").ToIDispatch()
+		defer dataFields.Release()
+		dataFieldCount := int(oleutil.MustGetProperty(dataFields, "Count").Val)
+		values := make([]PivotValueField, dataFieldCount)
+		for j := 1; j <= dataFieldCount; j++ {
+			dataField := oleutil.MustGetProperty(dataFields, "Item", j).ToIDispatch()
+			defer dataField.Release()
+			values[j-1] = PivotValueField{
+				Field:    oleutil.MustGetProperty(dataField, "SourceName").ToString(),
+				Function: excelToPivotFunction(int32(oleutil.MustGetProperty(dataField, "Function").Val)),
+				Name:     oleutil.MustGetProperty(dataField, "Name").ToString(),
+			}
+		}
 		pivotTableList[i-1] = PivotTable{
-			Name:  name,
-			Range: NormalizeRange(oleutil.MustGetProperty(pivotTableRange, "Address").ToString()),
+			Name:        name,
+			Range:       NormalizeRange(oleutil.MustGetProperty(pivotTableRange, "Address").ToString()),
+			SourceRange: normalizeSourceRange(sourceData),
+			Rows:        getPivotFieldNames(pivotTable, "RowFields"),
+			Columns:     getPivotFieldNames(pivotTable, "ColumnFields"),
+			Filters:     getPivotFieldNames(pivotTable, "PageFields"),
+			Values:      values,
 		}
 	}
 	return pivotTableList, nil
 }
 
+func (o *OleWorksheet) AddPivotTable(pivotTable *PivotTable) error {
+	source := pivotTable.SourceRange
+	if sheetName, ref := SplitSheetName(source); sheetName != "" {
+		// SourceData is recommended to be in R1C1 format
+		startCol, startRow, endCol, endRow, err := ParseRange(ref)
+		if err != nil {
+			return err
+		}
+		source = fmt.Sprintf("%s!R%dC%d:R%dC%d", QuoteSheetName(sheetName), startRow, startCol, endRow, endCol)
+	}
+	workbook := oleutil.MustGetProperty(o.worksheet, "Parent").ToIDispatch()
+	defer workbook.Release()
+	pivotCaches := oleutil.MustCallMethod(workbook, "PivotCaches").ToIDispatch()
+	defer pivotCaches.Release()
+	// https://learn.microsoft.com/en-us/office/vba/api/excel.pivotcaches.create
+	pivotCacheVar, err := oleutil.CallMethod(pivotCaches, "Create", int32(1), source) // xlDatabase
+	if err != nil {
+		return fmt.Errorf("failed to create pivot cache: %w", err)
+	}
+	pivotCache := pivotCacheVar.ToIDispatch()
+	defer pivotCache.Release()
+
+	destination := oleutil.MustGetProperty(o.worksheet, "Range", pivotTable.Range).ToIDispatch()
+	defer destination.Release()
+	args := []any{destination}
+	if pivotTable.Name != "" {
+		args = append(args, pivotTable.Name)
+	}
+	pivotTableVar, err := oleutil.CallMethod(pivotCache, "CreatePivotTable", args...)
+	if err != nil {
+		return fmt.Errorf("failed to create pivot table: %w", err)
+	}
+	pt := pivotTableVar.ToIDispatch()
+	defer pt.Release()
+
+	axes := []struct {
+		orientation int32
+		names       []string
+	}{
+		{1, pivotTable.Rows},    // xlRowField
+		{2, pivotTable.Columns}, // xlColumnField
+		{3, pivotTable.Filters}, // xlPageField
+	}
+	for _, axis := range axes {
+		for i, name := range axis.names {
+			fieldVar, err := oleutil.CallMethod(pt, "PivotFields", name)
+			if err != nil {
+				return fmt.Errorf("field not found: %s", name)
+			}
+			field := fieldVar.ToIDispatch()
+			defer field.Release()
+			oleutil.MustPutProperty(field, "Orientation", axis.orientation)
+			oleutil.MustPutProperty(field, "Position", int32(i+1))
+		}
+	}
+	for _, value := range pivotTable.Values {
+		fieldVar, err := oleutil.CallMethod(pt, "PivotFields", value.Field)
+		if err != nil {
+			return fmt.Errorf("field not found: %s", value.Field)
+		}
+		field := fieldVar.ToIDispatch()
+		defer field.Release()
+		// https://learn.microsoft.com/en-us/office/vba/api/excel.pivottable.adddatafield
+		dataField := oleutil.MustCallMethod(pt, "AddDataField", field).ToIDispatch()
+		defer dataField.Release()
+		oleutil.MustPutProperty(dataField, "Function", pivotFunctionToExcel(value.Function))
+		if value.Name != "" {
+			oleutil.MustPutProperty(dataField, "Caption", value.Name)
+		}
+	}
+
+	pivotTable.Name = oleutil.MustGetProperty(pt, "Name").ToString()
+	tableRange := oleutil.MustGetProperty(pt, "TableRange1").ToIDispatch()
+	defer tableRange.Release()
+	pivotTable.Range = NormalizeRange(oleutil.MustGetProperty(tableRange, "Address").ToString())
+	return nil
+}
+
 func (o *OleWorksheet) SetValue(cell string, value any) error {
+	o.regions = dataRegionsCache{}
 	range_ := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
 	defer range_.Release()
 	_, err := oleutil.PutProperty(range_, "Value", value)
@@ -266,12 +507,95 @@ func (o *OleWorksheet) SetValue(cell string, value any) error {
 }
 
 func (o *OleWorksheet) SetFormula(cell string, formula string) error {
+	o.regions = dataRegionsCache{}
 	range_ := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
 	defer range_.Release()
 	_, err := oleutil.PutProperty(range_, "Formula", formula)
 	return err
 }
 
+func (o *OleWorksheet) SetRangeValues(rangeStr string, values [][]any) error {
+	o.regions = dataRegionsCache{}
+	startCol, startRow, endCol, endRow, err := ParseRange(rangeStr)
+	if err != nil {
+		return err
+	}
+	rows, cols := endRow-startRow+1, endCol-startCol+1
+	if len(values) != rows {
+		return fmt.Errorf("number of rows in data (%d) does not match range size (%d)", len(values), rows)
+	}
+	// elements are stored column by column
+	elements := make([]ole.VARIANT, rows*cols)
+	defer func() {
+		for i := range elements {
+			ole.VariantClear(&elements[i])
+		}
+	}()
+	for i, row := range values {
+		if len(row) != cols {
+			return fmt.Errorf("number of columns in row %d (%d) does not match range size (%d)", i, len(row), cols)
+		}
+		for j, value := range row {
+			elements[j*rows+i] = textOrNumberToVariant(value)
+		}
+	}
+	array := &safeArray2D{
+		safeArrayHeader: safeArrayHeader{
+			Dimensions:  2,
+			Features:    safeArrayFeatureAuto | safeArrayFeatureFixedSize | safeArrayFeatureVariant,
+			ElementSize: uint32(unsafe.Sizeof(ole.VARIANT{})),
+			Data:        unsafe.Pointer(&elements[0]),
+		},
+		// bounds are listed from the last dimension
+		Bounds: [2]ole.SafeArrayBound{{Elements: uint32(cols), LowerBound: 1}, {Elements: uint32(rows), LowerBound: 1}},
+	}
+	variant := ole.NewVariant(ole.VT_ARRAY|ole.VT_VARIANT, int64(uintptr(unsafe.Pointer(array))))
+
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", NormalizeRange(rangeStr)).ToIDispatch()
+	defer rng.Release()
+	// a string starting with "=" is entered as a formula, as typed by a user
+	_, err = oleutil.PutProperty(rng, "Value", &variant)
+	runtime.KeepAlive(array)
+	return err
+}
+
+const (
+	safeArrayFeatureAuto      = 0x0001
+	safeArrayFeatureFixedSize = 0x0010
+	safeArrayFeatureVariant   = 0x0800
+)
+
+// safeArray2D is the layout of SAFEARRAY with 2 dimensions, which go-ole can not create.
+type safeArray2D struct {
+	safeArrayHeader
+	Bounds [2]ole.SafeArrayBound
+}
+
+// textOrNumberToVariant converts a value of a cell to a variant. Values other than numbers, booleans and nil are written as texts.
+func textOrNumberToVariant(value any) ole.VARIANT {
+	switch v := value.(type) {
+	case nil:
+		return ole.NewVariant(ole.VT_EMPTY, 0)
+	case bool:
+		if v {
+			return ole.NewVariant(ole.VT_BOOL, -1)
+		}
+		return ole.NewVariant(ole.VT_BOOL, 0)
+	case float64:
+		return ole.NewVariant(ole.VT_R8, int64(math.Float64bits(v)))
+	case float32:
+		return ole.NewVariant(ole.VT_R8, int64(math.Float64bits(float64(v))))
+	case int:
+		return ole.NewVariant(ole.VT_R8, int64(math.Float64bits(float64(v))))
+	case int64:
+		return ole.NewVariant(ole.VT_R8, int64(math.Float64bits(float64(v))))
+	case string:
+		return ole.NewVariant(ole.VT_BSTR, int64(uintptr(unsafe.Pointer(ole.SysAllocString(v)))))
+	default:
+		return ole.NewVariant(ole.VT_BSTR, int64(uintptr(unsafe.Pointer(ole.SysAllocString(fmt.Sprint(v))))))
+	}
+}
+
 func (o *OleWorksheet) GetValue(cell string) (string, error) {
 	range_ := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
 	defer range_.Release()
@@ -293,6 +617,160 @@ func (o *OleWorksheet) GetFormula(cell string) (string, error) {
 	return formula, nil
 }
 
+func (o *OleWorksheet) GetRawValue(cell string) (string, CellValueKind, error) {
+	range_ := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
+	defer range_.Release()
+	// Value2 has dates as serial numbers, unlike Value
+	v, err := oleutil.GetProperty(range_, "Value2")
+	if err != nil {
+		return "", CellValueKindEmpty, err
+	}
+	defer v.Clear()
+	switch v.VT {
+	case ole.VT_EMPTY, ole.VT_NULL:
+		return "", CellValueKindEmpty, nil
+	case ole.VT_BOOL:
+		if v.Val != 0 {
+			return "TRUE", CellValueKindBool, nil
+		}
+		return "FALSE", CellValueKindBool, nil
+	case ole.VT_ERROR:
+		return oleutil.MustGetProperty(range_, "Text").ToString(), CellValueKindError, nil
+	case ole.VT_BSTR:
+		return v.ToString(), CellValueKindString, nil
+	}
+	switch value := v.Value().(type) {
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), CellValueKindNumber, nil
+	case float32, int8, int16, int32, int64, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(value), CellValueKindNumber, nil
+	default:
+		return "", CellValueKindEmpty, fmt.Errorf("unsupported type: %T", value)
+	}
+}
+
+func (o *OleWorksheet) GetRangeValues(rangeStr string) ([][]CellData, error) {
+	startCol, startRow, endCol, endRow, err := ParseRange(rangeStr)
+	if err != nil {
+		return nil, err
+	}
+	rows, cols := endRow-startRow+1, endCol-startCol+1
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", NormalizeRange(rangeStr)).ToIDispatch()
+	defer rng.Release()
+
+	values, err := getRangeArray(rng, "Value", rows, cols)
+	if err != nil {
+		return nil, err
+	}
+	// Value2 has dates as serial numbers, unlike Value
+	rawValues, err := getRangeArray(rng, "Value2", rows, cols)
+	if err != nil {
+		return nil, err
+	}
+	formulas, err := getRangeArray(rng, "Formula", rows, cols)
+	if err != nil {
+		return nil, err
+	}
+	result := make([][]CellData, rows)
+	for i := range result {
+		result[i] = make([]CellData, cols)
+		for j := range result[i] {
+			result[i][j] = CellData{Value: values[i][j], RawValue: rawValues[i][j], StyleID: -1}
+			if strings.HasPrefix(formulas[i][j], "=") {
+				result[i][j].Formula = formulas[i][j]
+			}
+		}
+	}
+	return result, nil
+}
+
+// safeArrayHeader is the layout of SAFEARRAY. ole.SafeArray can not be used to access elements
+// because its Data field is 32 bits.
+type safeArrayHeader struct {
+	Dimensions  uint16
+	Features    uint16
+	ElementSize uint32
+	Locks       uint32
+	Data        unsafe.Pointer
+}
+
+// getRangeArray returns the property (e.g. Value, Formula) of the range as texts indexed by [row][column].
+// Excel returns a 2D array of variants for multiple cells, which go-ole can not convert.
+func getRangeArray(rng *ole.IDispatch, name string, rows, cols int) ([][]string, error) {
+	property, err := oleutil.GetProperty(rng, name)
+	if err != nil {
+		return nil, err
+	}
+	defer property.Clear()
+
+	result := make([][]string, rows)
+	for i := range result {
+		result[i] = make([]string, cols)
+	}
+	if property.VT&ole.VT_ARRAY == 0 {
+		// a single cell
+		result[0][0] = variantToText(property)
+		return result, nil
+	}
+	array := property.ToArray()
+	arrayRows, _ := array.TotalElements(1)
+	arrayCols, _ := array.TotalElements(2)
+	header := (*safeArrayHeader)(unsafe.Pointer(array.Array))
+	elementSize := unsafe.Sizeof(ole.VARIANT{})
+	if header.Dimensions != 2 || int(arrayRows) != rows || int(arrayCols) != cols || uintptr(header.ElementSize) != elementSize {
+		return nil, fmt.Errorf("unexpected array of %s: %d dimensions, %dx%d", name, header.Dimensions, arrayRows, arrayCols)
+	}
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			// elements are stored column by column
+			element := (*ole.VARIANT)(unsafe.Add(header.Data, uintptr(j*rows+i)*elementSize))
+			result[i][j] = variantToText(element)
+		}
+	}
+	return result, nil
+}
+
+// variantToText converts a cell value of Excel to text
+func variantToText(v *ole.VARIANT) string {
+	if v.VT == ole.VT_ERROR {
+		if text, ok := excelErrorTexts[int(v.Val&0xffff)]; ok {
+			return text
+		}
+		return "#ERROR"
+	}
+	switch value := v.Value().(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case bool:
+		if value {
+			return "TRUE"
+		}
+		return "FALSE"
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case time.Time:
+		if value.Hour() == 0 && value.Minute() == 0 && value.Second() == 0 {
+			return value.Format("2006-01-02")
+		}
+		return value.Format("2006-01-02 15:04:05")
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// excelErrorTexts maps error codes of Excel (CVErr) to their texts
+var excelErrorTexts = map[int]string{
+	2000: "#NULL!",
+	2007: "#DIV/0!",
+	2015: "#VALUE!",
+	2023: "#REF!",
+	2029: "#NAME?",
+	2036: "#NUM!",
+	2042: "#N/A",
+}
+
 func (o *OleWorksheet) GetDimention() (string, error) {
 	range_ := oleutil.MustGetProperty(o.worksheet, "UsedRange").ToIDispatch()
 	defer range_.Release()
@@ -349,20 +827,28 @@ func (o *OleWorksheet) CapturePicture(captureRange string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	// Read the image from the clipboard
+	data, err := readClipboardImage()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// readClipboardImage reads the image copied to the clipboard as PNG.
+func readClipboardImage() ([]byte, error) {
 	buf := new(bytes.Buffer)
 	bufWriter := bufio.NewWriter(buf)
 	clipboardReader, err := clipboard.ReadFromClipboard()
 	if err != nil {
-		return "", fmt.Errorf("failed to read from clipboard: %w", err)
+		return nil, fmt.Errorf("failed to read from clipboard: %w", err)
 	}
 	if _, err := io.Copy(bufWriter, clipboardReader); err != nil {
-		return "", fmt.Errorf("failed to copy clipboard data: %w", err)
+		return nil, fmt.Errorf("failed to copy clipboard data: %w", err)
 	}
 	if err := bufWriter.Flush(); err != nil {
-		return "", fmt.Errorf("failed to flush buffer: %w", err)
+		return nil, fmt.Errorf("failed to flush buffer: %w", err)
 	}
-	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+	return buf.Bytes(), nil
 }
 
 func (o *OleWorksheet) AddTable(tableRange string, tableName string) error {
@@ -618,7 +1104,12 @@ func extractDecimalPlacesFromFormat(format string) int {
 func (o *OleWorksheet) SetCellStyle(cell string, style *CellStyle) error {
 	rng := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
 	defer rng.Release()
+	applyCellStyle(rng, style)
+	return nil
+}
 
+// applyCellStyle applies style to an object which has Font, Interior, Borders and NumberFormat (Range or FormatCondition)
+func applyCellStyle(rng *ole.IDispatch, style *CellStyle) {
 	// Apply Font styles
 	if style.Font != nil {
 		font := oleutil.MustGetProperty(rng, "Font").ToIDispatch()
@@ -680,10 +1171,1566 @@ func (o *OleWorksheet) SetCellStyle(cell string, style *CellStyle) error {
 	if style.NumFmt != nil && *style.NumFmt != "" {
 		oleutil.PutProperty(rng, "NumberFormat", *style.NumFmt)
 	}
+}
+
+func (o *OleWorksheet) GetDataValidations() ([]DataValidation, error) {
+	usedRange := oleutil.MustGetProperty(o.worksheet, "UsedRange").ToIDispatch()
+	defer usedRange.Release()
+	cellsVar, err := oleutil.CallMethod(usedRange, "SpecialCells", -4174) // xlCellTypeAllValidation
+	if err != nil {
+		// SpecialCells raises an error if no cells are found
+		return []DataValidation{}, nil
+	}
+	cells := cellsVar.ToIDispatch()
+	defer cells.Release()
+	areas := oleutil.MustGetProperty(cells, "Areas").ToIDispatch()
+	defer areas.Release()
+
+	count := int(oleutil.MustGetProperty(areas, "Count").Val)
+	validations := make([]DataValidation, 0, count)
+	for i := 1; i <= count; i++ {
+		area := oleutil.MustGetProperty(areas, "Item", i).ToIDispatch()
+		defer area.Release()
+		validation := oleutil.MustGetProperty(area, "Validation").ToIDispatch()
+		defer validation.Release()
+		typeVar, err := oleutil.GetProperty(validation, "Type")
+		if err != nil {
+			// the area contains different rules
+			continue
+		}
+		dv := DataValidation{
+			Range:      NormalizeRange(oleutil.MustGetProperty(area, "Address").ToString()),
+			Type:       excelToDataValidationType(int32(typeVar.Val)),
+			AllowBlank: oleutil.MustGetProperty(validation, "IgnoreBlank").Value().(bool),
+		}
+		if dv.Type == "" {
+			continue
+		}
+		formula1 := oleutil.MustGetProperty(validation, "Formula1").ToString()
+		if dv.Type == DataValidationTypeList {
+			if strings.HasPrefix(formula1, "=") {
+				dv.Formula1 = strings.TrimPrefix(formula1, "=")
+			} else {
+				dv.List = strings.Split(formula1, ",")
+			}
+		} else {
+			dv.Formula1 = strings.TrimPrefix(formula1, "=")
+			if dv.Type != DataValidationTypeCustom {
+				dv.Operator = excelToDataValidationOperator(int32(oleutil.MustGetProperty(validation, "Operator").Val))
+				if dv.Operator == DataValidationOperatorBetween || dv.Operator == DataValidationOperatorNotBetween {
+					dv.Formula2 = strings.TrimPrefix(oleutil.MustGetProperty(validation, "Formula2").ToString(), "=")
+				}
+			}
+		}
+		if oleutil.MustGetProperty(validation, "ShowInput").Value().(bool) {
+			dv.InputTitle = oleutil.MustGetProperty(validation, "InputTitle").ToString()
+			dv.InputMessage = oleutil.MustGetProperty(validation, "InputMessage").ToString()
+		}
+		if oleutil.MustGetProperty(validation, "ShowError").Value().(bool) {
+			dv.ErrorStyle = excelToDataValidationErrorStyle(int32(oleutil.MustGetProperty(validation, "AlertStyle").Val))
+			dv.ErrorTitle = oleutil.MustGetProperty(validation, "ErrorTitle").ToString()
+			dv.ErrorMessage = oleutil.MustGetProperty(validation, "ErrorMessage").ToString()
+		}
+		validations = append(validations, dv)
+	}
+	return validations, nil
+}
+
+func (o *OleWorksheet) AddDataValidation(validation *DataValidation) error {
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", validation.Range).ToIDispatch()
+	defer rng.Release()
+	dv := oleutil.MustGetProperty(rng, "Validation").ToIDispatch()
+	defer dv.Release()
+
+	var formula1, formula2 any
+	if validation.Type == DataValidationTypeList && len(validation.List) > 0 {
+		formula1 = strings.Join(validation.List, ",")
+	} else if validation.Formula1 != "" {
+		formula1 = "=" + strings.TrimPrefix(validation.Formula1, "=")
+	}
+	if validation.Formula2 != "" {
+		formula2 = "=" + strings.TrimPrefix(validation.Formula2, "=")
+	}
+	var operator any
+	if validation.Operator != "" {
+		operator = dataValidationOperatorToExcel(validation.Operator)
+	}
+	// https://learn.microsoft.com/ja-jp/office/vba/api/excel.validation.add
+	if _, err := oleutil.CallMethod(
+		dv,
+		"Add",
+		dataValidationTypeToExcel(validation.Type),
+		dataValidationErrorStyleToExcel(validation.ErrorStyle),
+		operator,
+		formula1,
+		formula2,
+	); err != nil {
+		return fmt.Errorf("failed to add data validation: %w", err)
+	}
+	oleutil.PutProperty(dv, "IgnoreBlank", validation.AllowBlank)
+	if validation.InputTitle != "" || validation.InputMessage != "" {
+		oleutil.PutProperty(dv, "InputTitle", validation.InputTitle)
+		oleutil.PutProperty(dv, "InputMessage", validation.InputMessage)
+		oleutil.PutProperty(dv, "ShowInput", true)
+	}
+	if validation.ErrorStyle != "" || validation.ErrorTitle != "" || validation.ErrorMessage != "" {
+		oleutil.PutProperty(dv, "ErrorTitle", validation.ErrorTitle)
+		oleutil.PutProperty(dv, "ErrorMessage", validation.ErrorMessage)
+		oleutil.PutProperty(dv, "ShowError", true)
+	}
+	return nil
+}
+
+func (o *OleWorksheet) DeleteDataValidation(rangeStr string) error {
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", rangeStr).ToIDispatch()
+	defer rng.Release()
+	dv := oleutil.MustGetProperty(rng, "Validation").ToIDispatch()
+	defer dv.Release()
+	if _, err := oleutil.CallMethod(dv, "Delete"); err != nil {
+		return fmt.Errorf("failed to delete data validation: %w", err)
+	}
+	return nil
+}
+
+func (o *OleWorksheet) GetConditionalFormats() ([]ConditionalFormat, error) {
+	cells := oleutil.MustGetProperty(o.worksheet, "Cells").ToIDispatch()
+	defer cells.Release()
+	formatConditions := oleutil.MustGetProperty(cells, "FormatConditions").ToIDispatch()
+	defer formatConditions.Release()
+
+	count := int(oleutil.MustGetProperty(formatConditions, "Count").Val)
+	formats := make([]ConditionalFormat, 0, count)
+	for i := 1; i <= count; i++ {
+		fc := oleutil.MustGetProperty(formatConditions, "Item", i).ToIDispatch()
+		defer fc.Release()
+		appliesTo := oleutil.MustGetProperty(fc, "AppliesTo").ToIDispatch()
+		defer appliesTo.Release()
+
+		format := ConditionalFormat{
+			Range: sqrefToRange(strings.ReplaceAll(strings.ReplaceAll(oleutil.MustGetProperty(appliesTo, "Address").ToString(), "$", ""), ",", " ")),
+		}
+		switch int32(oleutil.MustGetProperty(fc, "Type").Val) {
+		case 1: // xlCellValue
+			format.Type = ConditionalFormatTypeCellValue
+			format.Operator = excelToConditionalFormatOperator(int32(oleutil.MustGetProperty(fc, "Operator").Val))
+			format.Formula1 = strings.TrimPrefix(oleutil.MustGetProperty(fc, "Formula1").ToString(), "=")
+			if format.Operator == ConditionalFormatOperatorBetween || format.Operator == ConditionalFormatOperatorNotBetween {
+				format.Formula2 = strings.TrimPrefix(oleutil.MustGetProperty(fc, "Formula2").ToString(), "=")
+			}
+			format.Style = readConditionalFormatStyle(fc)
+		case 2: // xlExpression
+			format.Type = ConditionalFormatTypeFormula
+			format.Formula1 = strings.TrimPrefix(oleutil.MustGetProperty(fc, "Formula1").ToString(), "=")
+			format.Style = readConditionalFormatStyle(fc)
+		case 3: // xlColorScale
+			format.Type = ConditionalFormatTypeColorScale
+			criteria := oleutil.MustGetProperty(fc, "ColorScaleCriteria").ToIDispatch()
+			defer criteria.Release()
+			var colors []string
+			criteriaCount := int(oleutil.MustGetProperty(criteria, "Count").Val)
+			for j := 1; j <= criteriaCount; j++ {
+				criterion := oleutil.MustGetProperty(criteria, "Item", j).ToIDispatch()
+				defer criterion.Release()
+				formatColor := oleutil.MustGetProperty(criterion, "FormatColor").ToIDispatch()
+				defer formatColor.Release()
+				colors = append(colors, bgrToRgb(oleutil.MustGetProperty(formatColor, "Color").Value().(float64)))
+			}
+			if len(colors) > 0 {
+				format.MinColor, format.MaxColor = colors[0], colors[len(colors)-1]
+			}
+			if len(colors) == 3 {
+				format.MidColor = colors[1]
+			}
+		case 4: // xlDatabar
+			format.Type = ConditionalFormatTypeDataBar
+			barColor := oleutil.MustGetProperty(fc, "BarColor").ToIDispatch()
+			defer barColor.Release()
+			format.BarColor = bgrToRgb(oleutil.MustGetProperty(barColor, "Color").Value().(float64))
+		case 5: // xlTop10
+			format.Type = ConditionalFormatTypeTop
+			if int32(oleutil.MustGetProperty(fc, "TopBottom").Val) == 0 { // xlTop10Bottom
+				format.Type = ConditionalFormatTypeBottom
+			}
+			format.Rank = int(oleutil.MustGetProperty(fc, "Rank").Val)
+			format.Percent = oleutil.MustGetProperty(fc, "Percent").Value().(bool)
+			format.Style = readConditionalFormatStyle(fc)
+		case 6: // xlIconSets
+			format.Type = ConditionalFormatTypeIconSet
+			iconSet := oleutil.MustGetProperty(fc, "IconSet").ToIDispatch()
+			defer iconSet.Release()
+			if id := int(oleutil.MustGetProperty(iconSet, "ID").Val); id >= 1 && id <= len(IconStyleValues()) {
+				format.IconStyle = IconStyleValues()[id-1]
+			}
+			format.ReverseIcons = oleutil.MustGetProperty(fc, "ReverseOrder").Value().(bool)
+		case 8: // xlUniqueValues
+			format.Type = ConditionalFormatTypeUnique
+			if int32(oleutil.MustGetProperty(fc, "DupeUnique").Val) == 1 { // xlDuplicate
+				format.Type = ConditionalFormatTypeDuplicate
+			}
+			format.Style = readConditionalFormatStyle(fc)
+		default:
+			// other rules are not supported
+			continue
+		}
+		if stopIfTrue, err := oleutil.GetProperty(fc, "StopIfTrue"); err == nil {
+			format.StopIfTrue, _ = stopIfTrue.Value().(bool)
+		}
+		formats = append(formats, format)
+	}
+	return formats, nil
+}
+
+func (o *OleWorksheet) AddConditionalFormat(format *ConditionalFormat) error {
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", format.Range).ToIDispatch()
+	defer rng.Release()
+	formatConditions := oleutil.MustGetProperty(rng, "FormatConditions").ToIDispatch()
+	defer formatConditions.Release()
+
+	// https://learn.microsoft.com/ja-jp/office/vba/api/excel.formatconditions
+	var fcVar *ole.VARIANT
+	var err error
+	switch format.Type {
+	case ConditionalFormatTypeCellValue:
+		var formula2 any
+		if format.Formula2 != "" {
+			formula2 = "=" + strings.TrimPrefix(format.Formula2, "=")
+		}
+		fcVar, err = oleutil.CallMethod(formatConditions, "Add", 1, conditionalFormatOperatorToExcel(format.Operator), "="+strings.TrimPrefix(format.Formula1, "="), formula2) // xlCellValue
+	case ConditionalFormatTypeFormula:
+		fcVar, err = oleutil.CallMethod(formatConditions, "Add", 2, nil, "="+strings.TrimPrefix(format.Formula1, "=")) // xlExpression
+	case ConditionalFormatTypeTop, ConditionalFormatTypeBottom:
+		fcVar, err = oleutil.CallMethod(formatConditions, "AddTop10")
+	case ConditionalFormatTypeDuplicate, ConditionalFormatTypeUnique:
+		fcVar, err = oleutil.CallMethod(formatConditions, "AddUniqueValues")
+	case ConditionalFormatTypeColorScale:
+		colorScaleType := 2
+		if format.MidColor != "" {
+			colorScaleType = 3
+		}
+		fcVar, err = oleutil.CallMethod(formatConditions, "AddColorScale", colorScaleType)
+	case ConditionalFormatTypeDataBar:
+		fcVar, err = oleutil.CallMethod(formatConditions, "AddDatabar")
+	case ConditionalFormatTypeIconSet:
+		fcVar, err = oleutil.CallMethod(formatConditions, "AddIconSetCondition")
+	default:
+		return fmt.Errorf("unsupported conditional format type: %s", format.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add conditional format: %w", err)
+	}
+	fc := fcVar.ToIDispatch()
+	defer fc.Release()
+
+	switch format.Type {
+	case ConditionalFormatTypeTop, ConditionalFormatTypeBottom:
+		topBottom := 1 // xlTop10Top
+		if format.Type == ConditionalFormatTypeBottom {
+			topBottom = 0 // xlTop10Bottom
+		}
+		oleutil.PutProperty(fc, "TopBottom", topBottom)
+		oleutil.PutProperty(fc, "Rank", format.Rank)
+		oleutil.PutProperty(fc, "Percent", format.Percent)
+	case ConditionalFormatTypeDuplicate:
+		oleutil.PutProperty(fc, "DupeUnique", 1) // xlDuplicate
+	case ConditionalFormatTypeUnique:
+		oleutil.PutProperty(fc, "DupeUnique", 0) // xlUnique
+	case ConditionalFormatTypeColorScale:
+		criteria := oleutil.MustGetProperty(fc, "ColorScaleCriteria").ToIDispatch()
+		defer criteria.Release()
+		colors := []string{format.MinColor, format.MaxColor}
+		if format.MidColor != "" {
+			colors = []string{format.MinColor, format.MidColor, format.MaxColor}
+		}
+		for j, color := range colors {
+			if color == "" {
+				continue
+			}
+			criterion := oleutil.MustGetProperty(criteria, "Item", j+1).ToIDispatch()
+			defer criterion.Release()
+			formatColor := oleutil.MustGetProperty(criterion, "FormatColor").ToIDispatch()
+			defer formatColor.Release()
+			oleutil.PutProperty(formatColor, "Color", rgbToBgr(color))
+		}
+	case ConditionalFormatTypeDataBar:
+		if format.BarColor != "" {
+			barColor := oleutil.MustGetProperty(fc, "BarColor").ToIDispatch()
+			defer barColor.Release()
+			oleutil.PutProperty(barColor, "Color", rgbToBgr(format.BarColor))
+		}
+	case ConditionalFormatTypeIconSet:
+		for j, iconStyle := range IconStyleValues() {
+			if iconStyle == format.IconStyle {
+				workbookIconSets := oleutil.MustGetProperty(o.excel.workbook, "IconSets", j+1).ToIDispatch()
+				defer workbookIconSets.Release()
+				oleutil.PutPropertyRef(fc, "IconSet", workbookIconSets)
+				break
+			}
+		}
+		oleutil.PutProperty(fc, "ReverseOrder", format.ReverseIcons)
+	}
+	if format.Style != nil {
+		applyCellStyle(fc, format.Style)
+	}
+	oleutil.PutProperty(fc, "StopIfTrue", format.StopIfTrue)
+	return nil
+}
+
+func (o *OleWorksheet) GetComments() ([]Comment, error) {
+	var comments []Comment
+	threadedCells := map[string]bool{}
+	// Threaded comments are available since Excel 365
+	if threadedVar, err := oleutil.GetProperty(o.worksheet, "CommentsThreaded"); err == nil {
+		threaded := threadedVar.ToIDispatch()
+		defer threaded.Release()
+		count := int(oleutil.MustGetProperty(threaded, "Count").Val)
+		for i := 1; i <= count; i++ {
+			comment := oleutil.MustGetProperty(threaded, "Item", i).ToIDispatch()
+			defer comment.Release()
+			parent := oleutil.MustGetProperty(comment, "Parent").ToIDispatch()
+			defer parent.Release()
+			author := oleutil.MustGetProperty(comment, "Author").ToIDispatch()
+			defer author.Release()
+			cell := oleutil.MustGetProperty(parent, "Address", false, false).ToString()
+			text := oleutil.MustCallMethod(comment, "Text").ToString()
+			replies := oleutil.MustGetProperty(comment, "Replies").ToIDispatch()
+			defer replies.Release()
+			replyCount := int(oleutil.MustGetProperty(replies, "Count").Val)
+			for j := 1; j <= replyCount; j++ {
+				reply := oleutil.MustGetProperty(replies, "Item", j).ToIDispatch()
+				defer reply.Release()
+				replyAuthor := oleutil.MustGetProperty(reply, "Author").ToIDispatch()
+				defer replyAuthor.Release()
+				text += fmt.Sprintf("\n%s: %s", oleutil.MustGetProperty(replyAuthor, "Name").ToString(), oleutil.MustCallMethod(reply, "Text").ToString())
+			}
+			comments = append(comments, Comment{
+				Cell:   cell,
+				Author: oleutil.MustGetProperty(author, "Name").ToString(),
+				Text:   text,
+			})
+			threadedCells[cell] = true
+		}
+	}
+
+	notes := oleutil.MustGetProperty(o.worksheet, "Comments").ToIDispatch()
+	defer notes.Release()
+	count := int(oleutil.MustGetProperty(notes, "Count").Val)
+	for i := 1; i <= count; i++ {
+		note := oleutil.MustGetProperty(notes, "Item", i).ToIDispatch()
+		defer note.Release()
+		parent := oleutil.MustGetProperty(note, "Parent").ToIDispatch()
+		defer parent.Release()
+		cell := oleutil.MustGetProperty(parent, "Address", false, false).ToString()
+		if threadedCells[cell] {
+			// the note is a placeholder of the threaded comment
+			continue
+		}
+		author := oleutil.MustGetProperty(note, "Author").ToString()
+		comments = append(comments, Comment{
+			Cell:   cell,
+			Author: author,
+			Text:   trimCommentAuthor(oleutil.MustCallMethod(note, "Text").ToString(), author),
+		})
+	}
+	return comments, nil
+}
 
+func (o *OleWorksheet) SetComment(comment Comment) error {
+	if err := o.DeleteComment(comment.Cell); err != nil {
+		return err
+	}
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", comment.Cell).ToIDispatch()
+	defer rng.Release()
+	// Comment.Author is read-only, so the author is written at the beginning of the note as Excel does
+	text := comment.Text
+	if comment.Author != "" {
+		text = comment.Author + ":\n" + text
+	}
+	noteVar, err := oleutil.CallMethod(rng, "AddComment", text)
+	if err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+	note := noteVar.ToIDispatch()
+	defer note.Release()
+	if comment.Author != "" {
+		shape := oleutil.MustGetProperty(note, "Shape").ToIDispatch()
+		defer shape.Release()
+		textFrame := oleutil.MustGetProperty(shape, "TextFrame").ToIDispatch()
+		defer textFrame.Release()
+		characters := oleutil.MustCallMethod(textFrame, "Characters", 1, len([]rune(comment.Author))+1).ToIDispatch()
+		defer characters.Release()
+		font := oleutil.MustGetProperty(characters, "Font").ToIDispatch()
+		defer font.Release()
+		oleutil.PutProperty(font, "Bold", true)
+	}
 	return nil
 }
 
+func (o *OleWorksheet) DeleteComment(cell string) error {
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
+	defer rng.Release()
+	if threadedVar, err := oleutil.GetProperty(rng, "CommentThreaded"); err == nil {
+		if threaded := threadedVar.ToIDispatch(); threaded != nil {
+			defer threaded.Release()
+			if _, err := oleutil.CallMethod(threaded, "Delete"); err != nil {
+				return fmt.Errorf("failed to delete comment: %w", err)
+			}
+		}
+	}
+	if note := oleutil.MustGetProperty(rng, "Comment").ToIDispatch(); note != nil {
+		defer note.Release()
+		if _, err := oleutil.CallMethod(note, "Delete"); err != nil {
+			return fmt.Errorf("failed to delete comment: %w", err)
+		}
+	}
+	return nil
+}
+
+func (o *OleWorksheet) GetHyperlinks(rangeStr string) ([]Hyperlink, error) {
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", rangeStr).ToIDispatch()
+	defer rng.Release()
+	links := oleutil.MustGetProperty(rng, "Hyperlinks").ToIDispatch()
+	defer links.Release()
+
+	count := int(oleutil.MustGetProperty(links, "Count").Val)
+	hyperlinks := make([]Hyperlink, 0, count)
+	for i := 1; i <= count; i++ {
+		link := oleutil.MustGetProperty(links, "Item", i).ToIDispatch()
+		defer link.Release()
+		anchor := oleutil.MustGetProperty(link, "Range").ToIDispatch()
+		defer anchor.Release()
+		hyperlinks = append(hyperlinks, Hyperlink{
+			Cell:     oleutil.MustGetProperty(anchor, "Address", false, false).ToString(),
+			Address:  oleutil.MustGetProperty(link, "Address").ToString(),
+			Location: oleutil.MustGetProperty(link, "SubAddress").ToString(),
+			Tooltip:  oleutil.MustGetProperty(link, "ScreenTip").ToString(),
+		})
+	}
+	return hyperlinks, nil
+}
+
+func (o *OleWorksheet) SetHyperlink(hyperlink Hyperlink) error {
+	if err := o.DeleteHyperlink(hyperlink.Cell); err != nil {
+		return err
+	}
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", hyperlink.Cell).ToIDispatch()
+	defer rng.Release()
+	links := oleutil.MustGetProperty(o.worksheet, "Hyperlinks").ToIDispatch()
+	defer links.Release()
+
+	var screenTip, textToDisplay any
+	if hyperlink.Tooltip != "" {
+		screenTip = hyperlink.Tooltip
+	}
+	if hyperlink.Display != "" {
+		textToDisplay = hyperlink.Display
+	}
+	// https://learn.microsoft.com/ja-jp/office/vba/api/excel.hyperlinks.add
+	if _, err := oleutil.CallMethod(links, "Add", rng, hyperlink.Address, hyperlink.Location, screenTip, textToDisplay); err != nil {
+		return fmt.Errorf("failed to set hyperlink: %w", err)
+	}
+	return nil
+}
+
+func (o *OleWorksheet) DeleteHyperlink(cell string) error {
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
+	defer rng.Release()
+	links := oleutil.MustGetProperty(rng, "Hyperlinks").ToIDispatch()
+	defer links.Release()
+	if int(oleutil.MustGetProperty(links, "Count").Val) == 0 {
+		return nil
+	}
+	if _, err := oleutil.CallMethod(links, "Delete"); err != nil {
+		return fmt.Errorf("failed to delete hyperlink: %w", err)
+	}
+	return nil
+}
+
+func (o *OleWorksheet) GetCharts() ([]Chart, error) {
+	chartObjects := oleutil.MustCallMethod(o.worksheet, "ChartObjects").ToIDispatch()
+	defer chartObjects.Release()
+
+	count := int(oleutil.MustGetProperty(chartObjects, "Count").Val)
+	charts := make([]Chart, 0, count)
+	for i := 1; i <= count; i++ {
+		chartObject := oleutil.MustGetProperty(chartObjects, "Item", i).ToIDispatch()
+		defer chartObject.Release()
+		topLeft := oleutil.MustGetProperty(chartObject, "TopLeftCell").ToIDispatch()
+		defer topLeft.Release()
+		bottomRight := oleutil.MustGetProperty(chartObject, "BottomRightCell").ToIDispatch()
+		defer bottomRight.Release()
+		chartDisp := oleutil.MustGetProperty(chartObject, "Chart").ToIDispatch()
+		defer chartDisp.Release()
+
+		chart := Chart{
+			Name:           oleutil.MustGetProperty(chartObject, "Name").ToString(),
+			Type:           excelToChartType(int32(oleutil.MustGetProperty(chartDisp, "ChartType").Val)),
+			Anchor:         oleutil.MustGetProperty(topLeft, "Address", false, false).ToString() + ":" + oleutil.MustGetProperty(bottomRight, "Address", false, false).ToString(),
+			LegendPosition: ChartLegendPositionNone,
+		}
+		if oleutil.MustGetProperty(chartDisp, "HasTitle").Value().(bool) {
+			chartTitle := oleutil.MustGetProperty(chartDisp, "ChartTitle").ToIDispatch()
+			defer chartTitle.Release()
+			chart.Title = oleutil.MustGetProperty(chartTitle, "Text").ToString()
+		}
+		if oleutil.MustGetProperty(chartDisp, "HasLegend").Value().(bool) {
+			legend := oleutil.MustGetProperty(chartDisp, "Legend").ToIDispatch()
+			defer legend.Release()
+			chart.LegendPosition = excelToChartLegendPosition(int32(oleutil.MustGetProperty(legend, "Position").Val))
+		}
+		chart.XAxisTitle = getChartAxisTitle(chartDisp, 1) // xlCategory
+		chart.YAxisTitle = getChartAxisTitle(chartDisp, 2) // xlValue
+
+		seriesCollection := oleutil.MustCallMethod(chartDisp, "SeriesCollection").ToIDispatch()
+		defer seriesCollection.Release()
+		seriesCount := int(oleutil.MustGetProperty(seriesCollection, "Count").Val)
+		for j := 1; j <= seriesCount; j++ {
+			seriesDisp := oleutil.MustCallMethod(seriesCollection, "Item", j).ToIDispatch()
+			defer seriesDisp.Release()
+			series := parseSeriesFormula(oleutil.MustGetProperty(seriesDisp, "Formula").ToString())
+			series.Type = excelToChartType(int32(oleutil.MustGetProperty(seriesDisp, "ChartType").Val))
+			if series.Type != chart.Type {
+				chart.Type = ChartTypeCombo
+			}
+			chart.Series = append(chart.Series, series)
+		}
+		if chart.Type != ChartTypeCombo {
+			for j := range chart.Series {
+				chart.Series[j].Type = ""
+			}
+		}
+		charts = append(charts, chart)
+	}
+	return charts, nil
+}
+
+func (o *OleWorksheet) AddChart(chart *Chart) error {
+	if len(chart.Series) == 0 {
+		return fmt.Errorf("chart requires at least one series")
+	}
+	anchor := oleutil.MustGetProperty(o.worksheet, "Range", chart.Anchor).ToIDispatch()
+	defer anchor.Release()
+	left := oleutil.MustGetProperty(anchor, "Left").Value()
+	top := oleutil.MustGetProperty(anchor, "Top").Value()
+	width, height := chart.Width, chart.Height
+	if width == 0 {
+		width = 480
+	}
+	if height == 0 {
+		height = 260
+	}
+	chartObjects := oleutil.MustCallMethod(o.worksheet, "ChartObjects").ToIDispatch()
+	defer chartObjects.Release()
+	// pixels to points
+	chartObjectVar, err := oleutil.CallMethod(chartObjects, "Add", left, top, float64(width)*0.75, float64(height)*0.75)
+	if err != nil {
+		return fmt.Errorf("failed to add chart: %w", err)
+	}
+	chartObject := chartObjectVar.ToIDispatch()
+	defer chartObject.Release()
+	chartDisp := oleutil.MustGetProperty(chartObject, "Chart").ToIDispatch()
+	defer chartDisp.Release()
+
+	primaryType := chart.Type
+	if primaryType == ChartTypeCombo {
+		primaryType = chart.Series[0].Type
+	}
+	oleutil.PutProperty(chartDisp, "ChartType", chartTypeToExcel(primaryType))
+	seriesCollection := oleutil.MustCallMethod(chartDisp, "SeriesCollection").ToIDispatch()
+	defer seriesCollection.Release()
+	for _, series := range chart.Series {
+		seriesDisp := oleutil.MustCallMethod(seriesCollection, "NewSeries").ToIDispatch()
+		defer seriesDisp.Release()
+		if series.Name != "" {
+			oleutil.PutProperty(seriesDisp, "Name", "="+series.Name)
+		}
+		oleutil.PutProperty(seriesDisp, "Values", "="+series.Values)
+		if series.Categories != "" {
+			oleutil.PutProperty(seriesDisp, "XValues", "="+series.Categories)
+		}
+		if chart.Type == ChartTypeCombo {
+			oleutil.PutProperty(seriesDisp, "ChartType", chartTypeToExcel(series.Type))
+		}
+	}
+
+	if chart.Title != "" {
+		oleutil.PutProperty(chartDisp, "HasTitle", true)
+		chartTitle := oleutil.MustGetProperty(chartDisp, "ChartTitle").ToIDispatch()
+		defer chartTitle.Release()
+		oleutil.PutProperty(chartTitle, "Text", chart.Title)
+	}
+	setChartAxisTitle(chartDisp, 1, chart.XAxisTitle) // xlCategory
+	setChartAxisTitle(chartDisp, 2, chart.YAxisTitle) // xlValue
+	if chart.LegendPosition == ChartLegendPositionNone {
+		oleutil.PutProperty(chartDisp, "HasLegend", false)
+	} else {
+		oleutil.PutProperty(chartDisp, "HasLegend", true)
+		legend := oleutil.MustGetProperty(chartDisp, "Legend").ToIDispatch()
+		defer legend.Release()
+		oleutil.PutProperty(legend, "Position", chartLegendPositionToExcel(chart.LegendPosition))
+	}
+	return nil
+}
+
+func (o *OleWorksheet) GetPictures(rangeStr string) ([]Picture, error) {
+	var areas [][4]int
+	if rangeStr != "" {
+		for _, area := range SplitRangeAreas(rangeStr) {
+			startCol, startRow, endCol, endRow, err := ParseRange(area)
+			if err != nil {
+				return nil, err
+			}
+			areas = append(areas, [4]int{startCol, startRow, endCol, endRow})
+		}
+	}
+	shapes := oleutil.MustGetProperty(o.worksheet, "Shapes").ToIDispatch()
+	defer shapes.Release()
+	count := int(oleutil.MustGetProperty(shapes, "Count").Val)
+	var pictures []Picture
+	for i := 1; i <= count; i++ {
+		shape := oleutil.MustCallMethod(shapes, "Item", i).ToIDispatch()
+		defer shape.Release()
+		shapeType := int32(oleutil.MustGetProperty(shape, "Type").Val)
+		if shapeType != 13 && shapeType != 11 { // msoPicture, msoLinkedPicture
+			continue
+		}
+		topLeftCell := oleutil.MustGetProperty(shape, "TopLeftCell").ToIDispatch()
+		defer topLeftCell.Release()
+		col := int(oleutil.MustGetProperty(topLeftCell, "Column").Val)
+		row := int(oleutil.MustGetProperty(topLeftCell, "Row").Val)
+		inRange := len(areas) == 0
+		for _, area := range areas {
+			if area[0] <= col && col <= area[2] && area[1] <= row && row <= area[3] {
+				inRange = true
+				break
+			}
+		}
+		if !inRange {
+			continue
+		}
+		// the original image is not accessible, so the picture is copied as it appears
+		if _, err := oleutil.CallMethod(shape, "CopyPicture", int32(1), int32(2)); err != nil { // xlScreen, xlBitmap
+			return nil, fmt.Errorf("failed to copy picture: %w", err)
+		}
+		data, err := readClipboardImage()
+		if err != nil {
+			return nil, err
+		}
+		cell, err := excelize.CoordinatesToCellName(col, row)
+		if err != nil {
+			return nil, err
+		}
+		pictures = append(pictures, Picture{
+			Cell:      cell,
+			Extension: ".png",
+			Data:      data,
+			AltText:   oleutil.MustGetProperty(shape, "AlternativeText").ToString(),
+		})
+	}
+	return pictures, nil
+}
+
+func (o *OleWorksheet) AddPicture(picture *Picture) error {
+	// Shapes.AddPicture accepts only a file
+	tempFile, err := os.CreateTemp("", "excel-mcp-picture-*"+picture.Extension)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+	if _, err := tempFile.Write(picture.Data); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	anchor := oleutil.MustGetProperty(o.worksheet, "Range", picture.Cell).ToIDispatch()
+	defer anchor.Release()
+	left := oleutil.MustGetProperty(anchor, "Left").Value()
+	top := oleutil.MustGetProperty(anchor, "Top").Value()
+	shapes := oleutil.MustGetProperty(o.worksheet, "Shapes").ToIDispatch()
+	defer shapes.Release()
+	// https://learn.microsoft.com/en-us/office/vba/api/excel.shapes.addpicture
+	shapeVar, err := oleutil.CallMethod(shapes, "AddPicture", tempFile.Name(), int32(0), int32(-1), left, top, int32(-1), int32(-1)) // msoFalse, msoTrue
+	if err != nil {
+		return fmt.Errorf("failed to add picture: %w", err)
+	}
+	shape := shapeVar.ToIDispatch()
+	defer shape.Release()
+	if picture.ScaleX != 0 && picture.ScaleX != 1 {
+		oleutil.MustCallMethod(shape, "ScaleWidth", picture.ScaleX, int32(-1)) // msoTrue: relative to the original size
+	}
+	if picture.ScaleY != 0 && picture.ScaleY != 1 {
+		oleutil.MustCallMethod(shape, "ScaleHeight", picture.ScaleY, int32(-1))
+	}
+	if picture.AltText != "" {
+		oleutil.MustPutProperty(shape, "AlternativeText", picture.AltText)
+	}
+	return nil
+}
+
+func (o *OleWorksheet) ClearRange(rangeStr string, modes []ClearMode) error {
+	o.regions = dataRegionsCache{}
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", rangeStr).ToIDispatch()
+	defer rng.Release()
+
+	clearValues, clearFormulas := hasClearMode(modes, ClearModeValue), hasClearMode(modes, ClearModeFormula)
+	if clearValues && clearFormulas {
+		if _, err := oleutil.CallMethod(rng, "ClearContents"); err != nil {
+			return fmt.Errorf("failed to clear contents: %w", err)
+		}
+	} else if clearValues || clearFormulas {
+		cellType := int32(2) // xlCellTypeConstants
+		if clearFormulas {
+			cellType = -4123 // xlCellTypeFormulas
+		}
+		// SpecialCells fails if no cells are found
+		if cellsVar, err := oleutil.CallMethod(rng, "SpecialCells", cellType); err == nil {
+			cells := cellsVar.ToIDispatch()
+			defer cells.Release()
+			if _, err := oleutil.CallMethod(cells, "ClearContents"); err != nil {
+				return fmt.Errorf("failed to clear contents: %w", err)
+			}
+		}
+	}
+	if hasClearMode(modes, ClearModeStyle) {
+		if _, err := oleutil.CallMethod(rng, "ClearFormats"); err != nil {
+			return fmt.Errorf("failed to clear formats: %w", err)
+		}
+	}
+	if hasClearMode(modes, ClearModeComment) {
+		if _, err := oleutil.CallMethod(rng, "ClearComments"); err != nil {
+			return fmt.Errorf("failed to clear comments: %w", err)
+		}
+	}
+	if hasClearMode(modes, ClearModeHyperlink) {
+		links := oleutil.MustGetProperty(rng, "Hyperlinks").ToIDispatch()
+		defer links.Release()
+		if int(oleutil.MustGetProperty(links, "Count").Val) > 0 {
+			if _, err := oleutil.CallMethod(links, "Delete"); err != nil {
+				return fmt.Errorf("failed to delete hyperlinks: %w", err)
+			}
+		}
+	}
+	if hasClearMode(modes, ClearModeDataValidation) {
+		dv := oleutil.MustGetProperty(rng, "Validation").ToIDispatch()
+		defer dv.Release()
+		if _, err := oleutil.CallMethod(dv, "Delete"); err != nil {
+			return fmt.Errorf("failed to delete data validation: %w", err)
+		}
+	}
+	// accessing UsedRange makes Excel recalculate it
+	usedRange := oleutil.MustGetProperty(o.worksheet, "UsedRange").ToIDispatch()
+	usedRange.Release()
+	return nil
+}
+
+func (o *OleWorksheet) CopyRange(rangeStr string, destSheetName string, destCell string, options CopyRangeOptions) error {
+	// the destination may be this sheet
+	o.regions = dataRegionsCache{}
+	destWorksheet, err := o.excel.FindSheet(destSheetName)
+	if err != nil {
+		return err
+	}
+	defer destWorksheet.Release()
+	dest := destWorksheet.(*OleWorksheet)
+
+	src := oleutil.MustGetProperty(o.worksheet, "Range", rangeStr).ToIDispatch()
+	defer src.Release()
+	destination := oleutil.MustGetProperty(dest.worksheet, "Range", destCell).ToIDispatch()
+	defer destination.Release()
+
+	if options.Move {
+		if _, err := oleutil.CallMethod(src, "Cut", destination); err != nil {
+			return fmt.Errorf("failed to move range: %w", err)
+		}
+		return nil
+	}
+	if _, err := oleutil.CallMethod(src, "Copy"); err != nil {
+		return fmt.Errorf("failed to copy range: %w", err)
+	}
+	defer oleutil.MustPutProperty(o.excel.application, "CutCopyMode", false)
+	// https://learn.microsoft.com/en-us/office/vba/api/excel.range.pastespecial
+	if _, err := oleutil.CallMethod(destination, "PasteSpecial", copyModeToExcelPasteType(options.Mode), int32(-4142), false, options.Transpose); err != nil { // xlPasteSpecialOperationNone
+		return fmt.Errorf("failed to paste range: %w", err)
+	}
+	return nil
+}
+
+func (o *OleWorksheet) SortRange(rangeStr string, keys []SortKey, hasHeader bool) error {
+	o.regions = dataRegionsCache{}
+	startCol, startRow, endCol, endRow, err := ParseRange(rangeStr)
+	if err != nil {
+		return err
+	}
+	dataStartRow := startRow
+	if hasHeader {
+		dataStartRow++
+	}
+	if dataStartRow >= endRow {
+		return nil
+	}
+	sortRange := oleutil.MustGetProperty(o.worksheet, "Range", rangeStr).ToIDispatch()
+	defer sortRange.Release()
+	worksheetSort := oleutil.MustGetProperty(o.worksheet, "Sort").ToIDispatch()
+	defer worksheetSort.Release()
+	sortFields := oleutil.MustGetProperty(worksheetSort, "SortFields").ToIDispatch()
+	defer sortFields.Release()
+
+	if _, err := oleutil.CallMethod(sortFields, "Clear"); err != nil {
+		return fmt.Errorf("failed to clear sort fields: %w", err)
+	}
+	for _, key := range keys {
+		if key.Column < startCol || endCol < key.Column {
+			return fmt.Errorf("sort key column is out of the range: %d", key.Column)
+		}
+		keyStart, err := excelize.CoordinatesToCellName(key.Column, dataStartRow)
+		if err != nil {
+			return err
+		}
+		keyEnd, err := excelize.CoordinatesToCellName(key.Column, endRow)
+		if err != nil {
+			return err
+		}
+		keyRange := oleutil.MustGetProperty(o.worksheet, "Range", keyStart+":"+keyEnd).ToIDispatch()
+		defer keyRange.Release()
+		var customOrder any
+		if len(key.CustomOrder) > 0 {
+			customOrder = strings.Join(key.CustomOrder, ",")
+		}
+		// https://learn.microsoft.com/en-us/office/vba/api/excel.sortfields.add
+		// Excel compares dates as numbers, and text as it is displayed
+		if _, err := oleutil.CallMethod(sortFields, "Add", keyRange, int32(0), sortOrderToExcel(key.Order), customOrder, sortDataTypeToExcel(key.DataType)); err != nil { // xlSortOnValues
+			return fmt.Errorf("failed to add sort field: %w", err)
+		}
+	}
+
+	header := int32(2) // xlNo
+	if hasHeader {
+		header = 1 // xlYes
+	}
+	if _, err := oleutil.CallMethod(worksheetSort, "SetRange", sortRange); err != nil {
+		return fmt.Errorf("failed to set sort range: %w", err)
+	}
+	oleutil.MustPutProperty(worksheetSort, "Header", header)
+	oleutil.MustPutProperty(worksheetSort, "MatchCase", false)
+	oleutil.MustPutProperty(worksheetSort, "Orientation", int32(1)) // xlTopToBottom
+	if _, err := oleutil.CallMethod(worksheetSort, "Apply"); err != nil {
+		return fmt.Errorf("failed to sort range: %w", err)
+	}
+	return nil
+}
+
+func (o *OleWorksheet) GetAutoFilter() (*AutoFilter, error) {
+	if !oleutil.MustGetProperty(o.worksheet, "AutoFilterMode").Value().(bool) {
+		return nil, nil
+	}
+	autoFilter := oleutil.MustGetProperty(o.worksheet, "AutoFilter").ToIDispatch()
+	defer autoFilter.Release()
+	filterRange := oleutil.MustGetProperty(autoFilter, "Range").ToIDispatch()
+	defer filterRange.Release()
+	address := NormalizeRange(oleutil.MustGetProperty(filterRange, "Address").ToString())
+	startCol, _, _, _, err := ParseRange(address)
+	if err != nil {
+		return nil, err
+	}
+	filters := oleutil.MustGetProperty(autoFilter, "Filters").ToIDispatch()
+	defer filters.Release()
+
+	result := &AutoFilter{Range: address}
+	count := int(oleutil.MustGetProperty(filters, "Count").Val)
+	for i := 1; i <= count; i++ {
+		filter := oleutil.MustGetProperty(filters, "Item", i).ToIDispatch()
+		defer filter.Release()
+		if !oleutil.MustGetProperty(filter, "On").Value().(bool) {
+			continue
+		}
+		column := FilterColumn{Column: startCol + i - 1}
+		criteria1 := getFilterCriteria(filter, "Criteria1")
+		// https://learn.microsoft.com/en-us/office/vba/api/excel.xlautofilteroperator
+		operator := int32(oleutil.MustGetProperty(filter, "Operator").Val)
+		switch operator {
+		case 7: // xlFilterValues
+			for _, criteria := range criteria1 {
+				if value := strings.TrimPrefix(criteria, "="); value == "" {
+					column.Blank = true
+				} else {
+					column.Values = append(column.Values, value)
+				}
+			}
+		case 0, 1, 2: // xlAnd, xlOr
+			column.And = operator == 1
+			for _, criteria := range append(criteria1, getFilterCriteria(filter, "Criteria2")...) {
+				column.Conditions = append(column.Conditions, parseFilterCriteria(criteria))
+			}
+		default:
+			column.Other = excelFilterOperatorDescription(operator, criteria1)
+		}
+		result.Columns = append(result.Columns, column)
+	}
+	return result, nil
+}
+
+func (o *OleWorksheet) SetAutoFilter(autoFilter *AutoFilter) error {
+	// turning off the AutoFilter also shows the rows hidden by it
+	if oleutil.MustGetProperty(o.worksheet, "AutoFilterMode").Value().(bool) {
+		if _, err := oleutil.PutProperty(o.worksheet, "AutoFilterMode", false); err != nil {
+			return fmt.Errorf("failed to remove AutoFilter: %w", err)
+		}
+	}
+	if autoFilter == nil {
+		return nil
+	}
+	startCol, _, endCol, _, err := ParseRange(autoFilter.Range)
+	if err != nil {
+		return err
+	}
+	filterRange := oleutil.MustGetProperty(o.worksheet, "Range", autoFilter.Range).ToIDispatch()
+	defer filterRange.Release()
+
+	// https://learn.microsoft.com/en-us/office/vba/api/excel.range.autofilter
+	if len(autoFilter.Columns) == 0 {
+		if _, err := oleutil.CallMethod(filterRange, "AutoFilter", 1); err != nil {
+			return fmt.Errorf("failed to set AutoFilter: %w", err)
+		}
+		return nil
+	}
+	for _, column := range autoFilter.Columns {
+		if column.Column < startCol || endCol < column.Column {
+			return fmt.Errorf("filter column is out of the range: %d", column.Column)
+		}
+		field := column.Column - startCol + 1
+		switch {
+		case len(column.Values) > 0 || column.Blank:
+			criteria := slices.Clone(column.Values)
+			if column.Blank {
+				criteria = append(criteria, "=")
+			}
+			_, err = oleutil.CallMethod(filterRange, "AutoFilter", field, criteria, int32(7)) // xlFilterValues
+		case len(column.Conditions) == 1:
+			_, err = oleutil.CallMethod(filterRange, "AutoFilter", field, filterConditionToExcel(column.Conditions[0]))
+		case len(column.Conditions) == 2:
+			operator := int32(2) // xlOr
+			if column.And {
+				operator = 1 // xlAnd
+			}
+			_, err = oleutil.CallMethod(filterRange, "AutoFilter", field, filterConditionToExcel(column.Conditions[0]), operator, filterConditionToExcel(column.Conditions[1]))
+		case len(column.Conditions) > 2:
+			return fmt.Errorf("a filter column accepts at most 2 conditions: %d", len(column.Conditions))
+		default:
+			_, err = oleutil.CallMethod(filterRange, "AutoFilter", field)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to set AutoFilter: %w", err)
+		}
+	}
+	return nil
+}
+
+func (o *OleWorksheet) IsRowHidden(row int) (bool, error) {
+	rowRange := oleutil.MustGetProperty(o.worksheet, "Rows", row).ToIDispatch()
+	defer rowRange.Release()
+	return oleutil.MustGetProperty(rowRange, "Hidden").Value().(bool), nil
+}
+
+func (o *OleWorksheet) IsColumnHidden(col int) (bool, error) {
+	colRange := oleutil.MustGetProperty(o.worksheet, "Columns", col).ToIDispatch()
+	defer colRange.Release()
+	return oleutil.MustGetProperty(colRange, "Hidden").Value().(bool), nil
+}
+
+func (o *OleWorksheet) SetRowsHidden(startRow, endRow int, hidden bool) error {
+	rowRange := oleutil.MustGetProperty(o.worksheet, "Rows", fmt.Sprintf("%d:%d", startRow, endRow)).ToIDispatch()
+	defer rowRange.Release()
+	_, err := oleutil.PutProperty(rowRange, "Hidden", hidden)
+	return err
+}
+
+func (o *OleWorksheet) SetColumnsHidden(startCol, endCol int, hidden bool) error {
+	colRange, err := o.columnsRange(startCol, endCol)
+	if err != nil {
+		return err
+	}
+	defer colRange.Release()
+	_, err = oleutil.PutProperty(colRange, "Hidden", hidden)
+	return err
+}
+
+func (o *OleWorksheet) GetRowOutlineLevel(row int) (int, error) {
+	rowRange := oleutil.MustGetProperty(o.worksheet, "Rows", row).ToIDispatch()
+	defer rowRange.Release()
+	// Excel counts levels from 1 (not grouped)
+	return int(oleutil.MustGetProperty(rowRange, "OutlineLevel").Val) - 1, nil
+}
+
+func (o *OleWorksheet) GetColumnOutlineLevel(col int) (int, error) {
+	colRange := oleutil.MustGetProperty(o.worksheet, "Columns", col).ToIDispatch()
+	defer colRange.Release()
+	return int(oleutil.MustGetProperty(colRange, "OutlineLevel").Val) - 1, nil
+}
+
+func (o *OleWorksheet) SetRowsOutlineLevel(startRow, endRow int, level int) error {
+	if level < 0 || MaxOutlineLevel < level {
+		return fmt.Errorf("outline level must be between 0 and %d: %d", MaxOutlineLevel, level)
+	}
+	rowRange := oleutil.MustGetProperty(o.worksheet, "Rows", fmt.Sprintf("%d:%d", startRow, endRow)).ToIDispatch()
+	defer rowRange.Release()
+	_, err := oleutil.PutProperty(rowRange, "OutlineLevel", level+1)
+	return err
+}
+
+func (o *OleWorksheet) SetColumnsOutlineLevel(startCol, endCol int, level int) error {
+	if level < 0 || MaxOutlineLevel < level {
+		return fmt.Errorf("outline level must be between 0 and %d: %d", MaxOutlineLevel, level)
+	}
+	colRange, err := o.columnsRange(startCol, endCol)
+	if err != nil {
+		return err
+	}
+	defer colRange.Release()
+	_, err = oleutil.PutProperty(colRange, "OutlineLevel", level+1)
+	return err
+}
+
+// columnsRange returns the range of whole columns from startCol to endCol (e.g. "B:D")
+func (o *OleWorksheet) columnsRange(startCol, endCol int) (*ole.IDispatch, error) {
+	startName, err := excelize.ColumnNumberToName(startCol)
+	if err != nil {
+		return nil, err
+	}
+	endName, err := excelize.ColumnNumberToName(endCol)
+	if err != nil {
+		return nil, err
+	}
+	return oleutil.MustGetProperty(o.worksheet, "Columns", startName+":"+endName).ToIDispatch(), nil
+}
+
+// getFilterCriteria returns the criteria of the filter (e.g. "=a", ">=10"), or nil if it is not set.
+func getFilterCriteria(filter *ole.IDispatch, name string) []string {
+	criteria, err := oleutil.GetProperty(filter, name)
+	if err != nil {
+		return nil
+	}
+	defer criteria.Clear()
+	if criteria.VT&ole.VT_ARRAY != 0 {
+		var result []string
+		for _, value := range criteria.ToArray().ToValueArray() {
+			result = append(result, fmt.Sprint(value))
+		}
+		return result
+	}
+	if value := criteria.Value(); value != nil {
+		return []string{fmt.Sprint(value)}
+	}
+	return nil
+}
+
+// parseFilterCriteria parses a criteria string of Excel (e.g. ">=10") into a condition
+func parseFilterCriteria(criteria string) FilterCondition {
+	for _, prefix := range []struct {
+		text     string
+		operator FilterOperator
+	}{
+		{"<>", FilterOperatorNotEqual},
+		{">=", FilterOperatorGreaterThanOrEqual},
+		{"<=", FilterOperatorLessThanOrEqual},
+		{">", FilterOperatorGreaterThan},
+		{"<", FilterOperatorLessThan},
+		{"=", FilterOperatorEqual},
+	} {
+		if value, ok := strings.CutPrefix(criteria, prefix.text); ok {
+			return FilterCondition{Operator: prefix.operator, Value: value}
+		}
+	}
+	return FilterCondition{Operator: FilterOperatorEqual, Value: criteria}
+}
+
+// getChartAxisTitle returns the title of the axis. It returns empty string if the chart has no such axis (e.g. pie).
+func getChartAxisTitle(chart *ole.IDispatch, axisType int) string {
+	axisVar, err := oleutil.CallMethod(chart, "Axes", axisType)
+	if err != nil {
+		return ""
+	}
+	axis := axisVar.ToIDispatch()
+	defer axis.Release()
+	if !oleutil.MustGetProperty(axis, "HasTitle").Value().(bool) {
+		return ""
+	}
+	axisTitle := oleutil.MustGetProperty(axis, "AxisTitle").ToIDispatch()
+	defer axisTitle.Release()
+	return oleutil.MustGetProperty(axisTitle, "Text").ToString()
+}
+
+func setChartAxisTitle(chart *ole.IDispatch, axisType int, title string) {
+	if title == "" {
+		return
+	}
+	axisVar, err := oleutil.CallMethod(chart, "Axes", axisType)
+	if err != nil {
+		return
+	}
+	axis := axisVar.ToIDispatch()
+	defer axis.Release()
+	oleutil.PutProperty(axis, "HasTitle", true)
+	axisTitle := oleutil.MustGetProperty(axis, "AxisTitle").ToIDispatch()
+	defer axisTitle.Release()
+	oleutil.PutProperty(axisTitle, "Text", title)
+}
+
+// parseSeriesFormula parses a SERIES formula (e.g. "=SERIES(Sheet1!$B$1,Sheet1!$A$2:$A$6,Sheet1!$B$2:$B$6,1)")
+func parseSeriesFormula(formula string) ChartSeries {
+	formula = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(formula, "="), "SERIES("), ")")
+	var args []string
+	var current strings.Builder
+	inQuote, depth := false, 0
+	for _, r := range formula {
+		switch {
+		case r == '\'' || r == '"':
+			inQuote = !inQuote
+		case !inQuote && r == '(':
+			depth++
+		case !inQuote && r == ')':
+			depth--
+		case !inQuote && depth == 0 && r == ',':
+			args = append(args, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	args = append(args, current.String())
+	series := ChartSeries{Name: args[0]}
+	if len(args) > 1 {
+		series.Categories = args[1]
+	}
+	if len(args) > 2 {
+		series.Values = args[2]
+	}
+	return series
+}
+
+// chartTypeToExcel converts ChartType to Excel XlChartType constant
+func chartTypeToExcel(chartType ChartType) int32 {
+	switch chartType {
+	case ChartTypeBar:
+		return 57 // xlBarClustered
+	case ChartTypeLine:
+		return 4 // xlLine
+	case ChartTypePie:
+		return 5 // xlPie
+	case ChartTypeScatter:
+		return -4169 // xlXYScatter
+	case ChartTypeArea:
+		return 1 // xlArea
+	default:
+		return 51 // xlColumnClustered
+	}
+}
+
+// excelToChartType converts Excel XlChartType constant to ChartType
+func excelToChartType(excelType int32) ChartType {
+	switch excelType {
+	case 51, 52, 53, 54, 55, 56: // xlColumnClustered, xlColumnStacked, xlColumnStacked100, xl3DColumnClustered, ...
+		return ChartTypeColumn
+	case 57, 58, 59, 60, 61, 62: // xlBarClustered, xlBarStacked, xlBarStacked100, xl3DBarClustered, ...
+		return ChartTypeBar
+	case 4, 63, 64, 65, 66, 67, -4101: // xlLine, xlLineStacked, ..., xl3DLine
+		return ChartTypeLine
+	case 5, 68, 69, 70, 71, -4102: // xlPie, xlPieOfPie, xlPieExploded, xl3DPieExploded, xlBarOfPie, xl3DPie
+		return ChartTypePie
+	case -4169, 72, 73, 74, 75: // xlXYScatter, xlXYScatterSmooth, ...
+		return ChartTypeScatter
+	case 1, 76, 77, 78, 79, -4098: // xlArea, xlAreaStacked, xlAreaStacked100, xl3DAreaStacked, ..., xl3DArea
+		return ChartTypeArea
+	case -4120, 80: // xlDoughnut, xlDoughnutExploded
+		return "doughnut"
+	case -4151, 81, 82: // xlRadar, xlRadarMarkers, xlRadarFilled
+		return "radar"
+	case 15, 87: // xlBubble, xlBubble3DEffect
+		return "bubble"
+	default:
+		return "other"
+	}
+}
+
+// chartLegendPositionToExcel converts ChartLegendPosition to Excel XlLegendPosition constant
+func chartLegendPositionToExcel(position ChartLegendPosition) int32 {
+	switch position {
+	case ChartLegendPositionTop:
+		return -4160 // xlLegendPositionTop
+	case ChartLegendPositionLeft:
+		return -4131 // xlLegendPositionLeft
+	case ChartLegendPositionRight:
+		return -4152 // xlLegendPositionRight
+	case ChartLegendPositionTopRight:
+		return 2 // xlLegendPositionCorner
+	default:
+		return -4107 // xlLegendPositionBottom
+	}
+}
+
+// excelToChartLegendPosition converts Excel XlLegendPosition constant to ChartLegendPosition
+func excelToChartLegendPosition(excelPosition int32) ChartLegendPosition {
+	switch excelPosition {
+	case -4160:
+		return ChartLegendPositionTop
+	case -4131:
+		return ChartLegendPositionLeft
+	case -4152:
+		return ChartLegendPositionRight
+	case 2:
+		return ChartLegendPositionTopRight
+	default:
+		return ChartLegendPositionBottom
+	}
+}
+
+// getPivotFieldNames returns the source names of the pivot fields in the collection (e.g. RowFields).
+// The pseudo field of values is excluded.
+func getPivotFieldNames(pivotTable *ole.IDispatch, collectionName string) []string {
+	fields := oleutil.MustGetProperty(pivotTable, collectionName).ToIDispatch()
+	defer fields.Release()
+	count := int(oleutil.MustGetProperty(fields, "Count").Val)
+	var names []string
+	for i := 1; i <= count; i++ {
+		field := oleutil.MustGetProperty(fields, "Item", i).ToIDispatch()
+		defer field.Release()
+		sourceName, err := oleutil.GetProperty(field, "SourceName")
+		if err != nil {
+			continue
+		}
+		names = append(names, sourceName.ToString())
+	}
+	return names
+}
+
+// copyModeToExcelPasteType converts CopyMode to Excel XlPasteType constant
+func copyModeToExcelPasteType(mode CopyMode) int32 {
+	switch mode {
+	case CopyModeValue:
+		return -4163 // xlPasteValues
+	case CopyModeFormula:
+		return -4123 // xlPasteFormulas
+	case CopyModeFormat:
+		return -4122 // xlPasteFormats
+	default:
+		return -4104 // xlPasteAll
+	}
+}
+
+// sortOrderToExcel converts SortOrder to Excel XlSortOrder constant
+func sortOrderToExcel(order SortOrder) int32 {
+	switch order {
+	case SortOrderDescending:
+		return 2 // xlDescending
+	default:
+		return 1 // xlAscending
+	}
+}
+
+// sortDataTypeToExcel converts SortDataType to Excel XlSortDataOption constant
+func sortDataTypeToExcel(dataType SortDataType) int32 {
+	switch dataType {
+	case SortDataTypeNumber:
+		return 1 // xlSortTextAsNumbers
+	default:
+		return 0 // xlSortNormal
+	}
+}
+
+// filterConditionToExcel converts FilterCondition to Excel criteria string
+func filterConditionToExcel(condition FilterCondition) string {
+	switch condition.Operator {
+	case FilterOperatorNotEqual:
+		return "<>" + condition.Value
+	case FilterOperatorGreaterThan:
+		return ">" + condition.Value
+	case FilterOperatorGreaterThanOrEqual:
+		return ">=" + condition.Value
+	case FilterOperatorLessThan:
+		return "<" + condition.Value
+	case FilterOperatorLessThanOrEqual:
+		return "<=" + condition.Value
+	default:
+		return "=" + condition.Value
+	}
+}
+
+// excelFilterOperatorDescription describes Excel XlAutoFilterOperator constant which is not converted to FilterColumn
+func excelFilterOperatorDescription(operator int32, criteria []string) string {
+	value := strings.Join(criteria, ", ")
+	switch operator {
+	case 3: // xlTop10Items
+		return fmt.Sprintf("top %s items", value)
+	case 4: // xlBottom10Items
+		return fmt.Sprintf("bottom %s items", value)
+	case 5: // xlTop10Percent
+		return fmt.Sprintf("top %s percent", value)
+	case 6: // xlBottom10Percent
+		return fmt.Sprintf("bottom %s percent", value)
+	case 8, 9, 12, 13: // xlFilterCellColor, xlFilterFontColor, xlFilterNoFill, xlFilterAutomaticFontColor
+		return "color filter"
+	case 10, 14: // xlFilterIcon, xlFilterNoIcon
+		return "icon filter"
+	case 11: // xlFilterDynamic
+		return "dynamic filter"
+	default:
+		return fmt.Sprintf("filter operator %d", operator)
+	}
+}
+
+// pivotFunctionToExcel converts PivotFunction to Excel XlConsolidationFunction constant
+func pivotFunctionToExcel(function PivotFunction) int32 {
+	switch function {
+	case PivotFunctionCount:
+		return -4112 // xlCount
+	case PivotFunctionAverage:
+		return -4106 // xlAverage
+	case PivotFunctionMax:
+		return -4136 // xlMax
+	case PivotFunctionMin:
+		return -4139 // xlMin
+	case PivotFunctionProduct:
+		return -4149 // xlProduct
+	case PivotFunctionCountNums:
+		return -4113 // xlCountNums
+	case PivotFunctionStdDev:
+		return -4155 // xlStDev
+	case PivotFunctionStdDevp:
+		return -4156 // xlStDevP
+	case PivotFunctionVar:
+		return -4164 // xlVar
+	case PivotFunctionVarp:
+		return -4165 // xlVarP
+	default:
+		return -4157 // xlSum
+	}
+}
+
+// excelToPivotFunction converts Excel XlConsolidationFunction constant to PivotFunction
+func excelToPivotFunction(excelFunction int32) PivotFunction {
+	switch excelFunction {
+	case -4112:
+		return PivotFunctionCount
+	case -4106:
+		return PivotFunctionAverage
+	case -4136:
+		return PivotFunctionMax
+	case -4139:
+		return PivotFunctionMin
+	case -4149:
+		return PivotFunctionProduct
+	case -4113:
+		return PivotFunctionCountNums
+	case -4155:
+		return PivotFunctionStdDev
+	case -4156:
+		return PivotFunctionStdDevp
+	case -4164:
+		return PivotFunctionVar
+	case -4165:
+		return PivotFunctionVarp
+	default:
+		return PivotFunctionSum
+	}
+}
+
+// readConditionalFormatStyle reads font and fill of a FormatCondition. Properties which are not set are ignored.
+func readConditionalFormatStyle(fc *ole.IDispatch) *CellStyle {
+	style := &CellStyle{}
+	font := oleutil.MustGetProperty(fc, "Font").ToIDispatch()
+	defer font.Release()
+	bold, isBold := oleutil.MustGetProperty(font, "Bold").Value().(bool)
+	fontColor, hasFontColor := oleutil.MustGetProperty(font, "Color").Value().(float64)
+	if isBold || hasFontColor {
+		style.Font = &FontStyle{}
+		if isBold {
+			style.Font.Bold = &bold
+		}
+		if hasFontColor {
+			colorStr := bgrToRgb(fontColor)
+			style.Font.Color = &colorStr
+		}
+	}
+	interior := oleutil.MustGetProperty(fc, "Interior").ToIDispatch()
+	defer interior.Release()
+	if interiorColor, ok := oleutil.MustGetProperty(interior, "Color").Value().(float64); ok {
+		style.Fill = &FillStyle{
+			Type:    "pattern",
+			Pattern: FillPatternSolid,
+			Color:   []string{bgrToRgb(interiorColor)},
+		}
+	}
+	if style.Font == nil && style.Fill == nil {
+		return nil
+	}
+	return style
+}
+
+// conditionalFormatOperatorToExcel converts ConditionalFormatOperator to Excel XlFormatConditionOperator constant
+func conditionalFormatOperatorToExcel(operator ConditionalFormatOperator) int32 {
+	switch operator {
+	case ConditionalFormatOperatorBetween:
+		return 1 // xlBetween
+	case ConditionalFormatOperatorNotBetween:
+		return 2 // xlNotBetween
+	case ConditionalFormatOperatorEqual:
+		return 3 // xlEqual
+	case ConditionalFormatOperatorNotEqual:
+		return 4 // xlNotEqual
+	case ConditionalFormatOperatorGreaterThan:
+		return 5 // xlGreater
+	case ConditionalFormatOperatorLessThan:
+		return 6 // xlLess
+	case ConditionalFormatOperatorGreaterThanOrEqual:
+		return 7 // xlGreaterEqual
+	case ConditionalFormatOperatorLessThanOrEqual:
+		return 8 // xlLessEqual
+	default:
+		return 1 // xlBetween
+	}
+}
+
+// excelToConditionalFormatOperator converts Excel XlFormatConditionOperator constant to ConditionalFormatOperator
+func excelToConditionalFormatOperator(excelOperator int32) ConditionalFormatOperator {
+	switch excelOperator {
+	case 1:
+		return ConditionalFormatOperatorBetween
+	case 2:
+		return ConditionalFormatOperatorNotBetween
+	case 3:
+		return ConditionalFormatOperatorEqual
+	case 4:
+		return ConditionalFormatOperatorNotEqual
+	case 5:
+		return ConditionalFormatOperatorGreaterThan
+	case 6:
+		return ConditionalFormatOperatorLessThan
+	case 7:
+		return ConditionalFormatOperatorGreaterThanOrEqual
+	case 8:
+		return ConditionalFormatOperatorLessThanOrEqual
+	default:
+		return ""
+	}
+}
+
+// dataValidationTypeToExcel converts DataValidationType to Excel XlDVType constant
+func dataValidationTypeToExcel(dvType DataValidationType) int32 {
+	switch dvType {
+	case DataValidationTypeWhole:
+		return 1 // xlValidateWholeNumber
+	case DataValidationTypeDecimal:
+		return 2 // xlValidateDecimal
+	case DataValidationTypeList:
+		return 3 // xlValidateList
+	case DataValidationTypeDate:
+		return 4 // xlValidateDate
+	case DataValidationTypeTime:
+		return 5 // xlValidateTime
+	case DataValidationTypeTextLength:
+		return 6 // xlValidateTextLength
+	case DataValidationTypeCustom:
+		return 7 // xlValidateCustom
+	default:
+		return 0 // xlValidateInputOnly
+	}
+}
+
+// excelToDataValidationType converts Excel XlDVType constant to DataValidationType
+func excelToDataValidationType(excelType int32) DataValidationType {
+	switch excelType {
+	case 1: // xlValidateWholeNumber
+		return DataValidationTypeWhole
+	case 2: // xlValidateDecimal
+		return DataValidationTypeDecimal
+	case 3: // xlValidateList
+		return DataValidationTypeList
+	case 4: // xlValidateDate
+		return DataValidationTypeDate
+	case 5: // xlValidateTime
+		return DataValidationTypeTime
+	case 6: // xlValidateTextLength
+		return DataValidationTypeTextLength
+	case 7: // xlValidateCustom
+		return DataValidationTypeCustom
+	default: // xlValidateInputOnly
+		return ""
+	}
+}
+
+// dataValidationOperatorToExcel converts DataValidationOperator to Excel XlFormatConditionOperator constant
+func dataValidationOperatorToExcel(operator DataValidationOperator) int32 {
+	switch operator {
+	case DataValidationOperatorBetween:
+		return 1 // xlBetween
+	case DataValidationOperatorNotBetween:
+		return 2 // xlNotBetween
+	case DataValidationOperatorEqual:
+		return 3 // xlEqual
+	case DataValidationOperatorNotEqual:
+		return 4 // xlNotEqual
+	case DataValidationOperatorGreaterThan:
+		return 5 // xlGreater
+	case DataValidationOperatorLessThan:
+		return 6 // xlLess
+	case DataValidationOperatorGreaterThanOrEqual:
+		return 7 // xlGreaterEqual
+	case DataValidationOperatorLessThanOrEqual:
+		return 8 // xlLessEqual
+	default:
+		return 1 // xlBetween
+	}
+}
+
+// excelToDataValidationOperator converts Excel XlFormatConditionOperator constant to DataValidationOperator
+func excelToDataValidationOperator(excelOperator int32) DataValidationOperator {
+	switch excelOperator {
+	case 1: // xlBetween
+		return DataValidationOperatorBetween
+	case 2: // xlNotBetween
+		return DataValidationOperatorNotBetween
+	case 3: // xlEqual
+		return DataValidationOperatorEqual
+	case 4: // xlNotEqual
+		return DataValidationOperatorNotEqual
+	case 5: // xlGreater
+		return DataValidationOperatorGreaterThan
+	case 6: // xlLess
+		return DataValidationOperatorLessThan
+	case 7: // xlGreaterEqual
+		return DataValidationOperatorGreaterThanOrEqual
+	case 8: // xlLessEqual
+		return DataValidationOperatorLessThanOrEqual
+	default:
+		return ""
+	}
+}
+
+// dataValidationErrorStyleToExcel converts DataValidationErrorStyle to Excel XlDVAlertStyle constant
+func dataValidationErrorStyleToExcel(errorStyle DataValidationErrorStyle) int32 {
+	switch errorStyle {
+	case DataValidationErrorStyleWarning:
+		return 2 // xlValidAlertWarning
+	case DataValidationErrorStyleInformation:
+		return 3 // xlValidAlertInformation
+	default:
+		return 1 // xlValidAlertStop
+	}
+}
+
+// excelToDataValidationErrorStyle converts Excel XlDVAlertStyle constant to DataValidationErrorStyle
+func excelToDataValidationErrorStyle(excelStyle int32) DataValidationErrorStyle {
+	switch excelStyle {
+	case 2: // xlValidAlertWarning
+		return DataValidationErrorStyleWarning
+	case 3: // xlValidAlertInformation
+		return DataValidationErrorStyleInformation
+	default: // xlValidAlertStop
+		return DataValidationErrorStyleStop
+	}
+}
+
 // rgbToBgr converts RGB hex string to BGR color format
 func rgbToBgr(rgbColor string) int32 {
 	if len(rgbColor) != 7 || rgbColor[0] != '#' {
@@ -2,6 +2,11 @@ package excel
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
 	"github.com/xuri/excelize/v2"
 )
 
@@ -77,7 +82,7 @@ func NewOlePagingStrategy(pageSize int, worksheet *OleWorksheet) (PagingStrategy
 		return nil, fmt.Errorf("worksheet is nil")
 	}
 
-	printAreaPagingStrategy, err := NewPrintAreaPagingStrategy(worksheet)
+	printAreaPagingStrategy, err := NewPrintAreaPagingStrategy(pageSize, worksheet)
 	if err != nil {
 		return nil, err
 	}
@@ -86,6 +91,20 @@ func NewOlePagingStrategy(pageSize int, worksheet *OleWorksheet) (PagingStrategy
 		return nil, err
 	}
 	if printArea == "" {
+		regionStrategy, err := newRegionPagingStrategy(pageSize, worksheet)
+		if err != nil {
+			return nil, err
+		}
+		if regionStrategy != nil {
+			return regionStrategy, nil
+		}
+		dimension, err := worksheet.GetDimention()
+		if err != nil {
+			return nil, err
+		}
+		if IsWideForPaging(dimension, pageSize) {
+			return NewTiledPagingStrategy(pageSize, worksheet)
+		}
 		return NewGoxcelFixedSizePagingStrategy(pageSize, worksheet)
 	} else {
 		return printAreaPagingStrategy, nil
@@ -157,17 +176,31 @@ func (s *OleFixedSizePagingStrategy) CalculatePagingRanges() []string {
 }
 
 
+// PrintAreaWorksheet is a worksheet which tells its print area and horizontal page breaks.
+type PrintAreaWorksheet interface {
+	// PrintArea returns the print area of the worksheet (e.g. $A$1:$D$20), or an empty string if it is not set.
+	PrintArea() (string, error)
+	// HPageBreaks returns the first rows of the pages after the horizontal page breaks.
+	HPageBreaks() ([]int, error)
+}
+
 // PrintAreaPagingStrategy は印刷範囲とページ区切りに基づいてページング範囲を計算する戦略
+// Printed pages with more cells than the page size are split by rows, or tiled if a row has more cells than the page size.
 type PrintAreaPagingStrategy struct {
-	worksheet *OleWorksheet
+	pageSize  int
+	worksheet PrintAreaWorksheet
 }
 
 // NewPrintAreaPagingStrategy は新しいPrintAreaPagingStrategyインスタンスを生成する
-func NewPrintAreaPagingStrategy(worksheet *OleWorksheet) (*PrintAreaPagingStrategy, error) {
+func NewPrintAreaPagingStrategy(pageSize int, worksheet PrintAreaWorksheet) (*PrintAreaPagingStrategy, error) {
 	if worksheet == nil {
 		return nil, fmt.Errorf("worksheet is nil")
 	}
+	if pageSize <= 0 {
+		pageSize = 5000 // デフォルト値
+	}
 	return &PrintAreaPagingStrategy{
+		pageSize:  pageSize,
 		worksheet: worksheet,
 	}, nil
 }
@@ -188,44 +221,50 @@ func (s *PrintAreaPagingStrategy) getHPageBreaksPositions() ([]int, error) {
 
 // calculateRangesFromBreaks は印刷範囲とページ区切りから範囲のリストを生成する
 func (s *PrintAreaPagingStrategy) calculateRangesFromBreaks(printArea string, breaks []int) []string {
-	if printArea == "" {
+	var ranges []string
+	for _, page := range s.printedPages(printArea, breaks) {
+		ranges = append(ranges, pagesOf(s.pageSize, page)...)
+	}
+	if ranges == nil {
 		return []string{}
 	}
+	return ranges
+}
 
-	startCol, startRow, endCol, endRow, err := ParseRange(printArea)
-	if err != nil {
+// printedPages returns the pages of the print area split at the breaks, as they are printed.
+func (s *PrintAreaPagingStrategy) printedPages(printArea string, breaks []int) []string {
+	if printArea == "" {
 		return []string{}
 	}
 
 	ranges := make([]string, 0)
-	currentRow := startRow
-
-	// ページ区切りがない場合は印刷範囲全体を1つの範囲として扱う
-	if len(breaks) == 0 {
-		startRange, _ := excelize.CoordinatesToCellName(startCol, startRow)
-		endRange, _ := excelize.CoordinatesToCellName(endCol, endRow)
-		ranges = append(ranges, fmt.Sprintf("%s:%s", startRange, endRange))
-		return ranges
-	}
-
-	// ページ区切りで範囲を分割
-	for _, breakRow := range breaks {
-		if breakRow <= startRow || breakRow > endRow {
-			continue
+	// a print area of multiple areas is printed area by area
+	for _, area := range SplitRangeAreas(printArea) {
+		startCol, startRow, endCol, endRow, err := ParseRange(area)
+		if err != nil {
+			return []string{}
 		}
+		currentRow := startRow
 
-		startRange, _ := excelize.CoordinatesToCellName(startCol, currentRow)
-		endRange, _ := excelize.CoordinatesToCellName(endCol, breakRow-1)
-		ranges = append(ranges, fmt.Sprintf("%s:%s", startRange, endRange))
+		// ページ区切りで範囲を分割 (ページ区切りがない場合は印刷範囲全体を1つの範囲として扱う)
+		for _, breakRow := range breaks {
+			if breakRow <= currentRow || breakRow > endRow {
+				continue
+			}
 
-		currentRow = breakRow
-	}
+			startRange, _ := excelize.CoordinatesToCellName(startCol, currentRow)
+			endRange, _ := excelize.CoordinatesToCellName(endCol, breakRow-1)
+			ranges = append(ranges, fmt.Sprintf("%s:%s", startRange, endRange))
 
-	// 最後の範囲を追加
-	if currentRow <= endRow {
-		startRange, _ := excelize.CoordinatesToCellName(startCol, currentRow)
-		endRange, _ := excelize.CoordinatesToCellName(endCol, endRow)
-		ranges = append(ranges, fmt.Sprintf("%s:%s", startRange, endRange))
+			currentRow = breakRow
+		}
+
+		// 最後の範囲を追加
+		if currentRow <= endRow {
+			startRange, _ := excelize.CoordinatesToCellName(startCol, currentRow)
+			endRange, _ := excelize.CoordinatesToCellName(endCol, endRow)
+			ranges = append(ranges, fmt.Sprintf("%s:%s", startRange, endRange))
+		}
 	}
 
 	return ranges
@@ -246,6 +285,672 @@ func (s *PrintAreaPagingStrategy) CalculatePagingRanges() []string {
 	return s.calculateRangesFromBreaks(printArea, breaks)
 }
 
+// LabelColumnsOf returns the row label columns of the tiled printed page where the range starts, or zeros if
+// the page is not tiled.
+func (s *PrintAreaPagingStrategy) LabelColumnsOf(rangeStr string) (int, int) {
+	printArea, err := s.getPrintArea()
+	if err != nil {
+		return 0, 0
+	}
+	breaks, err := s.getHPageBreaksPositions()
+	if err != nil {
+		return 0, 0
+	}
+	return labelColumnsOf(s.pageSize, s.printedPages(printArea, breaks), rangeStr)
+}
+
+
+// tilingMinRows is the number of rows of a page, below which wide sheets are tiled into column blocks as well
+const tilingMinRows = 10
+
+// IsWideForPaging reports whether the range does not fit in a page, and pages of it split only by rows would have
+// fewer than tilingMinRows rows. A range whose row alone has more cells than the page size is always wide.
+func IsWideForPaging(dimension string, pageSize int) bool {
+	startCol, startRow, endCol, endRow, err := ParseRange(dimension)
+	if err != nil {
+		return false
+	}
+	cols, rows := endCol-startCol+1, endRow-startRow+1
+	if rows*cols <= pageSize {
+		return false
+	}
+	return cols*tilingMinRows > pageSize
+}
+
+// TiledPagingStrategy tiles a wide sheet into column blocks as well as row blocks.
+// Tiles are ordered from left to right, then from top to bottom, and the first column is repeated as row labels.
+type TiledPagingStrategy struct {
+	pageSize  int
+	dimension string
+}
+
+// NewTiledPagingStrategy creates a strategy which tiles the used range of the worksheet.
+func NewTiledPagingStrategy(pageSize int, worksheet Worksheet) (*TiledPagingStrategy, error) {
+	if pageSize <= 0 {
+		pageSize = 5000 // デフォルト値
+	}
+	dimension, err := worksheet.GetDimention()
+	if err != nil {
+		return nil, err
+	}
+	return &TiledPagingStrategy{
+		pageSize:  pageSize,
+		dimension: dimension,
+	}, nil
+}
+
+// LabelColumns returns the first and the last columns repeated as row labels on tiles right of them.
+func (s *TiledPagingStrategy) LabelColumns() (int, int) {
+	startCol, _, _, _, err := ParseRange(s.dimension)
+	if err != nil {
+		return 0, 0
+	}
+	return startCol, startCol
+}
+
+func (s *TiledPagingStrategy) CalculatePagingRanges() []string {
+	startCol, startRow, endCol, endRow, err := ParseRange(s.dimension)
+	if err != nil {
+		return []string{}
+	}
+	labelCols := 1
+
+	// column blocks have even widths, so that tiles have at least tilingMinRows rows, or all the rows, if possible
+	totalCols, totalRows := endCol-startCol+1, endRow-startRow+1
+	maxColsPerTile := max(s.pageSize/min(totalRows, tilingMinRows)-labelCols, 1)
+	blocks := (totalCols + maxColsPerTile - 1) / maxColsPerTile
+	colsPerTile := (totalCols + blocks - 1) / blocks
+	rowsPerTile := max(s.pageSize/(colsPerTile+labelCols), 1)
+
+	var ranges []string
+	for currentRow := startRow; currentRow <= endRow; currentRow += rowsPerTile {
+		tileEndRow := min(currentRow+rowsPerTile-1, endRow)
+		for currentCol := startCol; currentCol <= endCol; currentCol += colsPerTile {
+			tileEndCol := min(currentCol+colsPerTile-1, endCol)
+			startRange, _ := excelize.CoordinatesToCellName(currentCol, currentRow)
+			endRange, _ := excelize.CoordinatesToCellName(tileEndCol, tileEndRow)
+			ranges = append(ranges, fmt.Sprintf("%s:%s", startRange, endRange))
+		}
+	}
+	return ranges
+}
+
+// regionDetectionCellsLimit is the number of cells of the used range, above which data regions are not detected
+const regionDetectionCellsLimit = 200000
+
+// DataRegion is a rectangle of data separated from other data by blank rows and columns.
+type DataRegion struct {
+	Range string
+	// Labels are the values in the first row of the region
+	Labels []string
+}
+
+// dataRegionsCache keeps the data regions of a worksheet once they are detected, so that a tool which pages a sheet
+// and lists its regions detects them once. Worksheets reset it when they write values.
+type dataRegionsCache struct {
+	regions  []DataRegion
+	detected bool
+}
+
+// dataRegionsCacher is a worksheet which keeps its detected data regions.
+type dataRegionsCacher interface {
+	cachedDataRegions() *dataRegionsCache
+}
+
+// filledCellsReader is a worksheet which tells which cells have values or formulas faster than reading the values.
+type filledCellsReader interface {
+	filledCells(rangeStr string) ([][]bool, error)
+}
+
+// DetectDataRegions returns the data regions of the worksheet ordered by their top rows and then their left columns.
+// Cells touching each other, even diagonally, belong to the same region, and regions whose rectangles overlap are merged.
+// Regions are not detected for a sheet larger than regionDetectionCellsLimit cells, and nil is returned.
+func DetectDataRegions(worksheet Worksheet) ([]DataRegion, error) {
+	var cache *dataRegionsCache
+	if cacher, ok := worksheet.(dataRegionsCacher); ok {
+		if cache = cacher.cachedDataRegions(); cache.detected {
+			return cache.regions, nil
+		}
+	}
+	regions, err := detectDataRegions(worksheet)
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		*cache = dataRegionsCache{regions: regions, detected: true}
+	}
+	return regions, nil
+}
+
+// filledCellsOf tells which cells of the range have values or formulas.
+func filledCellsOf(worksheet Worksheet, rangeStr string) ([][]bool, error) {
+	if reader, ok := worksheet.(filledCellsReader); ok {
+		return reader.filledCells(rangeStr)
+	}
+	cells, err := worksheet.GetRangeValues(rangeStr)
+	if err != nil {
+		return nil, err
+	}
+	filled := make([][]bool, len(cells))
+	for i, row := range cells {
+		filled[i] = make([]bool, len(row))
+		for j, cell := range row {
+			filled[i][j] = cell.Value != "" || cell.Formula != ""
+		}
+	}
+	return filled, nil
+}
+
+func detectDataRegions(worksheet Worksheet) ([]DataRegion, error) {
+	dimension, err := worksheet.GetDimention()
+	if err != nil {
+		return nil, err
+	}
+	startCol, startRow, endCol, endRow, err := ParseRange(dimension)
+	if err != nil {
+		return nil, err
+	}
+	rows, cols := endRow-startRow+1, endCol-startCol+1
+	if rows*cols > regionDetectionCellsLimit {
+		return nil, nil
+	}
+	filled, err := filledCellsOf(worksheet, dimension)
+	if err != nil {
+		return nil, err
+	}
+
+	// label cells with the index of the rectangle of their connected cells
+	rectangles := [][4]int{} // top, left, bottom, right relative to the used range
+	labels := make([][]int, rows)
+	for i := range labels {
+		labels[i] = make([]int, cols)
+	}
+	for i := range rows {
+		for j := range cols {
+			if !filled[i][j] || labels[i][j] != 0 {
+				continue
+			}
+			rectangles = append(rectangles, [4]int{i, j, i, j})
+			index := len(rectangles)
+			labels[i][j] = index
+			queue := [][2]int{{i, j}}
+			for len(queue) > 0 {
+				cell := queue[0]
+				queue = queue[1:]
+				rectangle := &rectangles[index-1]
+				rectangle[0], rectangle[1] = min(rectangle[0], cell[0]), min(rectangle[1], cell[1])
+				rectangle[2], rectangle[3] = max(rectangle[2], cell[0]), max(rectangle[3], cell[1])
+				for di := -1; di <= 1; di++ {
+					for dj := -1; dj <= 1; dj++ {
+						ni, nj := cell[0]+di, cell[1]+dj
+						if ni < 0 || rows <= ni || nj < 0 || cols <= nj || labels[ni][nj] != 0 {
+							continue
+						}
+						if !filled[ni][nj] {
+							continue
+						}
+						labels[ni][nj] = index
+						queue = append(queue, [2]int{ni, nj})
+					}
+				}
+			}
+		}
+	}
+
+	// merge overlapping rectangles until none overlap
+	for merged := true; merged; {
+		merged = false
+		for a := 0; a < len(rectangles) && !merged; a++ {
+			for b := a + 1; b < len(rectangles); b++ {
+				ra, rb := rectangles[a], rectangles[b]
+				if ra[0] <= rb[2] && rb[0] <= ra[2] && ra[1] <= rb[3] && rb[1] <= ra[3] {
+					rectangles[a] = [4]int{min(ra[0], rb[0]), min(ra[1], rb[1]), max(ra[2], rb[2]), max(ra[3], rb[3])}
+					rectangles = append(rectangles[:b], rectangles[b+1:]...)
+					merged = true
+					break
+				}
+			}
+		}
+	}
+	slices.SortFunc(rectangles, func(a, b [4]int) int {
+		if a[0] != b[0] {
+			return a[0] - b[0]
+		}
+		return a[1] - b[1]
+	})
+
+	regions := make([]DataRegion, 0, len(rectangles))
+	for _, rectangle := range rectangles {
+		region := DataRegion{
+			Range: formatPagingRange(startCol+rectangle[1], startRow+rectangle[0], startCol+rectangle[3], startRow+rectangle[2]),
+		}
+		// only the first rows of the regions are read for the labels
+		labelCells, err := worksheet.GetRangeValues(formatPagingRange(startCol+rectangle[1], startRow+rectangle[0], startCol+rectangle[3], startRow+rectangle[0]))
+		if err != nil {
+			return nil, err
+		}
+		for _, cell := range labelCells[0] {
+			if cell.Value != "" {
+				region.Labels = append(region.Labels, cell.Value)
+			}
+		}
+		regions = append(regions, region)
+	}
+	return regions, nil
+}
+
+// RegionPagingStrategy pages by data regions. A region with more cells than the page size is split by rows,
+// or tiled as TiledPagingStrategy does if a row has more cells than the page size.
+type RegionPagingStrategy struct {
+	pageSize int
+	regions  []DataRegion
+}
+
+// NewRegionPagingStrategy creates a strategy which pages by the regions.
+func NewRegionPagingStrategy(pageSize int, regions []DataRegion) *RegionPagingStrategy {
+	if pageSize <= 0 {
+		pageSize = 5000 // デフォルト値
+	}
+	return &RegionPagingStrategy{
+		pageSize: pageSize,
+		regions:  regions,
+	}
+}
+
+// Regions returns the data regions in the paging order.
+func (s *RegionPagingStrategy) Regions() []DataRegion {
+	return s.regions
+}
+
+func (s *RegionPagingStrategy) CalculatePagingRanges() []string {
+	var ranges []string
+	for _, region := range s.regions {
+		if _, _, _, _, err := ParseRange(region.Range); err != nil {
+			return []string{}
+		}
+		ranges = append(ranges, pagesOf(s.pageSize, region.Range)...)
+	}
+	return ranges
+}
+
+// LabelColumnsOf returns the row label columns of the tiled region where the range starts, or zeros if the region
+// is not tiled.
+func (s *RegionPagingStrategy) LabelColumnsOf(rangeStr string) (int, int) {
+	areas := make([]string, len(s.regions))
+	for i, region := range s.regions {
+		areas[i] = region.Range
+	}
+	return labelColumnsOf(s.pageSize, areas, rangeStr)
+}
+
+// pagesOf splits the area by rows into pages of at most pageSize cells, or tiles it as TiledPagingStrategy does
+// if a row has more cells than pageSize.
+func pagesOf(pageSize int, area string) []string {
+	if isRowWiderThanPage(area, pageSize) {
+		return (&TiledPagingStrategy{pageSize: pageSize, dimension: area}).CalculatePagingRanges()
+	}
+	startCol, startRow, endCol, endRow, err := ParseRange(area)
+	if err != nil {
+		return nil
+	}
+	var ranges []string
+	rowsPerPage := max(pageSize/(endCol-startCol+1), 1)
+	for currentRow := startRow; currentRow <= endRow; currentRow += rowsPerPage {
+		ranges = append(ranges, formatPagingRange(startCol, currentRow, endCol, min(currentRow+rowsPerPage-1, endRow)))
+	}
+	return ranges
+}
+
+// isRowWiderThanPage reports whether a row of the area has more cells than the page size.
+func isRowWiderThanPage(area string, pageSize int) bool {
+	startCol, _, endCol, _, err := ParseRange(area)
+	return err == nil && endCol-startCol+1 > pageSize
+}
+
+// labelColumnsOf returns the row label columns of the area where the range starts if pagesOf tiles it, or zeros.
+func labelColumnsOf(pageSize int, areas []string, rangeStr string) (int, int) {
+	col, row, _, _, err := ParseRange(rangeStr)
+	if err != nil {
+		return 0, 0
+	}
+	for _, area := range areas {
+		startCol, startRow, endCol, endRow, err := ParseRange(area)
+		if err != nil || col < startCol || endCol < col || row < startRow || endRow < row {
+			continue
+		}
+		if !isRowWiderThanPage(area, pageSize) {
+			return 0, 0
+		}
+		return (&TiledPagingStrategy{pageSize: pageSize, dimension: area}).LabelColumns()
+	}
+	return 0, 0
+}
+
+// newRegionPagingStrategy returns a RegionPagingStrategy if the worksheet has several data regions, or nil otherwise.
+// Regions are not detected if the used range fits in a page, which is read at once anyway.
+func newRegionPagingStrategy(pageSize int, worksheet Worksheet) (*RegionPagingStrategy, error) {
+	dimension, err := worksheet.GetDimention()
+	if err != nil {
+		return nil, err
+	}
+	startCol, startRow, endCol, endRow, err := ParseRange(dimension)
+	if err != nil {
+		return nil, err
+	}
+	if (endRow-startRow+1)*(endCol-startCol+1) <= pageSize {
+		return nil, nil
+	}
+	regions, err := DetectDataRegions(worksheet)
+	if err != nil {
+		return nil, err
+	}
+	if len(regions) < 2 {
+		return nil, nil
+	}
+	return NewRegionPagingStrategy(pageSize, regions), nil
+}
+
+// headerDetectionRows is the number of rows from the top of the used range where a header row is searched for
+const headerDetectionRows = 10
+
+// HeaderPagingStrategy pages with another strategy, and tells the header rows to repeat at the top of every page.
+// When the strategy pages by data regions, each region has its own header rows.
+type HeaderPagingStrategy struct {
+	strategy PagingStrategy
+	// headers are the header rows of the areas, which are the data regions or the used range
+	headers []areaHeader
+}
+
+// areaHeader is the header rows of an area
+type areaHeader struct {
+	area             string
+	startRow, endRow int
+}
+
+// NewHeaderPagingStrategy creates a strategy whose header rows are the headerRows rows from the top of the used range,
+// or of each data region. If headerRows is negative, the header row is detected from the values of the worksheet.
+func NewHeaderPagingStrategy(strategy PagingStrategy, worksheet Worksheet, headerRows int) (*HeaderPagingStrategy, error) {
+	var areas []string
+	if regionStrategy, ok := strategy.(*RegionPagingStrategy); ok {
+		for _, region := range regionStrategy.Regions() {
+			areas = append(areas, region.Range)
+		}
+	} else {
+		dimension, err := worksheet.GetDimention()
+		if err != nil {
+			return nil, err
+		}
+		areas = append(areas, dimension)
+	}
+	s := &HeaderPagingStrategy{strategy: strategy}
+	for _, area := range areas {
+		_, startRow, _, endRow, err := ParseRange(area)
+		if err != nil {
+			return nil, err
+		}
+		header := areaHeader{area: area}
+		switch {
+		case headerRows > 0:
+			header.startRow, header.endRow = startRow, min(startRow+headerRows-1, endRow)
+		case headerRows < 0:
+			row, err := DetectHeaderRow(worksheet, area)
+			if err != nil {
+				return nil, err
+			}
+			header.startRow, header.endRow = row, row
+		}
+		s.headers = append(s.headers, header)
+	}
+	return s, nil
+}
+
+func (s *HeaderPagingStrategy) CalculatePagingRanges() []string {
+	return s.strategy.CalculatePagingRanges()
+}
+
+// HeaderRowsOf returns the first and the last header rows of the area (the used range or a data region) where the
+// range starts, or zeros if the area has no header rows.
+func (s *HeaderPagingStrategy) HeaderRowsOf(rangeStr string) (int, int) {
+	col, row, _, _, err := ParseRange(rangeStr)
+	if err != nil {
+		return 0, 0
+	}
+	for _, header := range s.headers {
+		startCol, startRow, endCol, endRow, err := ParseRange(header.area)
+		if err == nil && startCol <= col && col <= endCol && startRow <= row && row <= endRow {
+			return header.startRow, header.endRow
+		}
+	}
+	return 0, 0
+}
+
+// LabelColumnsOf returns the row label columns of the wrapped strategy for the range, or zeros if it has none.
+// When the strategy pages by data regions or printed pages, only tiled ones have row labels.
+func (s *HeaderPagingStrategy) LabelColumnsOf(rangeStr string) (int, int) {
+	switch strategy := s.strategy.(type) {
+	case *TiledPagingStrategy:
+		return strategy.LabelColumns()
+	case *RegionPagingStrategy:
+		return strategy.LabelColumnsOf(rangeStr)
+	case *PrintAreaPagingStrategy:
+		return strategy.LabelColumnsOf(rangeStr)
+	}
+	return 0, 0
+}
+
+// Estimates of the output of excel_read_sheet in characters, calibrated against its HTML: metadata and notices of
+// a page, tags of a row with its number, tags of a cell, style references of a cell and definitions of a style.
+// A token is about charsPerToken ASCII characters or a single other character.
+const (
+	pageOverheadChars     = 800
+	rowOverheadChars      = 24
+	cellOverheadChars     = 9
+	styleReferenceChars   = 18
+	styleDefinitionsChars = 160
+	charsPerToken         = 4
+)
+
+// BudgetPagingStrategy splits pages of another strategy by rows, so that the estimated tokens of each rendered page,
+// including header rows repeated above it, fit in the budget. A row exceeding the budget alone is a page by itself.
+// Pages are split when they are read by Page, which reads only the rows of the page.
+type BudgetPagingStrategy struct {
+	strategy    PagingStrategy
+	worksheet   Worksheet
+	tokensLimit int
+}
+
+// NewBudgetPagingStrategy creates a strategy which splits pages of the strategy to fit in tokensLimit.
+func NewBudgetPagingStrategy(strategy PagingStrategy, worksheet Worksheet, tokensLimit int) *BudgetPagingStrategy {
+	return &BudgetPagingStrategy{
+		strategy:    strategy,
+		worksheet:   worksheet,
+		tokensLimit: tokensLimit,
+	}
+}
+
+// CalculatePagingRanges returns the pages of the strategy before they are split by Page.
+func (s *BudgetPagingStrategy) CalculatePagingRanges() []string {
+	return s.strategy.CalculatePagingRanges()
+}
+
+// Page returns the rows from the top of the range which fit in the budget, and the range to read next.
+// The range is split if it is a page of the strategy, or the rest of one below the rows read before.
+// Other ranges are returned as they are without the next range. withStyles estimates style references and definitions.
+func (s *BudgetPagingStrategy) Page(rangeStr string, withStyles bool) (string, string, error) {
+	startCol, startRow, endCol, endRow, err := ParseRange(rangeStr)
+	if err != nil {
+		// e.g. a multi-area range
+		return rangeStr, "", nil
+	}
+	ranges := s.strategy.CalculatePagingRanges()
+	index := slices.IndexFunc(ranges, func(pageRange string) bool {
+		pageStartCol, pageStartRow, pageEndCol, pageEndRow, err := ParseRange(pageRange)
+		return err == nil && pageStartCol == startCol && pageEndCol == endCol && pageStartRow <= startRow && pageEndRow == endRow
+	})
+	if index < 0 {
+		return rangeStr, "", nil
+	}
+	nextRange := ""
+	if index+1 < len(ranges) {
+		nextRange = ranges[index+1]
+	}
+
+	pageEndRow, err := s.fittingEndRow(startCol, startRow, endCol, endRow, withStyles)
+	if err != nil {
+		return "", "", err
+	}
+	if pageEndRow < endRow {
+		nextRange = formatPagingRange(startCol, pageEndRow+1, endCol, endRow)
+	}
+	return formatPagingRange(startCol, startRow, endCol, pageEndRow), nextRange, nil
+}
+
+// fittingEndRow returns the last of the rows from startRow which fit in the budget, at least startRow.
+// Only the rows which can fit and the next one are read.
+func (s *BudgetPagingStrategy) fittingEndRow(startCol, startRow, endCol, endRow int, withStyles bool) (int, error) {
+	styles := make(map[int]bool)
+	// the column headers of the table are a row as well
+	budget := s.tokensLimit*charsPerToken - pageOverheadChars - rowOverheadChars - (endCol-startCol+1)*(cellOverheadChars+1)
+	if headerStrategy, ok := s.strategy.(*HeaderPagingStrategy); ok {
+		headerStartRow, headerEndRow := headerStrategy.HeaderRowsOf(formatPagingRange(startCol, startRow, endCol, endRow))
+		if headerStartRow > 0 && headerStartRow < startRow {
+			headerCells, err := s.worksheet.GetRangeValues(formatPagingRange(startCol, headerStartRow, endCol, min(headerEndRow, startRow-1)))
+			if err != nil {
+				return 0, err
+			}
+			for _, row := range headerCells {
+				budget -= estimateRowChars(row, styles, withStyles)
+			}
+		}
+	}
+
+	// each row has at least its tags
+	maxRows := max(budget/(rowOverheadChars+(endCol-startCol+1)*cellOverheadChars), 1)
+	cells, err := s.worksheet.GetRangeValues(formatPagingRange(startCol, startRow, endCol, min(startRow+maxRows, endRow)))
+	if err != nil {
+		return 0, err
+	}
+	for i, row := range cells {
+		budget -= estimateRowChars(row, styles, withStyles)
+		if budget < 0 && i > 0 {
+			return startRow + i - 1, nil
+		}
+	}
+	return min(startRow+len(cells)-1, endRow), nil
+}
+
+// estimateRowChars estimates characters of the row rendered in a table. Styles not in styles yet add their definitions.
+func estimateRowChars(row []CellData, styles map[int]bool, withStyles bool) int {
+	chars := rowOverheadChars
+	for _, cell := range row {
+		chars += cellOverheadChars + max(estimateChars(cell.Value), estimateChars(cell.Formula))
+		if !withStyles {
+			continue
+		}
+		chars += styleReferenceChars
+		if !styles[cell.StyleID] {
+			styles[cell.StyleID] = true
+			chars += styleDefinitionsChars
+		}
+	}
+	return chars
+}
+
+// estimateChars estimates the text in ASCII characters, where another character counts as a token.
+func estimateChars(text string) int {
+	chars := 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			chars++
+		} else {
+			chars += charsPerToken
+		}
+	}
+	return chars
+}
+
+func formatPagingRange(startCol int, startRow int, endCol int, endRow int) string {
+	startRange, _ := excelize.CoordinatesToCellName(startCol, startRow)
+	endRange, _ := excelize.CoordinatesToCellName(endCol, endRow)
+	return fmt.Sprintf("%s:%s", startRange, endRange)
+}
+
+// DetectHeaderRow returns the header row near the top of the range, or 0 if it is not found.
+// A header row has only distinct texts, in at least half as many cells as any row below, and some of the rows below
+// have numbers or dates in the columns of the texts. Rows above it, such as a title, are not header rows.
+func DetectHeaderRow(worksheet Worksheet, rangeStr string) (int, error) {
+	startCol, startRow, endCol, endRow, err := ParseRange(rangeStr)
+	if err != nil {
+		return 0, err
+	}
+	if endRow == startRow {
+		return 0, nil
+	}
+	endRow = min(endRow, startRow+headerDetectionRows)
+	topLeft, _ := excelize.CoordinatesToCellName(startCol, startRow)
+	bottomRight, _ := excelize.CoordinatesToCellName(endCol, endRow)
+	cells, err := worksheet.GetRangeValues(topLeft + ":" + bottomRight)
+	if err != nil {
+		return 0, err
+	}
+	count := func(row []CellData) int {
+		n := 0
+		for _, cell := range row {
+			if cell.Value != "" {
+				n++
+			}
+		}
+		return n
+	}
+	for i := 0; i+1 < len(cells); i++ {
+		widest := 0
+		for _, row := range cells[i+1:] {
+			widest = max(widest, count(row))
+		}
+		texts := make(map[string]bool)
+		isHeader := count(cells[i]) > 0 && count(cells[i])*2 >= widest
+		for _, cell := range cells[i] {
+			if cell.Value == "" {
+				continue
+			}
+			if cell.Formula != "" || isNumberLike(cell.Value) || texts[cell.Value] {
+				isHeader = false
+				break
+			}
+			texts[cell.Value] = true
+		}
+		if !isHeader {
+			continue
+		}
+		for _, row := range cells[i+1:] {
+			for j, cell := range row {
+				if cells[i][j].Value != "" && isNumberLike(cell.Value) {
+					return startRow + i, nil
+				}
+			}
+		}
+	}
+	return 0, nil
+}
+
+// isNumberLike reports whether the formatted value is a number, a currency, a percentage, a date or a time.
+func isNumberLike(value string) bool {
+	value = strings.TrimSpace(value)
+	number := strings.TrimSuffix(strings.TrimLeft(strings.ReplaceAll(value, ",", ""), "$¥€£"), "%")
+	if _, err := strconv.ParseFloat(number, 64); err == nil {
+		return true
+	}
+	digits := false
+	for _, r := range value {
+		switch {
+		case '0' <= r && r <= '9':
+			digits = true
+		case !strings.ContainsRune("-/:. ", r):
+			return false
+		}
+	}
+	return digits
+}
 
 // PagingRangeService はページング処理を提供するサービス
 type PagingRangeService struct {
@@ -286,7 +991,8 @@ func (s *PagingRangeService) FilterRemainingPagingRanges(allRanges []string, kno
 	return remaining
 }
 
-// FindNextRange returns the next range in the sequence after the current range
+// FindNextRange returns the next range in the sequence after the current range.
+// Ranges are walked in the order the strategy lists them, e.g. tiles from left to right, then from top to bottom.
 func (s *PagingRangeService) FindNextRange(allRanges []string, currentRange string) string {
 	for i, r := range allRanges {
 		if r == currentRange && i+1 < len(allRanges) {
@@ -0,0 +1,460 @@
+package excel
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// newTestWorksheet returns a worksheet with the rows from A1, whose dimension is the used range.
+func newTestWorksheet(t *testing.T, rows [][]any) *ExcelizeWorksheet {
+	t.Helper()
+	file := excelize.NewFile()
+	t.Cleanup(func() { file.Close() })
+	endCol, endRow := 1, max(len(rows), 1)
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := file.SetSheetRow("Sheet1", cell, &row); err != nil {
+			t.Fatal(err)
+		}
+		endCol = max(endCol, len(row))
+	}
+	if err := file.SetSheetDimension("Sheet1", formatPagingRange(1, 1, endCol, endRow)); err != nil {
+		t.Fatal(err)
+	}
+	return &ExcelizeWorksheet{file: file, sheetName: "Sheet1"}
+}
+
+func TestDetectHeaderRow(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     [][]any
+		rangeStr string
+		want     int
+	}{
+		{
+			name:     "header with numbers below",
+			rows:     [][]any{{"Name", "Amount"}, {"Apple", 100}, {"Banana", 200}},
+			rangeStr: "A1:B3",
+			want:     1,
+		},
+		{
+			name:     "title above the header",
+			rows:     [][]any{{"Sales report"}, {"Name", "Region", "Amount"}, {"Apple", "East", 100}},
+			rangeStr: "A1:C3",
+			want:     2,
+		},
+		{
+			name:     "dates below",
+			rows:     [][]any{{"Date", "Event"}, {"2024-01-15", "Launch"}},
+			rangeStr: "A1:B2",
+			want:     1,
+		},
+		{
+			name:     "texts only",
+			rows:     [][]any{{"Name", "Region"}, {"Apple", "East"}, {"Banana", "West"}},
+			rangeStr: "A1:B3",
+			want:     0,
+		},
+		{
+			name:     "duplicate texts",
+			rows:     [][]any{{"Value", "Value"}, {1, 2}},
+			rangeStr: "A1:B2",
+			want:     0,
+		},
+		{
+			name:     "numbers in the top row",
+			rows:     [][]any{{2023, 2024}, {100, 200}},
+			rangeStr: "A1:B2",
+			want:     0,
+		},
+		{
+			name:     "single row",
+			rows:     [][]any{{"Name", "Amount"}},
+			rangeStr: "A1:B1",
+			want:     0,
+		},
+		{
+			name:     "range below the top",
+			rows:     [][]any{{"Notes"}, {}, {nil, "Item", "Price"}, {nil, "Pen", 1.5}},
+			rangeStr: "B3:C4",
+			want:     3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			worksheet := newTestWorksheet(t, tt.rows)
+			got, err := DetectHeaderRow(worksheet, tt.rangeStr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectHeaderRow(%q) = %d, want %d", tt.rangeStr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTiledPagingStrategy(t *testing.T) {
+	tests := []struct {
+		name          string
+		dimension     string
+		pageSize      int
+		want          []string
+		wantLabelCols [2]int
+	}{
+		{
+			name:          "wide sheet in even column blocks",
+			dimension:     "A1:T20",
+			pageSize:      100,
+			want:          []string{"A1:G12", "H1:N12", "O1:T12", "A13:G20", "H13:N20", "O13:T20"},
+			wantLabelCols: [2]int{1, 1},
+		},
+		{
+			name:          "narrow sheet in row blocks",
+			dimension:     "A1:C30",
+			pageSize:      100,
+			want:          []string{"A1:C25", "A26:C30"},
+			wantLabelCols: [2]int{1, 1},
+		},
+		{
+			name:          "used range off the top left",
+			dimension:     "B2:AA3",
+			pageSize:      20,
+			want:          []string{"B2:J3", "K2:S3", "T2:AA3"},
+			wantLabelCols: [2]int{2, 2},
+		},
+		{
+			name:          "sheet in a page",
+			dimension:     "B2:F3",
+			pageSize:      20,
+			want:          []string{"B2:F3"},
+			wantLabelCols: [2]int{2, 2},
+		},
+		{
+			name:          "page smaller than a row",
+			dimension:     "A1:B2",
+			pageSize:      1,
+			want:          []string{"A1:A1", "B1:B1", "A2:A2", "B2:B2"},
+			wantLabelCols: [2]int{1, 1},
+		},
+		{
+			name:      "invalid dimension",
+			dimension: "",
+			pageSize:  100,
+			want:      []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := &TiledPagingStrategy{pageSize: tt.pageSize, dimension: tt.dimension}
+			if got := strategy.CalculatePagingRanges(); !slices.Equal(got, tt.want) {
+				t.Errorf("CalculatePagingRanges() = %v, want %v", got, tt.want)
+			}
+			if startCol, endCol := strategy.LabelColumns(); startCol != tt.wantLabelCols[0] || endCol != tt.wantLabelCols[1] {
+				t.Errorf("LabelColumns() = %d, %d, want %d, %d", startCol, endCol, tt.wantLabelCols[0], tt.wantLabelCols[1])
+			}
+		})
+	}
+}
+
+func TestIsWideForPaging(t *testing.T) {
+	tests := []struct {
+		dimension string
+		pageSize  int
+		want      bool
+	}{
+		{dimension: "A1:J1000", pageSize: 100, want: false},
+		{dimension: "A1:K1000", pageSize: 100, want: true},
+		{dimension: "C1:L5", pageSize: 100, want: false},
+		// a wide range is not tiled if it fits in a page
+		{dimension: "A1:Z3", pageSize: 100, want: false},
+		{dimension: "A1:Z4", pageSize: 100, want: true},
+		{dimension: "A1:CW1", pageSize: 100, want: true},
+		{dimension: "", pageSize: 100, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dimension, func(t *testing.T) {
+			if got := IsWideForPaging(tt.dimension, tt.pageSize); got != tt.want {
+				t.Errorf("IsWideForPaging(%q, %d) = %v, want %v", tt.dimension, tt.pageSize, got, tt.want)
+			}
+		})
+	}
+}
+
+// rangesPagingStrategy is a strategy which returns the ranges as they are
+type rangesPagingStrategy []string
+
+func (s rangesPagingStrategy) CalculatePagingRanges() []string {
+	return s
+}
+
+func TestBudgetPagingStrategyPage(t *testing.T) {
+	// a row of two cells of a character is 24 + 2 * (9 + 1) = 44 characters, and 300 tokens leave
+	// 300 * 4 - 800 for the page - 44 for the column headers = 356 characters for 8 rows
+	rows := make([][]any, 30)
+	for i := range rows {
+		rows[i] = []any{"x", "y"}
+	}
+	longRows := slices.Clone(rows)
+	longRows[1] = []any{strings.Repeat("long ", 400), "y"}
+
+	tests := []struct {
+		name       string
+		rows       [][]any
+		rangeStr   string
+		headerRows int
+		withStyles bool
+		wantPage   string
+		wantNext   string
+	}{
+		{name: "first page", rows: rows, rangeStr: "A1:B20", wantPage: "A1:B8", wantNext: "A9:B20"},
+		{name: "rest of the page", rows: rows, rangeStr: "A9:B20", wantPage: "A9:B16", wantNext: "A17:B20"},
+		{name: "end of the page", rows: rows, rangeStr: "A17:B20", wantPage: "A17:B20", wantNext: "A21:B30"},
+		{name: "last page", rows: rows, rangeStr: "A21:B30", wantPage: "A21:B28", wantNext: "A29:B30"},
+		{name: "repeated header rows", rows: rows, rangeStr: "A9:B20", headerRows: 1, wantPage: "A9:B15", wantNext: "A16:B20"},
+		// the first style is defined with 160 characters, and each cell has 18 characters of its style references
+		{name: "with styles", rows: rows, rangeStr: "A1:B20", withStyles: true, wantPage: "A1:B2", wantNext: "A3:B20"},
+		{name: "row exceeding the budget", rows: longRows, rangeStr: "A2:B20", wantPage: "A2:B2", wantNext: "A3:B20"},
+		{name: "range across pages", rows: rows, rangeStr: "A5:B25", wantPage: "A5:B25", wantNext: ""},
+		{name: "multi-area range", rows: rows, rangeStr: "A1:B2,A5:B6", wantPage: "A1:B2,A5:B6", wantNext: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			worksheet := newTestWorksheet(t, tt.rows)
+			var strategy PagingStrategy = rangesPagingStrategy{"A1:B20", "A21:B30"}
+			if tt.headerRows > 0 {
+				headerStrategy, err := NewHeaderPagingStrategy(strategy, worksheet, tt.headerRows)
+				if err != nil {
+					t.Fatal(err)
+				}
+				strategy = headerStrategy
+			}
+			budgetStrategy := NewBudgetPagingStrategy(strategy, worksheet, 300)
+			page, next, err := budgetStrategy.Page(tt.rangeStr, tt.withStyles)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if page != tt.wantPage || next != tt.wantNext {
+				t.Errorf("Page(%q) = %q, %q, want %q, %q", tt.rangeStr, page, next, tt.wantPage, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestBudgetPagingStrategyPageError(t *testing.T) {
+	worksheet := newTestWorksheet(t, [][]any{{"x"}})
+	missing := &ExcelizeWorksheet{file: worksheet.file, sheetName: "Missing"}
+	budgetStrategy := NewBudgetPagingStrategy(rangesPagingStrategy{"A1:A1"}, missing, 300)
+	if page, next, err := budgetStrategy.Page("A1:A1", false); err == nil {
+		t.Errorf("Page() = %q, %q, want an error of the missing sheet", page, next)
+	}
+}
+
+func TestDetectDataRegions(t *testing.T) {
+	tests := []struct {
+		name   string
+		rows   [][]any
+		merges []string
+		want   []DataRegion
+	}{
+		{
+			name: "single region",
+			rows: [][]any{{"Name", "Qty"}, {"Pen", 3}},
+			want: []DataRegion{{Range: "A1:B2", Labels: []string{"Name", "Qty"}}},
+		},
+		{
+			name: "regions separated by a blank row",
+			rows: [][]any{{"Name", "Qty"}, {"Pen", 3}, {}, {"Total", 3}},
+			want: []DataRegion{
+				{Range: "A1:B2", Labels: []string{"Name", "Qty"}},
+				{Range: "A4:B4", Labels: []string{"Total", "3"}},
+			},
+		},
+		{
+			name: "regions separated by a blank column",
+			rows: [][]any{{"X", nil, "Y"}, {1, nil, 2}},
+			want: []DataRegion{
+				{Range: "A1:A2", Labels: []string{"X"}},
+				{Range: "C1:C2", Labels: []string{"Y"}},
+			},
+		},
+		{
+			name: "cells touching diagonally",
+			rows: [][]any{{"a", nil}, {nil, "b"}},
+			want: []DataRegion{{Range: "A1:B2", Labels: []string{"a"}}},
+		},
+		{
+			name: "overlapping rectangles",
+			rows: [][]any{{"a", nil, "c"}, {"a", nil, nil}, {"a", "a", "a"}},
+			want: []DataRegion{{Range: "A1:C3", Labels: []string{"a", "c"}}},
+		},
+		{
+			name:   "cells touching a merged cell",
+			rows:   [][]any{{"Title", nil, nil}, {nil, nil, "x"}},
+			merges: []string{"A1:C1"},
+			want:   []DataRegion{{Range: "A1:C2", Labels: []string{"Title", "Title", "Title"}}},
+		},
+		{
+			name: "empty sheet",
+			want: []DataRegion{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			worksheet := newTestWorksheet(t, tt.rows)
+			for _, merge := range tt.merges {
+				cells := strings.Split(merge, ":")
+				if err := worksheet.file.MergeCell("Sheet1", cells[0], cells[1]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := DetectDataRegions(worksheet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.EqualFunc(got, tt.want, func(a, b DataRegion) bool {
+				return a.Range == b.Range && slices.Equal(a.Labels, b.Labels)
+			}) {
+				t.Errorf("DetectDataRegions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRegionPagingStrategy(t *testing.T) {
+	worksheet := newTestWorksheet(t, [][]any{{"Name", "Qty"}, {"Pen", 3}, {}, {"Total", 3}})
+
+	// a sheet in a page is read at once without detecting regions
+	strategy, err := newRegionPagingStrategy(8, worksheet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strategy != nil || worksheet.regions.detected {
+		t.Fatalf("newRegionPagingStrategy(8) = %v, detected %v, want nil without detection", strategy, worksheet.regions.detected)
+	}
+
+	strategy, err = newRegionPagingStrategy(4, worksheet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"A1:B2", "A4:B4"}
+	if strategy == nil || !slices.Equal(strategy.CalculatePagingRanges(), want) {
+		t.Fatalf("newRegionPagingStrategy(4) = %v, want pages %v", strategy, want)
+	}
+
+	// detected regions are reused until values are written
+	if !worksheet.regions.detected {
+		t.Fatal("regions are not kept")
+	}
+	if err := worksheet.SetValue("A3", "Ink"); err != nil {
+		t.Fatal(err)
+	}
+	regions, err := DetectDataRegions(worksheet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(regions) != 1 || regions[0].Range != "A1:B4" {
+		t.Errorf("DetectDataRegions() after writing = %v, want A1:B4", regions)
+	}
+}
+
+func TestRegionPagingStrategy(t *testing.T) {
+	strategy := NewRegionPagingStrategy(20, []DataRegion{{Range: "A1:B30"}, {Range: "D1:F4"}, {Range: "H1:AC2"}})
+	want := []string{"A1:B10", "A11:B20", "A21:B30", "D1:F4", "H1:O2", "P1:W2", "X1:AC2"}
+	if got := strategy.CalculatePagingRanges(); !slices.Equal(got, want) {
+		t.Errorf("CalculatePagingRanges() = %v, want %v", got, want)
+	}
+
+	// only the wide region is tiled with its first column as row labels
+	tests := []struct {
+		rangeStr      string
+		wantLabelCols [2]int
+	}{
+		{rangeStr: "P1:W2", wantLabelCols: [2]int{8, 8}},
+		{rangeStr: "H1:O2", wantLabelCols: [2]int{8, 8}},
+		{rangeStr: "D1:F4", wantLabelCols: [2]int{0, 0}},
+		{rangeStr: "A11:B20", wantLabelCols: [2]int{0, 0}},
+		{rangeStr: "Z9", wantLabelCols: [2]int{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rangeStr, func(t *testing.T) {
+			if startCol, endCol := strategy.LabelColumnsOf(tt.rangeStr); startCol != tt.wantLabelCols[0] || endCol != tt.wantLabelCols[1] {
+				t.Errorf("LabelColumnsOf(%q) = %d, %d, want %d, %d", tt.rangeStr, startCol, endCol, tt.wantLabelCols[0], tt.wantLabelCols[1])
+			}
+		})
+	}
+}
+
+func TestCalculateRangesFromBreaks(t *testing.T) {
+	tests := []struct {
+		name      string
+		printArea string
+		breaks    []int
+		pageSize  int
+		want      []string
+	}{
+		{
+			name:      "pages at breaks",
+			printArea: "$A$1:$C$30",
+			breaks:    []int{11, 21},
+			pageSize:  4000,
+			want:      []string{"A1:C10", "A11:C20", "A21:C30"},
+		},
+		{
+			name:      "no breaks",
+			printArea: "$A$1:$C$30",
+			pageSize:  4000,
+			want:      []string{"A1:C30"},
+		},
+		{
+			name:      "breaks out of the print area",
+			printArea: "$A$5:$C$30",
+			breaks:    []int{3, 5, 40},
+			pageSize:  4000,
+			want:      []string{"A5:C30"},
+		},
+		{
+			name:      "page larger than the page size",
+			printArea: "$A$1:$C$3000",
+			breaks:    []int{101},
+			pageSize:  4000,
+			want:      []string{"A1:C100", "A101:C1433", "A1434:C2766", "A2767:C3000"},
+		},
+		{
+			name:      "wide page split by rows",
+			printArea: "$A$1:$F$4",
+			pageSize:  20,
+			want:      []string{"A1:F3", "A4:F4"},
+		},
+		{
+			name:      "page with a row larger than the page size in tiles",
+			printArea: "$A$1:$Y$2",
+			breaks:    []int{2},
+			pageSize:  20,
+			want:      []string{"A1:M1", "N1:Y1", "A2:M2", "N2:Y2"},
+		},
+		{
+			name:      "multiple areas",
+			printArea: "$A$1:$B$10,$D$1:$E$10",
+			breaks:    []int{6},
+			pageSize:  4000,
+			want:      []string{"A1:B5", "A6:B10", "D1:E5", "D6:E10"},
+		},
+		{
+			name:     "no print area",
+			pageSize: 4000,
+			want:     []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := &PrintAreaPagingStrategy{pageSize: tt.pageSize}
+			if got := strategy.calculateRangesFromBreaks(tt.printArea, tt.breaks); !slices.Equal(got, tt.want) {
+				t.Errorf("calculateRangesFromBreaks(%q, %v) = %v, want %v", tt.printArea, tt.breaks, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,652 @@
+package excel
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	// maxRenderSide and maxRenderPixels limit the size of the rendered image
+	maxRenderSide   = 8192
+	maxRenderPixels = 16 * 1024 * 1024
+	// cellPadding is the horizontal space between the cell border and the text in pixels
+	cellPadding     = 3
+	defaultFontSize = 11.0
+)
+
+var (
+	gridLineColor     = color.RGBA{0xD9, 0xD9, 0xD9, 0xFF}
+	defaultTextColor  = color.RGBA{0x00, 0x00, 0x00, 0xFF}
+	defaultBackground = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+)
+
+// goFonts are the fonts used to draw text, indexed by bold and italic.
+// Fonts of the workbook are not available, so the Go fonts substitute them.
+var goFonts = sync.OnceValues(func() (map[[2]bool]*opentype.Font, error) {
+	fonts := map[[2]bool]*opentype.Font{}
+	for key, ttf := range map[[2]bool][]byte{
+		{false, false}: goregular.TTF,
+		{true, false}:  gobold.TTF,
+		{false, true}:  goitalic.TTF,
+		{true, true}:   gobolditalic.TTF,
+	} {
+		f, err := opentype.Parse(ttf)
+		if err != nil {
+			return nil, err
+		}
+		fonts[key] = f
+	}
+	return fonts, nil
+})
+
+// rangeRenderer draws a range of a worksheet to an image, approximating how Excel shows it on screen.
+// It uses values, column widths, row heights, merged cells, fonts, fills and borders.
+type rangeRenderer struct {
+	file      *excelize.File
+	sheetName string
+	startCol  int
+	startRow  int
+	endCol    int
+	endRow    int
+	// colX and rowY are the pixel offsets of the columns and rows from the range origin, with one extra entry for the end
+	colX   []int
+	rowY   []int
+	values [][]string
+	// merged maps the cells in the range to the intersection of their merged cell with the range,
+	// and anchors to the top-left cell of the merged cell
+	merged  map[[2]int][4]int
+	anchors map[[2]int][2]int
+	styles  map[int]*excelize.Style
+	faces   map[renderFontKey]font.Face
+	img     *image.RGBA
+}
+
+type renderFontKey struct {
+	bold   bool
+	italic bool
+	size   float64
+}
+
+// renderRange renders the range of the worksheet to PNG.
+func renderRange(file *excelize.File, sheetName string, rangeStr string) ([]byte, error) {
+	startCol, startRow, endCol, endRow, err := ParseRange(rangeStr)
+	if err != nil {
+		return nil, err
+	}
+	r := &rangeRenderer{
+		file:      file,
+		sheetName: sheetName,
+		startCol:  startCol,
+		startRow:  startRow,
+		endCol:    endCol,
+		endRow:    endRow,
+		merged:    map[[2]int][4]int{},
+		anchors:   map[[2]int][2]int{},
+		styles:    map[int]*excelize.Style{},
+		faces:     map[renderFontKey]font.Face{},
+	}
+	defer func() {
+		for _, face := range r.faces {
+			face.Close()
+		}
+	}()
+	if err := r.measure(); err != nil {
+		return nil, err
+	}
+	if err := r.loadCells(); err != nil {
+		return nil, err
+	}
+	if err := r.draw(); err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, r.img); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// measure computes the pixel offsets of columns and rows. Hidden columns and rows have no width.
+func (r *rangeRenderer) measure() error {
+	r.colX = []int{0}
+	for col := r.startCol; col <= r.endCol; col++ {
+		name, err := excelize.ColumnNumberToName(col)
+		if err != nil {
+			return err
+		}
+		width := 0
+		visible, err := r.file.GetColVisible(r.sheetName, name)
+		if err != nil {
+			return err
+		}
+		if visible {
+			colWidth, err := r.file.GetColWidth(r.sheetName, name)
+			if err != nil {
+				return err
+			}
+			// width in characters of the maximum digit width (7 pixels in the default font) to pixels
+			width = int(math.Trunc((256*colWidth + math.Trunc(128.0/7)) / 256 * 7))
+		}
+		r.colX = append(r.colX, r.colX[len(r.colX)-1]+width)
+	}
+	r.rowY = []int{0}
+	// rows without row element are shown with the default height
+	worksheet := &ExcelizeWorksheet{file: r.file, sheetName: r.sheetName}
+	for row := r.startRow; row <= r.endRow; row++ {
+		height := 0
+		hidden, err := worksheet.IsRowHidden(row)
+		if err != nil {
+			return err
+		}
+		if !hidden {
+			rowHeight, err := r.file.GetRowHeight(r.sheetName, row)
+			if err != nil {
+				return err
+			}
+			// points to pixels at 96 DPI
+			height = int(math.Round(rowHeight * 96 / 72))
+		}
+		r.rowY = append(r.rowY, r.rowY[len(r.rowY)-1]+height)
+	}
+	width, height := r.colX[len(r.colX)-1], r.rowY[len(r.rowY)-1]
+	if width == 0 || height == 0 {
+		return fmt.Errorf("range has no visible cells: %s", r.rangeString())
+	}
+	if width > maxRenderSide || height > maxRenderSide || width*height > maxRenderPixels {
+		return fmt.Errorf("range is too large to render (%dx%d pixels), specify a smaller range", width, height)
+	}
+	r.img = image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(r.img, r.img.Bounds(), image.NewUniform(defaultBackground), image.Point{}, draw.Src)
+	return nil
+}
+
+// loadCells reads the merged cells and the formatted values in the range.
+func (r *rangeRenderer) loadCells() error {
+	mergeCells, err := r.file.GetMergeCells(r.sheetName)
+	if err != nil {
+		return err
+	}
+	for _, mergeCell := range mergeCells {
+		c1, r1, c2, r2, err := ParseRange(mergeCell.GetStartAxis() + ":" + mergeCell.GetEndAxis())
+		if err != nil {
+			continue
+		}
+		ic1, ir1, ic2, ir2 := max(c1, r.startCol), max(r1, r.startRow), min(c2, r.endCol), min(r2, r.endRow)
+		if ic1 > ic2 || ir1 > ir2 {
+			continue
+		}
+		for row := ir1; row <= ir2; row++ {
+			for col := ic1; col <= ic2; col++ {
+				r.merged[[2]int{col, row}] = [4]int{ic1, ir1, ic2, ir2}
+				r.anchors[[2]int{col, row}] = [2]int{c1, r1}
+			}
+		}
+	}
+
+	r.values = make([][]string, r.endRow-r.startRow+1)
+	for row := r.startRow; row <= r.endRow; row++ {
+		r.values[row-r.startRow] = make([]string, r.endCol-r.startCol+1)
+		for col := r.startCol; col <= r.endCol; col++ {
+			cell, err := excelize.CoordinatesToCellName(col, row)
+			if err != nil {
+				return err
+			}
+			value, err := r.file.GetCellValue(r.sheetName, cell)
+			if err != nil {
+				return err
+			}
+			r.values[row-r.startRow][col-r.startCol] = value
+		}
+	}
+	return nil
+}
+
+// renderUnit is a cell, or the part of a merged cell in the range
+type renderUnit struct {
+	// col and row are the cell whose value and style are drawn
+	col, row int
+	rect     image.Rectangle
+	// startCol and startRow are the top-left cell of the unit in the range
+	startCol, startRow int
+	endCol, endRow     int
+}
+
+// units returns the cells and merged cells in the range to draw, in order of rows
+func (r *rangeRenderer) units() []renderUnit {
+	var units []renderUnit
+	for row := r.startRow; row <= r.endRow; row++ {
+		for col := r.startCol; col <= r.endCol; col++ {
+			unit := renderUnit{col: col, row: row, startCol: col, startRow: row, endCol: col, endRow: row}
+			if area, ok := r.merged[[2]int{col, row}]; ok {
+				if area[0] != col || area[1] != row {
+					continue
+				}
+				anchor := r.anchors[[2]int{col, row}]
+				unit.col, unit.row = anchor[0], anchor[1]
+				unit.endCol, unit.endRow = area[2], area[3]
+			}
+			unit.rect = image.Rect(
+				r.colX[unit.startCol-r.startCol], r.rowY[unit.startRow-r.startRow],
+				r.colX[unit.endCol-r.startCol+1], r.rowY[unit.endRow-r.startRow+1],
+			)
+			if unit.rect.Empty() {
+				continue
+			}
+			units = append(units, unit)
+		}
+	}
+	return units
+}
+
+func (r *rangeRenderer) draw() error {
+	showGridLines := true
+	if view, err := r.file.GetSheetView(r.sheetName, 0); err == nil && view.ShowGridLines != nil {
+		showGridLines = *view.ShowGridLines
+	}
+	units := r.units()
+	styles := make([]*excelize.Style, len(units))
+	for i, unit := range units {
+		style, err := r.getStyle(unit.col, unit.row)
+		if err != nil {
+			return err
+		}
+		styles[i] = style
+	}
+
+	// backgrounds first, as text may overflow to the neighbors
+	for i, unit := range units {
+		if fill, ok := r.fillColor(styles[i]); ok {
+			draw.Draw(r.img, unit.rect, image.NewUniform(fill), image.Point{}, draw.Src)
+		} else if showGridLines {
+			r.hLine(unit.rect.Min.X, unit.rect.Max.X, unit.rect.Max.Y-1, 1, gridLineColor, nil)
+			r.vLine(unit.rect.Max.X-1, unit.rect.Min.Y, unit.rect.Max.Y, 1, gridLineColor, nil)
+		}
+	}
+	for i, unit := range units {
+		if err := r.drawText(unit, styles[i]); err != nil {
+			return err
+		}
+	}
+	for i, unit := range units {
+		r.drawBorders(unit, styles[i])
+	}
+	return nil
+}
+
+func (r *rangeRenderer) getStyle(col, row int) (*excelize.Style, error) {
+	cell, err := excelize.CoordinatesToCellName(col, row)
+	if err != nil {
+		return nil, err
+	}
+	styleID, err := r.file.GetCellStyle(r.sheetName, cell)
+	if err != nil {
+		return nil, err
+	}
+	if style, ok := r.styles[styleID]; ok {
+		return style, nil
+	}
+	style, err := r.file.GetStyle(styleID)
+	if err != nil {
+		return nil, err
+	}
+	r.styles[styleID] = style
+	return style, nil
+}
+
+// value returns the formatted value of the cell, which may be outside the range for merged cells
+func (r *rangeRenderer) value(col, row int) (string, error) {
+	if r.startCol <= col && col <= r.endCol && r.startRow <= row && row <= r.endRow {
+		return r.values[row-r.startRow][col-r.startCol], nil
+	}
+	cell, err := excelize.CoordinatesToCellName(col, row)
+	if err != nil {
+		return "", err
+	}
+	return r.file.GetCellValue(r.sheetName, cell)
+}
+
+// isEmpty reports whether the cell in the range has no value and is not merged, so that text can overflow into it
+func (r *rangeRenderer) isEmpty(col, row int) bool {
+	if col < r.startCol || col > r.endCol {
+		return false
+	}
+	if _, ok := r.merged[[2]int{col, row}]; ok {
+		return false
+	}
+	return r.values[row-r.startRow][col-r.startCol] == ""
+}
+
+func (r *rangeRenderer) drawText(unit renderUnit, style *excelize.Style) error {
+	value, err := r.value(unit.col, unit.row)
+	if err != nil || value == "" {
+		return err
+	}
+	cell, err := excelize.CoordinatesToCellName(unit.col, unit.row)
+	if err != nil {
+		return err
+	}
+	cellType, err := r.file.GetCellType(r.sheetName, cell)
+	if err != nil {
+		return err
+	}
+	numeric := false
+	if cellType != excelize.CellTypeSharedString && cellType != excelize.CellTypeInlineString && cellType != excelize.CellTypeBool {
+		raw, err := r.file.GetCellValue(r.sheetName, cell, excelize.Options{RawCellValue: true})
+		if err != nil {
+			return err
+		}
+		_, parseErr := strconv.ParseFloat(raw, 64)
+		numeric = parseErr == nil
+	}
+
+	face, err := r.face(style)
+	if err != nil {
+		return err
+	}
+	textColor := defaultTextColor
+	if style.Font != nil {
+		if c, ok := r.color(style.Font.Color, style.Font.ColorIndexed, style.Font.ColorTheme, style.Font.ColorTint); ok {
+			textColor = c
+		}
+	}
+	alignment := style.Alignment
+	if alignment == nil {
+		alignment = &excelize.Alignment{}
+	}
+	horizontal := alignment.Horizontal
+	switch horizontal {
+	case "left", "right", "center":
+	case "centerContinuous", "distributed":
+		horizontal = "center"
+	case "", "general":
+		switch {
+		case numeric:
+			horizontal = "right"
+		case cellType == excelize.CellTypeBool:
+			horizontal = "center"
+		default:
+			horizontal = "left"
+		}
+	default:
+		horizontal = "left"
+	}
+	indent := alignment.Indent * 9
+
+	available := unit.rect.Dx() - 2*cellPadding - indent
+	var lines []string
+	if alignment.WrapText {
+		lines = wrapText(face, value, available)
+	} else {
+		line := strings.ReplaceAll(value, "\n", " ")
+		if numeric && font.MeasureString(face, line).Ceil() > available {
+			// Excel shows # for numbers which do not fit
+			hash := font.MeasureString(face, "#").Ceil()
+			line = strings.Repeat("#", max(available/max(hash, 1), 1))
+		}
+		lines = []string{line}
+	}
+
+	// text which is not wrapped overflows into empty neighbors
+	clip := unit.rect
+	if !alignment.WrapText && !numeric && unit.startCol == unit.endCol {
+		width := font.MeasureString(face, lines[0]).Ceil() + 2*cellPadding + indent
+		row := unit.startRow
+		if horizontal == "left" || horizontal == "center" {
+			for col := unit.endCol + 1; clip.Dx() < width && r.isEmpty(col, row); col++ {
+				clip.Max.X = r.colX[col-r.startCol+1]
+			}
+		}
+		if horizontal == "right" || horizontal == "center" {
+			for col := unit.startCol - 1; clip.Dx() < width && r.isEmpty(col, row); col-- {
+				clip.Min.X = r.colX[col-r.startCol]
+			}
+		}
+	}
+
+	metrics := face.Metrics()
+	lineHeight := metrics.Height.Ceil()
+	ascent := metrics.Ascent.Ceil()
+	textHeight := lineHeight * len(lines)
+	var y int
+	switch alignment.Vertical {
+	case "top":
+		y = unit.rect.Min.Y + 1
+	case "center", "distributed", "justify":
+		y = unit.rect.Min.Y + (unit.rect.Dy()-textHeight)/2
+	default:
+		y = unit.rect.Max.Y - textHeight - 1
+	}
+
+	dst, ok := r.img.SubImage(clip).(*image.RGBA)
+	if !ok {
+		return nil
+	}
+	drawer := &font.Drawer{Dst: dst, Src: image.NewUniform(textColor), Face: face}
+	for i, line := range lines {
+		lineWidth := font.MeasureString(face, line).Ceil()
+		var x int
+		switch horizontal {
+		case "right":
+			x = unit.rect.Max.X - cellPadding - indent - lineWidth
+		case "center":
+			x = unit.rect.Min.X + (unit.rect.Dx()-lineWidth)/2
+		default:
+			x = unit.rect.Min.X + cellPadding + indent
+		}
+		baseline := y + i*lineHeight + ascent
+		drawer.Dot = fixed.P(x, baseline)
+		drawer.DrawString(line)
+		if style.Font != nil && style.Font.Underline != "" && style.Font.Underline != "none" {
+			drawRect(dst, image.Rect(x, baseline+2, x+lineWidth, baseline+3), textColor)
+		}
+		if style.Font != nil && style.Font.Strike {
+			drawRect(dst, image.Rect(x, baseline-ascent/3, x+lineWidth, baseline-ascent/3+1), textColor)
+		}
+	}
+	return nil
+}
+
+// wrapText splits text into lines which fit in the width, breaking at spaces where possible.
+func wrapText(face font.Face, text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Split(paragraph, " ") {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if font.MeasureString(face, candidate).Ceil() <= width || line == "" && len([]rune(word)) <= 1 {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			// break a word longer than the width by characters
+			line = ""
+			for _, ch := range word {
+				if line != "" && font.MeasureString(face, line+string(ch)).Ceil() > width {
+					lines = append(lines, line)
+					line = ""
+				}
+				line += string(ch)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (r *rangeRenderer) face(style *excelize.Style) (font.Face, error) {
+	key := renderFontKey{size: defaultFontSize}
+	if style.Font != nil {
+		key.bold, key.italic = style.Font.Bold, style.Font.Italic
+		if style.Font.Size > 0 {
+			key.size = style.Font.Size
+		}
+	}
+	if face, ok := r.faces[key]; ok {
+		return face, nil
+	}
+	fonts, err := goFonts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fonts: %w", err)
+	}
+	face, err := opentype.NewFace(fonts[[2]bool{key.bold, key.italic}], &opentype.FaceOptions{
+		Size:    key.size,
+		DPI:     96,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.faces[key] = face
+	return face, nil
+}
+
+func (r *rangeRenderer) fillColor(style *excelize.Style) (color.RGBA, bool) {
+	if style.Fill.Type == "pattern" && style.Fill.Pattern == 0 {
+		return color.RGBA{}, false
+	}
+	if len(style.Fill.Color) == 0 {
+		return color.RGBA{}, false
+	}
+	return parseHexColor(style.Fill.Color[0])
+}
+
+// color resolves a font color which may be an indexed or theme color
+func (r *rangeRenderer) color(hexColor string, indexed int, theme *int, tint float64) (color.RGBA, bool) {
+	if hexColor == "" && theme == nil && indexed == 0 {
+		return color.RGBA{}, false
+	}
+	base := r.file.GetBaseColor(strings.TrimPrefix(hexColor, "#"), indexed, theme)
+	if tint != 0 {
+		base = excelize.ThemeColor(base, tint)
+	}
+	return parseHexColor(base)
+}
+
+func (r *rangeRenderer) drawBorders(unit renderUnit, style *excelize.Style) {
+	for _, border := range style.Border {
+		if border.Style == 0 {
+			continue
+		}
+		c, ok := parseHexColor(border.Color)
+		if !ok {
+			c = defaultTextColor
+		}
+		thickness, pattern := borderLineStyle(border.Style)
+		rect := unit.rect
+		switch border.Type {
+		case "left":
+			r.vLine(max(rect.Min.X-1, 0), rect.Min.Y, rect.Max.Y, thickness, c, pattern)
+		case "right":
+			r.vLine(rect.Max.X-1, rect.Min.Y, rect.Max.Y, thickness, c, pattern)
+		case "top":
+			r.hLine(rect.Min.X, rect.Max.X, max(rect.Min.Y-1, 0), thickness, c, pattern)
+		case "bottom":
+			r.hLine(rect.Min.X, rect.Max.X, rect.Max.Y-1, thickness, c, pattern)
+		}
+	}
+}
+
+// borderLineStyle returns the thickness in pixels and the dash pattern (lengths of on and off) of excelize border styles.
+// Double lines are drawn as a thick line.
+func borderLineStyle(style int) (int, []int) {
+	switch style {
+	case 2:
+		return 2, nil // medium
+	case 3:
+		return 1, []int{3, 1} // dashed
+	case 4:
+		return 1, []int{1, 1} // dotted
+	case 5, 6:
+		return 3, nil // thick, double
+	case 7:
+		return 1, []int{1, 1} // hair
+	case 8:
+		return 2, []int{6, 2} // medium dashed
+	case 9, 11:
+		return 1, []int{6, 2, 2, 2} // dash dot, dash dot dot
+	case 10, 12, 13:
+		return 2, []int{6, 2, 2, 2} // medium dash dot, medium dash dot dot, slant dash dot
+	default:
+		return 1, nil // thin
+	}
+}
+
+// hLine draws a horizontal line centered on y
+func (r *rangeRenderer) hLine(x1, x2, y, thickness int, c color.RGBA, pattern []int) {
+	top := y - (thickness-1)/2
+	for _, segment := range dashSegments(x1, x2, pattern) {
+		drawRect(r.img, image.Rect(segment[0], top, segment[1], top+thickness), c)
+	}
+}
+
+// vLine draws a vertical line centered on x
+func (r *rangeRenderer) vLine(x, y1, y2, thickness int, c color.RGBA, pattern []int) {
+	left := x - (thickness-1)/2
+	for _, segment := range dashSegments(y1, y2, pattern) {
+		drawRect(r.img, image.Rect(left, segment[0], left+thickness, segment[1]), c)
+	}
+}
+
+// dashSegments splits [start, end) into the drawn segments of the dash pattern
+func dashSegments(start, end int, pattern []int) [][2]int {
+	if len(pattern) == 0 {
+		return [][2]int{{start, end}}
+	}
+	var segments [][2]int
+	for pos, i := start, 0; pos < end; i++ {
+		length := pattern[i%len(pattern)]
+		if i%2 == 0 {
+			segments = append(segments, [2]int{pos, min(pos+length, end)})
+		}
+		pos += length
+	}
+	return segments
+}
+
+func drawRect(dst *image.RGBA, rect image.Rectangle, c color.RGBA) {
+	draw.Draw(dst, rect.Intersect(dst.Bounds()), image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// parseHexColor parses colors such as "#FF0000", "FF0000" and "FFFF0000" (ARGB)
+func parseHexColor(hexColor string) (color.RGBA, bool) {
+	hexColor = strings.TrimPrefix(hexColor, "#")
+	if len(hexColor) == 8 {
+		hexColor = hexColor[2:]
+	}
+	if len(hexColor) != 6 {
+		return color.RGBA{}, false
+	}
+	value, err := strconv.ParseUint(hexColor, 16, 32)
+	if err != nil {
+		return color.RGBA{}, false
+	}
+	return color.RGBA{uint8(value >> 16), uint8(value >> 8), uint8(value), 0xFF}, true
+}
+
+func (r *rangeRenderer) rangeString() string {
+	startCell, _ := excelize.CoordinatesToCellName(r.startCol, r.startRow)
+	endCell, _ := excelize.CoordinatesToCellName(r.endCol, r.endRow)
+	return startCell + ":" + endCell
+}
@@ -0,0 +1,89 @@
+package excel
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestRenderRange(t *testing.T) {
+	// default column width (8.43 characters) and row height (15 points) in pixels
+	const colWidth, rowHeight = 64, 20
+	tests := []struct {
+		name       string
+		rangeStr   string
+		wantWidth  int
+		wantHeight int
+		wantErr    bool
+	}{
+		// the hidden row 3 has no height
+		{name: "merged, styled and wrapped cells", rangeStr: "A1:C4", wantWidth: 3 * colWidth, wantHeight: 3 * rowHeight},
+		{name: "trailing rows", rangeStr: "A1:A6", wantWidth: colWidth, wantHeight: 5 * rowHeight},
+		{name: "empty cell below the data", rangeStr: "E10", wantWidth: colWidth, wantHeight: rowHeight},
+		{name: "empty range below the data", rangeStr: "A10:B12", wantWidth: 2 * colWidth, wantHeight: 3 * rowHeight},
+		{name: "hidden row", rangeStr: "A3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := excelize.NewFile()
+			defer file.Close()
+			values := map[string]any{"A1": "Title", "A2": "long text which wraps in the cell", "B2": 12.5, "C2": true, "A4": "last"}
+			for cell, value := range values {
+				if err := file.SetCellValue("Sheet1", cell, value); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := file.MergeCell("Sheet1", "A1", "C1"); err != nil {
+				t.Fatal(err)
+			}
+			title, err := file.NewStyle(&excelize.Style{
+				Font:      &excelize.Font{Bold: true, Size: 14, Color: "FFFFFF"},
+				Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4472C4"}},
+				Alignment: &excelize.Alignment{Horizontal: "center"},
+				Border:    []excelize.Border{{Type: "bottom", Color: "000000", Style: 2}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := file.SetCellStyle("Sheet1", "A1", "C1", title); err != nil {
+				t.Fatal(err)
+			}
+			wrap, err := file.NewStyle(&excelize.Style{Alignment: &excelize.Alignment{WrapText: true, Vertical: "top"}, NumFmt: 2})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := file.SetCellStyle("Sheet1", "A2", "B2", wrap); err != nil {
+				t.Fatal(err)
+			}
+			if err := file.SetRowVisible("Sheet1", 3, false); err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := renderRange(file, "Sheet1", tt.rangeStr)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("renderRange() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			img, err := png.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bounds := img.Bounds(); bounds.Dx() != tt.wantWidth || bounds.Dy() != tt.wantHeight {
+				t.Errorf("image size = %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), tt.wantWidth, tt.wantHeight)
+			}
+			if tt.rangeStr == "A1:C4" {
+				// the fill of the merged title
+				if r, g, b, _ := img.At(5, 5).RGBA(); r>>8 != 0x44 || g>>8 != 0x72 || b>>8 != 0xC4 {
+					t.Errorf("color of the title = %02X%02X%02X, want 4472C4", r>>8, g>>8, b>>8)
+				}
+			}
+		})
+	}
+}
@@ -1,36 +1,155 @@
 package excel
 
 import (
+	"cmp"
 	"fmt"
 	"os"
 	"path"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/xuri/excelize/v2"
 )
 
-// parseRange parses Excel's range string (e.g. A1:C10 or A1)
+var (
+	a1RangeRegexp     = regexp.MustCompile(`^(\$?[A-Z]+\$?\d+)(?::(\$?[A-Z]+\$?\d+))?$`)
+	r1c1RangeRegexp   = regexp.MustCompile(`^R(\d+)C(\d+)(?::R(\d+)C(\d+))?$`)
+	wholeColumnRegexp = regexp.MustCompile(`^\$?([A-Z]+):\$?([A-Z]+)$`)
+	wholeRowRegexp    = regexp.MustCompile(`^\$?(\d+):\$?(\d+)$`)
+)
+
+// ParseRange parses Excel's range string and returns its start and end coordinates.
+// The following formats are accepted, case-insensitively:
+//   - A1 reference: A1, A1:C10, $A$1:$C$10
+//   - R1C1 reference: R1C1, R1C1:R10C3
+//   - whole columns or rows: A:C, 3:5 (spanning the whole sheet, see ClipRange)
+//
+// Sheet-qualified references (e.g. Sheet1!A1:C10) are rejected, since the sheet would be ignored.
+// Use ParseSheetRange for them. Multi-area ranges (e.g. A1:B2,D1:E2) must be split with SplitRangeAreas beforehand.
 func ParseRange(rangeStr string) (int, int, int, int, error) {
-	re := regexp.MustCompile(`^(\$?[A-Z]+\$?\d+)(?::(\$?[A-Z]+\$?\d+))?$`)
-	matches := re.FindStringSubmatch(rangeStr)
-	if matches == nil {
-		return 0, 0, 0, 0, fmt.Errorf("invalid range format: %s", rangeStr)
-	}
-	startCol, startRow, err := excelize.CellNameToCoordinates(matches[1])
+	sheetName, startCol, startRow, endCol, endRow, err := ParseSheetRange(rangeStr)
 	if err != nil {
 		return 0, 0, 0, 0, err
 	}
-
-	if matches[2] == "" {
-		// Single cell case
-		return startCol, startRow, startCol, startRow, nil
+	if sheetName != "" {
+		return 0, 0, 0, 0, fmt.Errorf("sheet-qualified range is not supported: %s", rangeStr)
 	}
+	return startCol, startRow, endCol, endRow, nil
+}
 
-	endCol, endRow, err := excelize.CellNameToCoordinates(matches[2])
+// ParseSheetRange parses a range string as ParseRange does, which may also be qualified with a sheet name
+// (e.g. Sheet1!A1:C10 or 'My Sheet'!A1:C10). It returns the sheet name, or empty string if it is not qualified,
+// and the start and end coordinates.
+func ParseSheetRange(rangeStr string) (string, int, int, int, int, error) {
+	if len(SplitRangeAreas(rangeStr)) > 1 {
+		return "", 0, 0, 0, 0, fmt.Errorf("multi-area range is not supported: %s", rangeStr)
+	}
+	sheetName, ref := SplitSheetName(strings.TrimSpace(rangeStr))
+	startCol, startRow, endCol, endRow, _, _, err := parseArea(ref)
 	if err != nil {
-		return 0, 0, 0, 0, err
+		return "", 0, 0, 0, 0, err
 	}
-	return startCol, startRow, endCol, endRow, nil
+	return sheetName, startCol, startRow, endCol, endRow, nil
+}
+
+// parseArea parses a single area without sheet name and also reports whether it spans whole columns or whole rows.
+func parseArea(rangeStr string) (startCol, startRow, endCol, endRow int, wholeColumns, wholeRows bool, err error) {
+	ref := strings.ToUpper(strings.TrimSpace(rangeStr))
+
+	if matches := a1RangeRegexp.FindStringSubmatch(ref); matches != nil {
+		startCol, startRow, err = excelize.CellNameToCoordinates(matches[1])
+		if err != nil {
+			return 0, 0, 0, 0, false, false, err
+		}
+		if matches[2] == "" {
+			// Single cell case
+			return startCol, startRow, startCol, startRow, false, false, nil
+		}
+		endCol, endRow, err = excelize.CellNameToCoordinates(matches[2])
+		if err != nil {
+			return 0, 0, 0, 0, false, false, err
+		}
+		return startCol, startRow, endCol, endRow, false, false, nil
+	}
+
+	if matches := r1c1RangeRegexp.FindStringSubmatch(ref); matches != nil {
+		startRow, _ = strconv.Atoi(matches[1])
+		startCol, _ = strconv.Atoi(matches[2])
+		endRow, endCol = startRow, startCol
+		if matches[3] != "" {
+			endRow, _ = strconv.Atoi(matches[3])
+			endCol, _ = strconv.Atoi(matches[4])
+		}
+		for _, cell := range [][]int{{startCol, startRow}, {endCol, endRow}} {
+			if _, err := excelize.CoordinatesToCellName(cell[0], cell[1]); err != nil {
+				return 0, 0, 0, 0, false, false, err
+			}
+		}
+		return startCol, startRow, endCol, endRow, false, false, nil
+	}
+
+	if matches := wholeColumnRegexp.FindStringSubmatch(ref); matches != nil {
+		startCol, err = excelize.ColumnNameToNumber(matches[1])
+		if err != nil {
+			return 0, 0, 0, 0, false, false, err
+		}
+		endCol, err = excelize.ColumnNameToNumber(matches[2])
+		if err != nil {
+			return 0, 0, 0, 0, false, false, err
+		}
+		return startCol, 1, endCol, excelize.TotalRows, true, false, nil
+	}
+
+	if matches := wholeRowRegexp.FindStringSubmatch(ref); matches != nil {
+		startRow, _ = strconv.Atoi(matches[1])
+		endRow, _ = strconv.Atoi(matches[2])
+		if startRow < 1 || endRow < 1 || startRow > excelize.TotalRows || endRow > excelize.TotalRows {
+			return 0, 0, 0, 0, false, false, fmt.Errorf("invalid row number in range: %s", rangeStr)
+		}
+		return 1, startRow, excelize.MaxColumns, endRow, false, true, nil
+	}
+
+	return 0, 0, 0, 0, false, false, fmt.Errorf("invalid range format: %s", rangeStr)
+}
+
+// ParseRowsOrColumns parses whole rows (e.g. 3:5 or 3) or whole columns (e.g. B:D or B).
+// It reports whether the range is rows, and the start and end numbers of the rows or columns.
+func ParseRowsOrColumns(rangeStr string) (bool, int, int, error) {
+	ref := strings.TrimSpace(rangeStr)
+	if !strings.Contains(ref, ":") {
+		ref += ":" + ref
+	}
+	startCol, startRow, endCol, endRow, wholeColumns, wholeRows, err := parseArea(ref)
+	if err == nil && wholeRows {
+		return true, min(startRow, endRow), max(startRow, endRow), nil
+	}
+	if err == nil && wholeColumns {
+		return false, min(startCol, endCol), max(startCol, endCol), nil
+	}
+	return false, 0, 0, fmt.Errorf("range must be whole rows (e.g. 3:5) or whole columns (e.g. B:D): %s", rangeStr)
+}
+
+// SplitRangeAreas splits a multi-area range (e.g. A1:B2,D1:E2) into its areas.
+// Commas inside quoted sheet names are not treated as separators.
+func SplitRangeAreas(rangeStr string) []string {
+	var areas []string
+	quoted := false
+	start := 0
+	for i := 0; i < len(rangeStr); i++ {
+		switch rangeStr[i] {
+		case '\'':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				areas = append(areas, strings.TrimSpace(rangeStr[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(areas, strings.TrimSpace(rangeStr[start:]))
 }
 
 func NormalizeRange(rangeStr string) string {
@@ -40,6 +159,209 @@ func NormalizeRange(rangeStr string) string {
 	return fmt.Sprintf("%s:%s", startCell, endCell)
 }
 
+// SplitSheetName splits a sheet-qualified reference (e.g. 'My Sheet'!A1:C10) into
+// the unquoted sheet name and the rest. The sheet name is empty if ref is not qualified.
+func SplitSheetName(ref string) (string, string) {
+	if strings.HasPrefix(ref, "'") {
+		for i := 1; i < len(ref); i++ {
+			if ref[i] != '\'' {
+				continue
+			}
+			if i+1 < len(ref) && ref[i+1] == '\'' {
+				// escaped quote
+				i++
+				continue
+			}
+			if i+1 < len(ref) && ref[i+1] == '!' {
+				return strings.ReplaceAll(ref[1:i], "''", "'"), ref[i+2:]
+			}
+			break
+		}
+		return "", ref
+	}
+	if i := strings.LastIndex(ref, "!"); i >= 0 {
+		return ref[:i], ref[i+1:]
+	}
+	return "", ref
+}
+
+var unquotedSheetNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// QuoteSheetName quotes the sheet name if it is needed to be used in a reference.
+func QuoteSheetName(sheetName string) string {
+	if unquotedSheetNameRegexp.MatchString(sheetName) {
+		return sheetName
+	}
+	return "'" + strings.ReplaceAll(sheetName, "'", "''") + "'"
+}
+
+// ResolveRange resolves a range string in the worksheet into normalized A1 ranges (e.g. A1:C10).
+// Each comma-separated area may be a defined name or any format accepted by ParseRange.
+// Names scoped to the worksheet take precedence over workbook-scoped names.
+// Whole columns and rows are clipped to the used range of the worksheet.
+// Multiple areas are joined with comma.
+func ResolveRange(workbook Excel, worksheet Worksheet, rangeStr string) (string, error) {
+	sheetName, err := worksheet.Name()
+	if err != nil {
+		return "", err
+	}
+	return resolveRange(workbook, sheetName, worksheet.GetDimention, rangeStr)
+}
+
+// ResolveNewSheetRange resolves a range string as ResolveRange does, in a sheet which is not created yet.
+// Whole columns and rows are clipped to A1, since the sheet has no used range.
+func ResolveNewSheetRange(workbook Excel, sheetName string, rangeStr string) (string, error) {
+	return resolveRange(workbook, sheetName, func() (string, error) { return "A1", nil }, rangeStr)
+}
+
+// resolveRange resolves a range string in the sheet, whose used range dimension returns.
+func resolveRange(workbook Excel, sheetName string, dimension func() (string, error), rangeStr string) (string, error) {
+	definedNames, err := workbook.GetDefinedNames()
+	if err != nil {
+		return "", fmt.Errorf("failed to get defined names: %w", err)
+	}
+
+	var resolvedAreas []string
+	for _, area := range SplitRangeAreas(rangeStr) {
+		ref := area
+		if definedName := findDefinedName(definedNames, sheetName, area); definedName != nil {
+			ref = definedName.RefersTo
+		}
+		// a defined name may refer to multiple areas
+		for _, refArea := range SplitRangeAreas(ref) {
+			refSheetName, refPart := SplitSheetName(strings.TrimSpace(refArea))
+			if refSheetName != "" && !strings.EqualFold(refSheetName, sheetName) {
+				return "", fmt.Errorf("range refers to another sheet: %s", area)
+			}
+			startCol, startRow, endCol, endRow, wholeColumns, wholeRows, err := parseArea(refPart)
+			if err != nil {
+				if ref != area {
+					return "", fmt.Errorf("defined name %s does not refer to a range: %s", area, ref)
+				}
+				return "", err
+			}
+			if wholeColumns || wholeRows {
+				usedRange, err := dimension()
+				if err != nil {
+					return "", err
+				}
+				startCol, startRow, endCol, endRow, err = clipRange(usedRange, startCol, startRow, endCol, endRow, wholeColumns, wholeRows)
+				if err != nil {
+					return "", err
+				}
+			}
+			startCell, err := excelize.CoordinatesToCellName(startCol, startRow)
+			if err != nil {
+				return "", err
+			}
+			endCell, err := excelize.CoordinatesToCellName(endCol, endRow)
+			if err != nil {
+				return "", err
+			}
+			resolvedAreas = append(resolvedAreas, fmt.Sprintf("%s:%s", startCell, endCell))
+		}
+	}
+	return strings.Join(resolvedAreas, ","), nil
+}
+
+// ResolveSourceRange resolves the source data of a pivot table into the sheet name and the range (e.g. A1:E31).
+// The source may be a table name, a defined name or a range. A range without sheet name refers to the specified sheet.
+func ResolveSourceRange(workbook Excel, sheetName string, source string) (string, string, error) {
+	source = strings.TrimPrefix(strings.TrimSpace(source), "=")
+	if _, _, _, _, _, err := ParseSheetRange(source); err != nil {
+		worksheets, err := workbook.GetSheets()
+		if err != nil {
+			return "", "", err
+		}
+		for _, worksheet := range worksheets {
+			defer worksheet.Release()
+		}
+		for _, worksheet := range worksheets {
+			tables, err := worksheet.GetTables()
+			if err != nil {
+				return "", "", err
+			}
+			for _, table := range tables {
+				if strings.EqualFold(table.Name, source) {
+					tableSheetName, err := worksheet.Name()
+					return tableSheetName, table.Range, err
+				}
+			}
+		}
+		definedNames, err := workbook.GetDefinedNames()
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get defined names: %w", err)
+		}
+		definedName := findDefinedName(definedNames, sheetName, source)
+		if definedName == nil {
+			return "", "", fmt.Errorf("source must be a range, a table name or a defined name: %s", source)
+		}
+		source = definedName.RefersTo
+	}
+	sourceSheetName, ref := SplitSheetName(source)
+	if sourceSheetName == "" {
+		sourceSheetName = sheetName
+	}
+	if _, _, _, _, err := ParseRange(ref); err != nil {
+		return "", "", fmt.Errorf("source does not refer to a range: %s", source)
+	}
+	return sourceSheetName, NormalizeRange(ref), nil
+}
+
+// normalizeSourceRange converts a sheet-qualified source range of a pivot table (e.g. "Sheet1!R1C1:R31C5")
+// into the A1 format (e.g. "Sheet1!A1:E31"). Names are returned as is.
+func normalizeSourceRange(source string) string {
+	sheetName, ref := SplitSheetName(source)
+	if sheetName == "" {
+		return source
+	}
+	if _, _, _, _, err := ParseRange(ref); err != nil {
+		return source
+	}
+	return QuoteSheetName(sheetName) + "!" + NormalizeRange(ref)
+}
+
+// ClipRange clips whole columns to the used rows, and whole rows to the used columns of the worksheet.
+func ClipRange(worksheet Worksheet, startCol, startRow, endCol, endRow int, wholeColumns, wholeRows bool) (int, int, int, int, error) {
+	dimension, err := worksheet.GetDimention()
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	return clipRange(dimension, startCol, startRow, endCol, endRow, wholeColumns, wholeRows)
+}
+
+// clipRange clips whole columns to the used rows, and whole rows to the used columns of the dimension.
+func clipRange(dimension string, startCol, startRow, endCol, endRow int, wholeColumns, wholeRows bool) (int, int, int, int, error) {
+	usedStartCol, usedStartRow, usedEndCol, usedEndRow, err := ParseRange(dimension)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	if wholeColumns {
+		startRow, endRow = usedStartRow, usedEndRow
+	}
+	if wholeRows {
+		startCol, endCol = usedStartCol, usedEndCol
+	}
+	return startCol, startRow, endCol, endRow, nil
+}
+
+// findDefinedName finds the defined name visible from the sheet, or returns nil.
+func findDefinedName(definedNames []DefinedName, sheetName string, name string) *DefinedName {
+	var found *DefinedName
+	for i, definedName := range definedNames {
+		if !strings.EqualFold(definedName.Name, name) {
+			continue
+		}
+		if definedName.Scope == sheetName {
+			return &definedNames[i]
+		}
+		if definedName.Scope == "" {
+			found = &definedNames[i]
+		}
+	}
+	return found
+}
+
 // FileIsNotReadable checks if a file is not writable
 func FileIsNotWritable(absolutePath string) bool {
 	f, err := os.OpenFile(path.Clean(absolutePath), os.O_WRONLY, os.ModePerm)
@@ -49,3 +371,528 @@ func FileIsNotWritable(absolutePath string) bool {
 	defer f.Close()
 	return false
 }
+
+// trimCommentAuthor removes the author name which Excel puts at the beginning of notes (e.g. "Author:\ntext").
+func trimCommentAuthor(text string, author string) string {
+	if author == "" || !strings.HasPrefix(text, author+":") {
+		return text
+	}
+	return strings.TrimLeft(strings.TrimPrefix(text, author+":"), " \r\n")
+}
+
+// isWorkbookLocation reports whether the hyperlink target is a location in the workbook
+// (e.g. "Sheet2!A1", "'My Sheet'!A1:B2" or a defined name) rather than an external URL or file.
+func isWorkbookLocation(target string) bool {
+	if strings.ContainsAny(target, "/\\#") {
+		return false
+	}
+	sheetName, ref := SplitSheetName(target)
+	if sheetName != "" {
+		return true
+	}
+	if _, _, _, _, err := ParseRange(ref); err == nil {
+		return true
+	}
+	// defined name, while file names usually have an extension
+	return unquotedSheetNameRegexp.MatchString(ref) && !strings.Contains(ref, ".")
+}
+
+// referencePartRegexp matches a part of an A1 reference: a cell, a column or a row, with optional $.
+var referencePartRegexp = regexp.MustCompile(`^(\$?)([A-Za-z]{1,3})?(\$?)(\d+)?$`)
+
+// ShiftFormula shifts the relative references in the formula by the offsets, as Excel does when a formula is copied.
+// Absolute references ($A$1) are kept, and references shifted out of the sheet become #REF!.
+// String literals, structured references (Table1[Column]) and names are not changed.
+func ShiftFormula(formula string, colOffset, rowOffset int) string {
+	if colOffset == 0 && rowOffset == 0 {
+		return formula
+	}
+	var result strings.Builder
+	isNameChar := func(c byte) bool {
+		return c == '_' || c == '.' || c == '$' || c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+	}
+	for i := 0; i < len(formula); {
+		c := formula[i]
+		switch {
+		case c == '"' || c == '[':
+			// copy string literals and structured references as is
+			end := i + 1
+			if c == '"' {
+				for end < len(formula) && (formula[end] != '"' || end+1 < len(formula) && formula[end+1] == '"') {
+					if formula[end] == '"' {
+						end++
+					}
+					end++
+				}
+			} else {
+				for depth := 1; end < len(formula) && depth > 0; end++ {
+					switch formula[end] {
+					case '[':
+						depth++
+					case ']':
+						depth--
+					}
+				}
+				end--
+			}
+			end = min(end+1, len(formula))
+			result.WriteString(formula[i:end])
+			i = end
+		case c == '\'':
+			// quoted sheet name followed by a reference
+			end := i + 1
+			for end < len(formula) && (formula[end] != '\'' || end+1 < len(formula) && formula[end+1] == '\'') {
+				if formula[end] == '\'' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(formula))
+			if end < len(formula) && formula[end] == '!' {
+				end++
+			}
+			result.WriteString(formula[i:end])
+			i = end
+			refEnd := i
+			for refEnd < len(formula) && (isNameChar(formula[refEnd]) || formula[refEnd] == ':') {
+				refEnd++
+			}
+			result.WriteString(shiftReference(formula[i:refEnd], colOffset, rowOffset))
+			i = refEnd
+		case isNameChar(c):
+			end := i
+			for end < len(formula) && (isNameChar(formula[end]) || formula[end] == ':' || formula[end] == '!') {
+				end++
+			}
+			token := formula[i:end]
+			if end < len(formula) && (formula[end] == '(' || formula[end] == '[') {
+				// function or table name
+				result.WriteString(token)
+			} else if sep := strings.LastIndex(token, "!"); sep >= 0 {
+				result.WriteString(token[:sep+1] + shiftReference(token[sep+1:], colOffset, rowOffset))
+			} else {
+				result.WriteString(shiftReference(token, colOffset, rowOffset))
+			}
+			i = end
+		default:
+			result.WriteByte(c)
+			i++
+		}
+	}
+	return result.String()
+}
+
+// shiftReference shifts an A1 reference (e.g. A1, $A1:B$2, A:C, 3:5) by the offsets.
+// It returns the token as is if it is not a reference.
+func shiftReference(token string, colOffset, rowOffset int) string {
+	parts := strings.Split(token, ":")
+	if len(parts) > 2 || token == "" {
+		return token
+	}
+	type part struct {
+		colAbs, rowAbs string
+		col, row       int
+	}
+	shifted := make([]part, len(parts))
+	kind := ""
+	for i, p := range parts {
+		matches := referencePartRegexp.FindStringSubmatch(p)
+		if matches == nil || matches[2] == "" && matches[4] == "" {
+			return token
+		}
+		partKind := "cell"
+		if matches[4] == "" {
+			partKind = "column"
+		} else if matches[2] == "" {
+			partKind = "row"
+		}
+		// a single column or row is a name or a number
+		if kind != "" && kind != partKind || len(parts) == 1 && partKind != "cell" {
+			return token
+		}
+		kind = partKind
+		if partKind == "row" && matches[1] != "" {
+			// "$1" is parsed as column $ and row 1
+			matches[3] = matches[1]
+			matches[1] = ""
+		}
+		sp := part{colAbs: matches[1], rowAbs: matches[3]}
+		if matches[2] != "" {
+			col, err := excelize.ColumnNameToNumber(matches[2])
+			if err != nil {
+				return token
+			}
+			sp.col = col
+			if sp.colAbs == "" {
+				sp.col += colOffset
+			}
+		}
+		if matches[4] != "" {
+			row, err := strconv.Atoi(matches[4])
+			if err != nil || row < 1 || row > excelize.TotalRows {
+				return token
+			}
+			sp.row = row
+			if sp.rowAbs == "" {
+				sp.row += rowOffset
+			}
+		}
+		shifted[i] = sp
+	}
+
+	results := make([]string, len(shifted))
+	for i, sp := range shifted {
+		text := ""
+		if kind != "row" {
+			if sp.col < 1 || sp.col > excelize.MaxColumns {
+				return "#REF!"
+			}
+			name, _ := excelize.ColumnNumberToName(sp.col)
+			text += sp.colAbs + name
+		}
+		if kind != "column" {
+			if sp.row < 1 || sp.row > excelize.TotalRows {
+				return "#REF!"
+			}
+			text += sp.rowAbs + strconv.Itoa(sp.row)
+		}
+		results[i] = text
+	}
+	return strings.Join(results, ":")
+}
+
+// FormulaSheetNames returns the names of the sheets referenced by the formula, without duplicates.
+// Sheets of 3-D references (e.g. Sheet1:Sheet3!A1) are returned separately, and references to other workbooks are ignored.
+func FormulaSheetNames(formula string) []string {
+	var names []string
+	add := func(sheets string) {
+		for _, name := range strings.Split(sheets, ":") {
+			if name != "" && !slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, name) }) {
+				names = append(names, name)
+			}
+		}
+	}
+	isNameChar := func(c byte) bool {
+		return c == '_' || c == '.' || c == '$' || c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= 0x80
+	}
+	// external is true just after a workbook reference (e.g. [1] or [Book.xlsx])
+	external := false
+	for i := 0; i < len(formula); {
+		c := formula[i]
+		switch {
+		case c == '"':
+			// skip string literals
+			end := i + 1
+			for end < len(formula) && (formula[end] != '"' || end+1 < len(formula) && formula[end+1] == '"') {
+				if formula[end] == '"' {
+					end++
+				}
+				end++
+			}
+			i = end + 1
+			external = false
+		case c == '[':
+			// skip workbook references and structured references
+			end := i + 1
+			for depth := 1; end < len(formula) && depth > 0; end++ {
+				switch formula[end] {
+				case '[':
+					depth++
+				case ']':
+					depth--
+				}
+			}
+			external = i == 0 || !isNameChar(formula[i-1])
+			i = end
+		case c == '\'':
+			end := i + 1
+			for end < len(formula) && (formula[end] != '\'' || end+1 < len(formula) && formula[end+1] == '\'') {
+				if formula[end] == '\'' {
+					end++
+				}
+				end++
+			}
+			name := strings.ReplaceAll(formula[min(i+1, len(formula)):min(end, len(formula))], "''", "'")
+			// '[Book.xlsx]Sheet1'!A1 refers to another workbook
+			if end+1 < len(formula) && formula[end+1] == '!' && !external && !strings.HasPrefix(name, "[") {
+				add(name)
+			}
+			i = end + 1
+			external = false
+		case isNameChar(c):
+			end := i
+			for end < len(formula) && (isNameChar(formula[end]) || formula[end] == ':') {
+				end++
+			}
+			if end < len(formula) && formula[end] == '!' && !external {
+				add(formula[i:end])
+			}
+			i = end
+			external = false
+		default:
+			i++
+			external = false
+		}
+	}
+	return names
+}
+
+// sortValue is the value of a cell compared by a sort key.
+type sortValue struct {
+	// value is a number, a boolean, a string or nil for an empty cell
+	value any
+	// text is the displayed text of the cell
+	text string
+}
+
+// sortDateLayouts are the layouts of dates stored as text which SortDataTypeDate understands
+var sortDateLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"2006-01-02 15:04:05",
+	"2006/01/02 15:04:05",
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+	"01/02/2006",
+	"1/2/2006",
+	"01/02/2006 15:04:05",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"2 Jan 2006",
+	"2 January 2006",
+}
+
+// compareSortValues compares two cell values by the sort key.
+// Empty cells are sorted last regardless of the order, as Excel does.
+func compareSortValues(a, b sortValue, key SortKey) int {
+	if a.value == nil || b.value == nil {
+		switch {
+		case a.value == nil && b.value == nil:
+			return 0
+		case a.value == nil:
+			return 1
+		default:
+			return -1
+		}
+	}
+	result := 0
+	if len(key.CustomOrder) > 0 {
+		result = cmp.Compare(customOrderIndex(key.CustomOrder, a.text), customOrderIndex(key.CustomOrder, b.text))
+		if result == 0 && customOrderIndex(key.CustomOrder, a.text) < len(key.CustomOrder) {
+			return 0
+		}
+	}
+	if result == 0 {
+		switch key.DataType {
+		case SortDataTypeNumber:
+			result = compareSortKinds(a, b, sortNumber)
+		case SortDataTypeDate:
+			result = compareSortKinds(a, b, sortDate)
+		case SortDataTypeText:
+			result = strings.Compare(strings.ToLower(a.text), strings.ToLower(b.text))
+		default:
+			result = compareSortKinds(a, b, func(v sortValue) (float64, bool) {
+				number, ok := v.value.(float64)
+				return number, ok
+			})
+		}
+	}
+	if key.Order == SortOrderDescending {
+		return -result
+	}
+	return result
+}
+
+// compareSortKinds compares values converted to numbers by the function first, then text, then booleans.
+func compareSortKinds(a, b sortValue, toNumber func(v sortValue) (float64, bool)) int {
+	kind := func(v sortValue) int {
+		if _, ok := toNumber(v); ok {
+			return 0
+		}
+		if _, ok := v.value.(bool); ok {
+			return 2
+		}
+		return 1
+	}
+	kindA, kindB := kind(a), kind(b)
+	if kindA != kindB {
+		return cmp.Compare(kindA, kindB)
+	}
+	switch kindA {
+	case 0:
+		numberA, _ := toNumber(a)
+		numberB, _ := toNumber(b)
+		return cmp.Compare(numberA, numberB)
+	case 2:
+		// FALSE is sorted before TRUE
+		boolA, boolB := a.value.(bool), b.value.(bool)
+		return cmp.Compare(boolToInt(boolA), boolToInt(boolB))
+	default:
+		return strings.Compare(strings.ToLower(a.text), strings.ToLower(b.text))
+	}
+}
+
+// customOrderIndex returns the index of the text in the custom order, or the length of the order if not found.
+func customOrderIndex(order []string, text string) int {
+	for i, item := range order {
+		if strings.EqualFold(item, text) {
+			return i
+		}
+	}
+	return len(order)
+}
+
+// sortNumber returns the number of the value, also parsing numbers stored as text (e.g. "1,200").
+func sortNumber(v sortValue) (float64, bool) {
+	if number, ok := v.value.(float64); ok {
+		return number, true
+	}
+	text, ok := v.value.(string)
+	if !ok {
+		return 0, false
+	}
+	number, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(text), ",", ""), 64)
+	return number, err == nil
+}
+
+// sortDate returns the serial number of the date, also parsing dates stored as text.
+func sortDate(v sortValue) (float64, bool) {
+	if number, ok := v.value.(float64); ok {
+		return number, true
+	}
+	text, ok := v.value.(string)
+	if !ok {
+		return 0, false
+	}
+	text = strings.TrimSpace(text)
+	for _, layout := range sortDateLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, t.Location())
+			return float64(t.Sub(epoch)) / float64(24*time.Hour), true
+		}
+	}
+	return 0, false
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Match reports whether a cell satisfies the criteria of the column. value is the value stored in the cell without
+// its number format (e.g. 0.05), and text is the displayed text (e.g. 5.00%). As Excel does, a list of values matches
+// the displayed text, and conditions compare the stored value.
+// Criteria described by Other are not evaluated, and any cell matches them.
+func (c FilterColumn) Match(value string, text string) bool {
+	if len(c.Values) > 0 || c.Blank {
+		if text == "" {
+			return c.Blank
+		}
+		for _, filterValue := range c.Values {
+			if strings.EqualFold(filterValue, text) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(c.Conditions) == 0 {
+		return true
+	}
+	for _, condition := range c.Conditions {
+		matched := condition.match(value, text)
+		if c.And && !matched {
+			return false
+		}
+		if !c.And && matched {
+			return true
+		}
+	}
+	return c.And
+}
+
+func (c FilterCondition) match(value string, text string) bool {
+	switch c.Operator {
+	case FilterOperatorEqual:
+		return filterValueEqual(c.Value, value, text)
+	case FilterOperatorNotEqual:
+		return !filterValueEqual(c.Value, value, text)
+	}
+	// empty cells do not satisfy comparisons
+	if value == "" {
+		return false
+	}
+	result := compareFilterValues(value, c.Value)
+	switch c.Operator {
+	case FilterOperatorGreaterThan:
+		return result > 0
+	case FilterOperatorGreaterThanOrEqual:
+		return result >= 0
+	case FilterOperatorLessThan:
+		return result < 0
+	default:
+		return result <= 0
+	}
+}
+
+// filterValueEqual reports whether the cell equals the criterion. Numbers are compared with the stored value,
+// and text is compared with the displayed text, where the criterion may contain wildcards (* and ?, escaped by ~).
+func filterValueEqual(criterion string, value string, text string) bool {
+	if number, ok := filterNumber(value); ok {
+		if criterionNumber, ok := filterNumber(criterion); ok {
+			return number == criterionNumber
+		}
+	}
+	if !strings.ContainsAny(criterion, "*?") {
+		return strings.EqualFold(criterion, text)
+	}
+	var pattern strings.Builder
+	pattern.WriteString("(?is)^")
+	escaped := false
+	for _, r := range criterion {
+		switch {
+		case escaped:
+			pattern.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '~':
+			escaped = true
+		case r == '*':
+			pattern.WriteString(".*")
+		case r == '?':
+			pattern.WriteString(".")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	pattern.WriteString("$")
+	re, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return false
+	}
+	return re.MatchString(text)
+}
+
+// compareFilterValues compares two values as numbers, which may be dates or percentages, or as case-insensitive text.
+func compareFilterValues(a, b string) int {
+	numberA, okA := filterNumber(a)
+	numberB, okB := filterNumber(b)
+	if okA && okB {
+		return cmp.Compare(numberA, numberB)
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// filterNumber returns the number of a value of a filter, parsing numbers (e.g. "1,200"), percentages (e.g. "10%")
+// and dates (e.g. "2024-01-15", as the serial number).
+func filterNumber(value string) (float64, bool) {
+	if number, ok := sortNumber(sortValue{value: value}); ok {
+		return number, true
+	}
+	if percent, ok := strings.CutSuffix(strings.TrimSpace(value), "%"); ok {
+		if number, ok := sortNumber(sortValue{value: percent}); ok {
+			return number / 100, true
+		}
+	}
+	return sortDate(sortValue{value: value})
+}
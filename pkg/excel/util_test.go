@@ -0,0 +1,155 @@
+package excel
+
+import "testing"
+
+func TestParseSheetRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		rangeStr  string
+		sheetName string
+		want      [4]int
+		wantErr   bool
+	}{
+		{name: "single cell", rangeStr: "B3", want: [4]int{2, 3, 2, 3}},
+		{name: "A1 range", rangeStr: "A1:C10", want: [4]int{1, 1, 3, 10}},
+		{name: "absolute", rangeStr: "$A$1:$C$10", want: [4]int{1, 1, 3, 10}},
+		{name: "lowercase", rangeStr: "a1:c10", want: [4]int{1, 1, 3, 10}},
+		{name: "R1C1 cell", rangeStr: "R2C3", want: [4]int{3, 2, 3, 2}},
+		{name: "R1C1 range lowercase", rangeStr: "r1c1:r10c3", want: [4]int{1, 1, 3, 10}},
+		{name: "whole columns", rangeStr: "B:D", want: [4]int{2, 1, 4, 1048576}},
+		{name: "whole rows", rangeStr: "$3:$5", want: [4]int{1, 3, 16384, 5}},
+		{name: "sheet name", rangeStr: "Sheet1!A1:B2", sheetName: "Sheet1", want: [4]int{1, 1, 2, 2}},
+		{name: "quoted sheet name", rangeStr: "'It''s, mine'!A1:B2", sheetName: "It's, mine", want: [4]int{1, 1, 2, 2}},
+		{name: "multi-area", rangeStr: "A1:B2,D1:E2", wantErr: true},
+		{name: "multi-area with sheet names", rangeStr: "Sheet1!A1,Sheet1!B2", wantErr: true},
+		{name: "invalid row", rangeStr: "0:3", wantErr: true},
+		{name: "out of the sheet", rangeStr: "R1C16385", wantErr: true},
+		{name: "name", rangeStr: "Sales", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sheetName, startCol, startRow, endCol, endRow, err := ParseSheetRange(tt.rangeStr)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseSheetRange(%q) succeeded, want error", tt.rangeStr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSheetRange(%q) failed: %v", tt.rangeStr, err)
+			}
+			if got := [4]int{startCol, startRow, endCol, endRow}; sheetName != tt.sheetName || got != tt.want {
+				t.Errorf("ParseSheetRange(%q) = %q, %v, want %q, %v", tt.rangeStr, sheetName, got, tt.sheetName, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRangeRejectsSheetName(t *testing.T) {
+	for _, rangeStr := range []string{"Other!A1:B2", "'My Sheet'!A1"} {
+		if _, _, _, _, err := ParseRange(rangeStr); err == nil {
+			t.Errorf("ParseRange(%q) succeeded, want error", rangeStr)
+		}
+	}
+}
+
+func TestShiftFormula(t *testing.T) {
+	tests := []struct {
+		name      string
+		formula   string
+		colOffset int
+		rowOffset int
+		want      string
+	}{
+		{name: "no offset", formula: "=A1+B2", want: "=A1+B2"},
+		{name: "relative cells", formula: "=A1+B2", colOffset: 1, rowOffset: 2, want: "=B3+C4"},
+		{name: "absolute cells", formula: "=$A$1+$B2+C$3", colOffset: 1, rowOffset: 1, want: "=$A$1+$B3+D$3"},
+		{name: "range in function", formula: "=SUM(A1:B10)", rowOffset: 5, want: "=SUM(A6:B15)"},
+		{name: "whole columns and rows", formula: "=SUM(A:A)+SUM(3:4)", colOffset: 2, rowOffset: 1, want: "=SUM(C:C)+SUM(4:5)"},
+		{name: "sheet reference", formula: "=Sheet2!A1+'My Sheet'!B2", colOffset: 1, want: "=Sheet2!B1+'My Sheet'!C2"},
+		{name: "string literal", formula: `="A1"&A1`, rowOffset: 1, want: `="A1"&A2`},
+		{name: "structured reference", formula: "=SUM(Table1[Amount])+A1", rowOffset: 1, want: "=SUM(Table1[Amount])+A2"},
+		{name: "out of the sheet", formula: "=A1+B2", rowOffset: -1, want: "=#REF!+B1"},
+		{name: "function name like a cell", formula: "=LOG10(A1)", rowOffset: 1, want: "=LOG10(A2)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShiftFormula(tt.formula, tt.colOffset, tt.rowOffset); got != tt.want {
+				t.Errorf("ShiftFormula(%q, %d, %d) = %q, want %q", tt.formula, tt.colOffset, tt.rowOffset, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareSortValues(t *testing.T) {
+	number := func(n float64) sortValue { return sortValue{value: n, text: ""} }
+	text := func(s string) sortValue { return sortValue{value: s, text: s} }
+	empty := sortValue{}
+	ascending := SortKey{Order: SortOrderAscending, DataType: SortDataTypeAuto}
+	descending := SortKey{Order: SortOrderDescending, DataType: SortDataTypeAuto}
+	tests := []struct {
+		name string
+		a, b sortValue
+		key  SortKey
+		want int
+	}{
+		{name: "numbers", a: number(2), b: number(10), key: ascending, want: -1},
+		{name: "numbers descending", a: number(2), b: number(10), key: descending, want: 1},
+		{name: "numbers before text", a: text("a"), b: number(1), key: ascending, want: 1},
+		{name: "text case-insensitive", a: text("apple"), b: text("Banana"), key: ascending, want: -1},
+		{name: "text before booleans", a: sortValue{value: true, text: "TRUE"}, b: text("z"), key: ascending, want: 1},
+		{name: "FALSE before TRUE", a: sortValue{value: false, text: "FALSE"}, b: sortValue{value: true, text: "TRUE"}, key: ascending, want: -1},
+		{name: "empty last ascending", a: empty, b: number(1), key: ascending, want: 1},
+		{name: "empty last descending", a: empty, b: number(1), key: descending, want: 1},
+		{name: "numbers stored as text", a: text("1,200"), b: text("900"), key: SortKey{Order: SortOrderAscending, DataType: SortDataTypeNumber}, want: 1},
+		{name: "numbers as text", a: text("1,200"), b: text("900"), key: SortKey{Order: SortOrderAscending, DataType: SortDataTypeText}, want: -1},
+		{name: "dates stored as text", a: text("2024/01/15"), b: text("2023-12-31"), key: SortKey{Order: SortOrderAscending, DataType: SortDataTypeDate}, want: 1},
+		{name: "date text and serial", a: text("2024-01-15"), b: number(45306), key: SortKey{Order: SortOrderAscending, DataType: SortDataTypeDate}, want: 0},
+		{name: "custom order", a: text("High"), b: text("Low"), key: SortKey{Order: SortOrderAscending, CustomOrder: []string{"Low", "Mid", "High"}}, want: 1},
+		{name: "custom order before others", a: text("Zero"), b: text("high"), key: SortKey{Order: SortOrderAscending, CustomOrder: []string{"Low", "High"}}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareSortValues(tt.a, tt.b, tt.key); got != tt.want {
+				t.Errorf("compareSortValues(%v, %v) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterColumnMatch(t *testing.T) {
+	condition := func(operator FilterOperator, value string) FilterCondition {
+		return FilterCondition{Operator: operator, Value: value}
+	}
+	tests := []struct {
+		name   string
+		column FilterColumn
+		value  string
+		text   string
+		want   bool
+	}{
+		{name: "values match displayed text", column: FilterColumn{Values: []string{"5.00%"}}, value: "0.05", text: "5.00%", want: true},
+		{name: "values case-insensitive", column: FilterColumn{Values: []string{"east"}}, value: "East", text: "East", want: true},
+		{name: "values exclude others", column: FilterColumn{Values: []string{"East"}}, value: "West", text: "West", want: false},
+		{name: "blank", column: FilterColumn{Values: []string{"East"}, Blank: true}, want: true},
+		{name: "percentage below the threshold", column: FilterColumn{Conditions: []FilterCondition{condition(FilterOperatorGreaterThan, "0.1")}}, value: "0.05", text: "5.00%", want: false},
+		{name: "percentage above the threshold", column: FilterColumn{Conditions: []FilterCondition{condition(FilterOperatorGreaterThan, "0.1")}}, value: "0.25", text: "25.00%", want: true},
+		{name: "percentage criterion", column: FilterColumn{Conditions: []FilterCondition{condition(FilterOperatorLessThan, "10%")}}, value: "0.05", text: "5.00%", want: true},
+		{name: "formatted number", column: FilterColumn{Conditions: []FilterCondition{condition(FilterOperatorGreaterThanOrEqual, "1000000")}}, value: "1234567", text: "1,234,567.00", want: true},
+		{name: "date criterion and serial value", column: FilterColumn{Conditions: []FilterCondition{condition(FilterOperatorGreaterThan, "2024-01-01")}}, value: "45306", text: "01-15-24", want: true},
+		{name: "equal number", column: FilterColumn{Conditions: []FilterCondition{condition(FilterOperatorEqual, "0.05")}}, value: "0.05", text: "5%", want: true},
+		{name: "wildcard on text", column: FilterColumn{Conditions: []FilterCondition{condition(FilterOperatorEqual, "Ea*")}}, value: "East", text: "East", want: true},
+		{name: "not equal", column: FilterColumn{Conditions: []FilterCondition{condition(FilterOperatorNotEqual, "East")}}, value: "East", text: "East", want: false},
+		{name: "empty cell fails comparisons", column: FilterColumn{Conditions: []FilterCondition{condition(FilterOperatorLessThan, "10")}}, want: false},
+		{name: "and", column: FilterColumn{Conditions: []FilterCondition{condition(FilterOperatorGreaterThan, "1"), condition(FilterOperatorLessThan, "5")}, And: true}, value: "7", text: "7", want: false},
+		{name: "or", column: FilterColumn{Conditions: []FilterCondition{condition(FilterOperatorLessThan, "1"), condition(FilterOperatorGreaterThan, "5")}}, value: "7", text: "7", want: true},
+		{name: "other criteria", column: FilterColumn{Other: "top 10"}, value: "1", text: "1", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.column.Match(tt.value, tt.text); got != tt.want {
+				t.Errorf("Match(%q, %q) = %v, want %v", tt.value, tt.text, got, tt.want)
+			}
+		})
+	}
+}
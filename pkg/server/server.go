@@ -1,8 +1,6 @@
 package server
 
 import (
-	"runtime"
-
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/wxyzh/excel-mcp-server/pkg/tools"
 )
@@ -19,13 +17,28 @@ func New(version string) *ExcelServer {
 	)
 	tools.AddExcelDescribeSheetsTool(s.server)
 	tools.AddExcelReadSheetTool(s.server)
-	if runtime.GOOS == "windows" {
-		tools.AddExcelScreenCaptureTool(s.server)
-	}
+	tools.AddExcelScreenCaptureTool(s.server)
 	tools.AddExcelWriteToSheetTool(s.server)
 	tools.AddExcelCreateTableTool(s.server)
 	tools.AddExcelCopySheetTool(s.server)
 	tools.AddExcelFormatRangeTool(s.server)
+	tools.AddExcelManageDefinedNameTool(s.server)
+	tools.AddExcelManageDataValidationTool(s.server)
+	tools.AddExcelAddConditionalFormatTool(s.server)
+	tools.AddExcelManageCommentTool(s.server)
+	tools.AddExcelManageHyperlinkTool(s.server)
+	tools.AddExcelAddChartTool(s.server)
+	tools.AddExcelCreatePivotTableTool(s.server)
+	tools.AddExcelReadPicturesTool(s.server)
+	tools.AddExcelInsertPictureTool(s.server)
+	tools.AddExcelFindTool(s.server)
+	tools.AddExcelReplaceTool(s.server)
+	tools.AddExcelClearRangeTool(s.server)
+	tools.AddExcelCopyRangeTool(s.server)
+	tools.AddExcelSortRangeTool(s.server)
+	tools.AddExcelManageAutoFilterTool(s.server)
+	tools.AddExcelHideRowsColumnsTool(s.server)
+	tools.AddExcelGroupRowsColumnsTool(s.server)
 	return s
 }
 
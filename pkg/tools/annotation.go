@@ -0,0 +1,467 @@
+package tools
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/wxyzh/excel-mcp-server/pkg/excel"
+	"github.com/xuri/excelize/v2"
+)
+
+// CellAnnotation adds attributes to cells of an HTML table and describes what they refer to.
+type CellAnnotation interface {
+	// CellAttributes returns HTML attributes for the cell (e.g. data-validation="v1").
+	CellAttributes(col int, row int) []string
+	// Definitions returns HTML which describes the annotations referred from cells.
+	// It must be called after CellAttributes has been called for all cells.
+	Definitions() string
+}
+
+// rangeArea is a parsed rectangle area of a range
+type rangeArea struct {
+	startCol, startRow, endCol, endRow int
+}
+
+func (a rangeArea) contains(col int, row int) bool {
+	return a.startCol <= col && col <= a.endCol && a.startRow <= row && row <= a.endRow
+}
+
+// parseRangeAreas parses a comma separated range into areas. Invalid areas are ignored.
+func parseRangeAreas(rangeStr string) []rangeArea {
+	var areas []rangeArea
+	for _, area := range excel.SplitRangeAreas(rangeStr) {
+		startCol, startRow, endCol, endRow, err := excel.ParseRange(area)
+		if err != nil {
+			continue
+		}
+		areas = append(areas, rangeArea{startCol, startRow, endCol, endRow})
+	}
+	return areas
+}
+
+// RangeRuleAnnotation annotates cells with the rules (e.g. data validations) applied to their ranges.
+type RangeRuleAnnotation struct {
+	// name is used as the attribute name, the class name and the title of definitions (e.g. "data-validation")
+	name     string
+	title    string
+	idPrefix string
+	rules    []any
+	areas    [][]rangeArea
+	ids      map[int]string
+	order    []int
+}
+
+func newRangeRuleAnnotation(name string, title string, idPrefix string, rules []any, ranges []string) *RangeRuleAnnotation {
+	areas := make([][]rangeArea, len(ranges))
+	for i, rangeStr := range ranges {
+		areas[i] = parseRangeAreas(rangeStr)
+	}
+	return &RangeRuleAnnotation{
+		name:     name,
+		title:    title,
+		idPrefix: idPrefix,
+		rules:    rules,
+		areas:    areas,
+		ids:      make(map[int]string),
+	}
+}
+
+// NewDataValidationAnnotation annotates cells with the data validation rules applied to them.
+func NewDataValidationAnnotation(validations []excel.DataValidation) *RangeRuleAnnotation {
+	rules := make([]any, len(validations))
+	ranges := make([]string, len(validations))
+	for i, validation := range validations {
+		rules[i] = validation
+		ranges[i] = validation.Range
+	}
+	return newRangeRuleAnnotation("data-validation", "Data Validations", "v", rules, ranges)
+}
+
+// NewCommentAnnotation annotates cells with their comments.
+func NewCommentAnnotation(comments []excel.Comment) *RangeRuleAnnotation {
+	rules := make([]any, len(comments))
+	ranges := make([]string, len(comments))
+	for i, comment := range comments {
+		rules[i] = comment
+		ranges[i] = comment.Cell
+	}
+	return newRangeRuleAnnotation("comment", "Comments", "m", rules, ranges)
+}
+
+// NewHyperlinkAnnotation annotates cells with their hyperlinks.
+func NewHyperlinkAnnotation(hyperlinks []excel.Hyperlink) *RangeRuleAnnotation {
+	rules := make([]any, len(hyperlinks))
+	ranges := make([]string, len(hyperlinks))
+	for i, hyperlink := range hyperlinks {
+		rules[i] = hyperlink
+		ranges[i] = hyperlink.Cell
+	}
+	return newRangeRuleAnnotation("hyperlink", "Hyperlinks", "h", rules, ranges)
+}
+
+// NewConditionalFormatAnnotation annotates cells with the conditional formatting rules applied to them.
+func NewConditionalFormatAnnotation(formats []excel.ConditionalFormat) *RangeRuleAnnotation {
+	rules := make([]any, len(formats))
+	ranges := make([]string, len(formats))
+	for i, format := range formats {
+		rules[i] = format
+		ranges[i] = format.Range
+	}
+	return newRangeRuleAnnotation("conditional-format", "Conditional Formats", "c", rules, ranges)
+}
+
+func (a *RangeRuleAnnotation) CellAttributes(col int, row int) []string {
+	var ids []string
+	for i, areas := range a.areas {
+		for _, area := range areas {
+			if !area.contains(col, row) {
+				continue
+			}
+			id, exists := a.ids[i]
+			if !exists {
+				id = fmt.Sprintf("%s%d", a.idPrefix, len(a.order)+1)
+				a.ids[i] = id
+				a.order = append(a.order, i)
+			}
+			ids = append(ids, id)
+			break
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s=\"%s\"", a.name, strings.Join(ids, " "))}
+}
+
+func (a *RangeRuleAnnotation) Definitions() string {
+	if len(a.order) == 0 {
+		return ""
+	}
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("<h2>%s</h2>\n", a.title))
+	result.WriteString(fmt.Sprintf("<div class=\"%ss\">\n", a.name))
+	for _, i := range a.order {
+		result.WriteString(fmt.Sprintf("<code class=\"%s language-yaml\" id=\"%s\">%s</code>\n", a.name, a.ids[i], codeEscaper.Replace(marshalYAMLFlow(a.rules[i]))))
+	}
+	result.WriteString("</div>\n\n")
+	return result.String()
+}
+
+// codeEscaper escapes texts in code elements. Quotes are kept for readability.
+var codeEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// marshalYAMLFlow marshals a rule to YAML flow style. Unlike convertToYAMLFlow, quotes are kept
+// because rules may contain formulas and texts with quotes.
+func marshalYAMLFlow(value any) string {
+	yamlBytes, err := yaml.MarshalWithOptions(value, yaml.Flow(true), yaml.OmitEmpty())
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(yamlBytes))
+}
+
+// RowFilter is implemented by annotations which omit rows from an HTML table.
+type RowFilter interface {
+	// IncludeRow reports whether the row is included in the table.
+	IncludeRow(row int) bool
+}
+
+// RowFilterAnnotation omits rows from the table, and lists the omitted rows in the definitions.
+type RowFilterAnnotation struct {
+	description string
+	// rule describes the criteria of the filter, if any
+	rule    any
+	include func(row int) bool
+	omitted []int
+}
+
+// NewAutoFilterAnnotation omits rows which do not satisfy the AutoFilter. Rows out of its range are included.
+// values returns the value stored in the cell and its displayed text.
+func NewAutoFilterAnnotation(autoFilter *excel.AutoFilter, values func(col int, row int) (string, string)) *RowFilterAnnotation {
+	_, startRow, _, endRow, _ := excel.ParseRange(autoFilter.Range)
+	return &RowFilterAnnotation{
+		description: "Rows which do not satisfy the AutoFilter are omitted",
+		rule:        autoFilter,
+		include: func(row int) bool {
+			if row <= startRow || endRow < row {
+				return true
+			}
+			for _, column := range autoFilter.Columns {
+				if !column.Match(values(column.Column, row)) {
+					return false
+				}
+			}
+			return true
+		},
+	}
+}
+
+// NewHiddenRowAnnotation omits hidden rows.
+func NewHiddenRowAnnotation(hidden func(row int) bool) *RowFilterAnnotation {
+	return &RowFilterAnnotation{
+		description: "Hidden rows are omitted",
+		include: func(row int) bool {
+			return !hidden(row)
+		},
+	}
+}
+
+func (a *RowFilterAnnotation) IncludeRow(row int) bool {
+	if a.include(row) {
+		return true
+	}
+	a.omitted = append(a.omitted, row)
+	return false
+}
+
+func (a *RowFilterAnnotation) CellAttributes(col int, row int) []string {
+	return nil
+}
+
+func (a *RowFilterAnnotation) Definitions() string {
+	var result strings.Builder
+	result.WriteString("<h2>Row Filter</h2>\n")
+	if a.rule != nil {
+		result.WriteString(fmt.Sprintf("<code class=\"row-filter language-yaml\">%s</code>\n", codeEscaper.Replace(marshalYAMLFlow(a.rule))))
+	}
+	if len(a.omitted) == 0 {
+		result.WriteString(fmt.Sprintf("<p>%s. No rows are omitted in this range.</p>\n\n", a.description))
+		return result.String()
+	}
+	result.WriteString(fmt.Sprintf("<p>%s. Omitted rows: %s</p>\n\n", a.description, formatSpans(a.omitted, strconv.Itoa)))
+	return result.String()
+}
+
+// formatSpans lists ascending numbers with consecutive ones as spans (e.g. 3, 5-7).
+func formatSpans(numbers []int, format func(number int) string) string {
+	var spans []string
+	for i := 0; i < len(numbers); {
+		j := i
+		for j+1 < len(numbers) && numbers[j+1] == numbers[j]+1 {
+			j++
+		}
+		if i == j {
+			spans = append(spans, format(numbers[i]))
+		} else {
+			spans = append(spans, fmt.Sprintf("%s-%s", format(numbers[i]), format(numbers[j])))
+		}
+		i = j + 1
+	}
+	return strings.Join(spans, ", ")
+}
+
+// ColumnFilter is implemented by annotations which omit columns from an HTML table.
+type ColumnFilter interface {
+	// IncludeColumn reports whether the column is included in the table.
+	IncludeColumn(col int) bool
+}
+
+// HeaderAnnotation is implemented by annotations which add attributes to row and column headers of an HTML table.
+type HeaderAnnotation interface {
+	// RowAttributes returns HTML attributes for the header of the row.
+	RowAttributes(row int) []string
+	// ColumnAttributes returns HTML attributes for the header of the column.
+	ColumnAttributes(col int) []string
+}
+
+// HeaderRepeater is implemented by annotations which repeat rows and columns out of the range at the top and the left of an HTML table.
+type HeaderRepeater interface {
+	// RepeatedRows returns the rows written before the rows of the range from startRow.
+	RepeatedRows(startRow int) []int
+	// RepeatedColumns returns the columns written before the columns of the range from startCol.
+	RepeatedColumns(startCol int) []int
+}
+
+// RepeatedHeaderAnnotation repeats header rows above the range and row label columns left of it, and marks them on their headers.
+type RepeatedHeaderAnnotation struct {
+	headerStartRow  int
+	headerEndRow    int
+	labelStartCol   int
+	labelEndCol     int
+	repeatedRows    []int
+	repeatedColumns []int
+}
+
+// NewRepeatedHeaderAnnotation repeats rows from headerStartRow to headerEndRow when the range starts below them, and columns
+// from labelStartCol to labelEndCol when the range starts right of them. Zeros mean no rows or no columns.
+func NewRepeatedHeaderAnnotation(headerStartRow int, headerEndRow int, labelStartCol int, labelEndCol int) *RepeatedHeaderAnnotation {
+	return &RepeatedHeaderAnnotation{
+		headerStartRow: headerStartRow,
+		headerEndRow:   headerEndRow,
+		labelStartCol:  labelStartCol,
+		labelEndCol:    labelEndCol,
+	}
+}
+
+func (a *RepeatedHeaderAnnotation) RepeatedRows(startRow int) []int {
+	a.repeatedRows = repeatedLines(a.headerStartRow, a.headerEndRow, startRow)
+	return a.repeatedRows
+}
+
+func (a *RepeatedHeaderAnnotation) RepeatedColumns(startCol int) []int {
+	a.repeatedColumns = repeatedLines(a.labelStartCol, a.labelEndCol, startCol)
+	return a.repeatedColumns
+}
+
+// repeatedLines returns rows or columns from start to end which are before the range from rangeStart
+func repeatedLines(start int, end int, rangeStart int) []int {
+	var lines []int
+	for line := max(start, 1); line <= end && line < rangeStart; line++ {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (a *RepeatedHeaderAnnotation) RowAttributes(row int) []string {
+	if slices.Contains(a.repeatedRows, row) {
+		return []string{"repeated"}
+	}
+	return nil
+}
+
+func (a *RepeatedHeaderAnnotation) ColumnAttributes(col int) []string {
+	if slices.Contains(a.repeatedColumns, col) {
+		return []string{"repeated"}
+	}
+	return nil
+}
+
+func (a *RepeatedHeaderAnnotation) CellAttributes(col int, row int) []string {
+	return nil
+}
+
+func (a *RepeatedHeaderAnnotation) Definitions() string {
+	if len(a.repeatedRows) == 0 && len(a.repeatedColumns) == 0 {
+		return ""
+	}
+	var result strings.Builder
+	result.WriteString("<h2>Repeated Headers</h2>\n")
+	if len(a.repeatedRows) > 0 {
+		result.WriteString(fmt.Sprintf("<p>Header rows %d:%d are repeated at the top of the table.</p>\n", a.repeatedRows[0], a.repeatedRows[len(a.repeatedRows)-1]))
+	}
+	if len(a.repeatedColumns) > 0 {
+		startName, _ := excelize.ColumnNumberToName(a.repeatedColumns[0])
+		endName, _ := excelize.ColumnNumberToName(a.repeatedColumns[len(a.repeatedColumns)-1])
+		result.WriteString(fmt.Sprintf("<p>Row label columns %s:%s are repeated at the left of the table.</p>\n", startName, endName))
+	}
+	result.WriteString("<p>Their headers have the repeated attribute.</p>\n\n")
+	return result.String()
+}
+
+// lineState is the hidden and outline state of a row or a column
+type lineState struct {
+	hidden bool
+	level  int
+}
+
+// OutlineAnnotation marks hidden rows and columns and their outline levels on the headers, or omits hidden ones.
+type OutlineAnnotation struct {
+	skipHidden    bool
+	rowState      func(row int) (bool, int)
+	columnState   func(col int) (bool, int)
+	rows          map[int]lineState
+	columns       map[int]lineState
+	hiddenRows    []int
+	hiddenColumns []int
+	grouped       bool
+}
+
+// NewOutlineAnnotation annotates headers with the hidden and outline state given by rowState and columnState.
+// If skipHidden is true, hidden rows and columns are omitted instead.
+func NewOutlineAnnotation(rowState func(row int) (bool, int), columnState func(col int) (bool, int), skipHidden bool) *OutlineAnnotation {
+	return &OutlineAnnotation{
+		skipHidden:  skipHidden,
+		rowState:    rowState,
+		columnState: columnState,
+		rows:        make(map[int]lineState),
+		columns:     make(map[int]lineState),
+	}
+}
+
+func (a *OutlineAnnotation) row(row int) lineState {
+	state, exists := a.rows[row]
+	if !exists {
+		state.hidden, state.level = a.rowState(row)
+		a.rows[row] = state
+		if state.hidden {
+			a.hiddenRows = append(a.hiddenRows, row)
+		}
+		a.grouped = a.grouped || state.level > 0
+	}
+	return state
+}
+
+func (a *OutlineAnnotation) column(col int) lineState {
+	state, exists := a.columns[col]
+	if !exists {
+		state.hidden, state.level = a.columnState(col)
+		a.columns[col] = state
+		if state.hidden {
+			a.hiddenColumns = append(a.hiddenColumns, col)
+		}
+		a.grouped = a.grouped || state.level > 0
+	}
+	return state
+}
+
+func (a *OutlineAnnotation) IncludeRow(row int) bool {
+	return !a.skipHidden || !a.row(row).hidden
+}
+
+func (a *OutlineAnnotation) IncludeColumn(col int) bool {
+	return !a.skipHidden || !a.column(col).hidden
+}
+
+func (a *OutlineAnnotation) RowAttributes(row int) []string {
+	return a.row(row).attributes()
+}
+
+func (a *OutlineAnnotation) ColumnAttributes(col int) []string {
+	return a.column(col).attributes()
+}
+
+func (s lineState) attributes() []string {
+	var attributes []string
+	if s.hidden {
+		attributes = append(attributes, "hidden")
+	}
+	if s.level > 0 {
+		attributes = append(attributes, fmt.Sprintf("outline-level=\"%d\"", s.level))
+	}
+	return attributes
+}
+
+func (a *OutlineAnnotation) CellAttributes(col int, row int) []string {
+	return nil
+}
+
+func (a *OutlineAnnotation) Definitions() string {
+	if len(a.hiddenRows) == 0 && len(a.hiddenColumns) == 0 && !a.grouped {
+		return ""
+	}
+	var result strings.Builder
+	result.WriteString("<h2>Hidden Rows and Columns</h2>\n")
+	label := "Hidden"
+	if a.skipHidden {
+		label = "Omitted"
+		result.WriteString("<p>Hidden rows and columns are omitted. Headers of rows and columns in outline groups have the outline-level attribute.</p>\n")
+	} else {
+		result.WriteString("<p>Headers of hidden rows and columns have the hidden attribute, and those in outline groups have the outline-level attribute.</p>\n")
+	}
+	if len(a.hiddenRows) > 0 {
+		slices.Sort(a.hiddenRows)
+		result.WriteString(fmt.Sprintf("<p>%s rows: %s</p>\n", label, formatSpans(a.hiddenRows, strconv.Itoa)))
+	}
+	if len(a.hiddenColumns) > 0 {
+		slices.Sort(a.hiddenColumns)
+		result.WriteString(fmt.Sprintf("<p>%s columns: %s</p>\n", label, formatSpans(a.hiddenColumns, func(col int) string {
+			name, _ := excelize.ColumnNumberToName(col)
+			return name
+		})))
+	}
+	result.WriteString("\n")
+	return result.String()
+}
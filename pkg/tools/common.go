@@ -331,63 +331,218 @@ func convertToYAMLFlow(value any) string {
 	return yamlStr
 }
 
-func CreateHTMLTableOfValues(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int) (*string, error) {
-	return createHTMLTable(startCol, startRow, endCol, endRow, func(cellRange string) (string, error) {
-		return worksheet.GetValue(cellRange)
-	})
+func CreateHTMLTableOfValues(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int, annotations ...CellAnnotation) (*string, error) {
+	cells, err := readRangeCells(worksheet, startCol, startRow, endCol, endRow)
+	if err != nil {
+		return nil, err
+	}
+	return createHTMLTable(startCol, startRow, endCol, endRow, cells.value, annotations...)
 }
 
-func CreateHTMLTableOfFormula(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int) (*string, error) {
-	return createHTMLTable(startCol, startRow, endCol, endRow, func(cellRange string) (string, error) {
-		return worksheet.GetFormula(cellRange)
-	})
+func CreateHTMLTableOfFormula(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int, annotations ...CellAnnotation) (*string, error) {
+	cells, err := readRangeCells(worksheet, startCol, startRow, endCol, endRow)
+	if err != nil {
+		return nil, err
+	}
+	return createHTMLTable(startCol, startRow, endCol, endRow, cells.formula, annotations...)
 }
 
 // CreateHTMLTable creates a table data in HTML format
-func createHTMLTable(startCol int, startRow int, endCol int, endRow int, extractor func(cellRange string) (string, error)) (*string, error) {
-	return createHTMLTableWithStyle(startCol, startRow, endCol, endRow, extractor, nil)
+func createHTMLTable(startCol int, startRow int, endCol int, endRow int, extractor func(cellRange string) (string, error), annotations ...CellAnnotation) (*string, error) {
+	return createHTMLTableWithStyle(startCol, startRow, endCol, endRow, extractor, nil, annotations...)
 }
 
-func CreateHTMLTableOfValuesWithStyle(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int) (*string, error) {
-	return createHTMLTableWithStyle(startCol, startRow, endCol, endRow,
-		func(cellRange string) (string, error) {
-			return worksheet.GetValue(cellRange)
-		},
-		func(cellRange string) (*excel.CellStyle, error) {
-			return worksheet.GetCellStyle(cellRange)
-		})
+func CreateHTMLTableOfValuesWithStyle(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int, annotations ...CellAnnotation) (*string, error) {
+	cells, err := readRangeCells(worksheet, startCol, startRow, endCol, endRow)
+	if err != nil {
+		return nil, err
+	}
+	return createHTMLTableWithStyle(startCol, startRow, endCol, endRow, cells.value, cells.style, annotations...)
 }
 
-func CreateHTMLTableOfFormulaWithStyle(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int) (*string, error) {
-	return createHTMLTableWithStyle(startCol, startRow, endCol, endRow,
-		func(cellRange string) (string, error) {
-			return worksheet.GetFormula(cellRange)
-		},
-		func(cellRange string) (*excel.CellStyle, error) {
-			return worksheet.GetCellStyle(cellRange)
-		})
+func CreateHTMLTableOfFormulaWithStyle(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int, annotations ...CellAnnotation) (*string, error) {
+	cells, err := readRangeCells(worksheet, startCol, startRow, endCol, endRow)
+	if err != nil {
+		return nil, err
+	}
+	return createHTMLTableWithStyle(startCol, startRow, endCol, endRow, cells.formula, cells.style, annotations...)
+}
+
+// rangeCells holds cells of a range read at once, and provides extractors of createHTMLTableWithStyle.
+type rangeCells struct {
+	worksheet                          excel.Worksheet
+	startCol, startRow, endCol, endRow int
+	blocks                             []cellBlock
+	// styles caches styles by style ID
+	styles map[int]*excel.CellStyle
+}
+
+// cellBlock is cells of a rectangle read at once
+type cellBlock struct {
+	startCol, startRow int
+	cells              [][]excel.CellData
+}
+
+func (b cellBlock) contains(col int, row int) bool {
+	return b.startRow <= row && row-b.startRow < len(b.cells) && b.startCol <= col && col-b.startCol < len(b.cells[row-b.startRow])
+}
+
+func readRangeCells(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int) (*rangeCells, error) {
+	r := &rangeCells{
+		worksheet: worksheet,
+		startCol:  startCol,
+		startRow:  startRow,
+		endCol:    endCol,
+		endRow:    endRow,
+		styles:    make(map[int]*excel.CellStyle),
+	}
+	if err := r.read(startCol, startRow, endCol, endRow); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rangeCells) read(startCol int, startRow int, endCol int, endRow int) error {
+	startCell, err := excelize.CoordinatesToCellName(startCol, startRow)
+	if err != nil {
+		return err
+	}
+	endCell, err := excelize.CoordinatesToCellName(endCol, endRow)
+	if err != nil {
+		return err
+	}
+	cells, err := r.worksheet.GetRangeValues(startCell + ":" + endCell)
+	if err != nil {
+		return err
+	}
+	r.blocks = append(r.blocks, cellBlock{startCol: startCol, startRow: startRow, cells: cells})
+	return nil
+}
+
+func (r *rangeCells) cell(cellRange string) (excel.CellData, error) {
+	col, row, err := excelize.CellNameToCoordinates(cellRange)
+	if err != nil {
+		return excel.CellData{}, err
+	}
+	for _, block := range r.blocks {
+		if block.contains(col, row) {
+			return block.cells[row-block.startRow][col-block.startCol], nil
+		}
+	}
+	// cells out of the range, such as repeated headers, are read by the row or the column across the range
+	startCol, startRow, endCol, endRow := col, row, col, row
+	if r.startCol <= col && col <= r.endCol {
+		startCol, endCol = r.startCol, r.endCol
+	} else if r.startRow <= row && row <= r.endRow {
+		startRow, endRow = r.startRow, r.endRow
+	}
+	if err := r.read(startCol, startRow, endCol, endRow); err != nil {
+		return excel.CellData{}, err
+	}
+	block := r.blocks[len(r.blocks)-1]
+	return block.cells[row-block.startRow][col-block.startCol], nil
+}
+
+func (r *rangeCells) value(cellRange string) (string, error) {
+	cell, err := r.cell(cellRange)
+	return cell.Value, err
+}
+
+// formula returns the formula of the cell, or its value if it has no formula
+func (r *rangeCells) formula(cellRange string) (string, error) {
+	cell, err := r.cell(cellRange)
+	if cell.Formula == "" {
+		return cell.Value, err
+	}
+	return cell.Formula, err
 }
 
-func createHTMLTableWithStyle(startCol int, startRow int, endCol int, endRow int, extractor func(cellRange string) (string, error), styleExtractor func(cellRange string) (*excel.CellStyle, error)) (*string, error) {
+func (r *rangeCells) style(cellRange string) (*excel.CellStyle, error) {
+	cell, err := r.cell(cellRange)
+	if err != nil {
+		return nil, err
+	}
+	if cell.StyleID < 0 {
+		return r.worksheet.GetCellStyle(cellRange)
+	}
+	if style, exists := r.styles[cell.StyleID]; exists {
+		return style, nil
+	}
+	style, err := r.worksheet.GetCellStyle(cellRange)
+	if err != nil {
+		return nil, err
+	}
+	r.styles[cell.StyleID] = style
+	return style, nil
+}
+
+func createHTMLTableWithStyle(startCol int, startRow int, endCol int, endRow int, extractor func(cellRange string) (string, error), styleExtractor func(cellRange string) (*excel.CellStyle, error), annotations ...CellAnnotation) (*string, error) {
 	registry := NewStyleRegistry()
 
 	// データとスタイルを収集
 	var result strings.Builder
 	result.WriteString("<table>\n<tr><th></th>")
 
-	// 列アドレスの出力
+	var rowFilters []RowFilter
+	var columnFilters []ColumnFilter
+	var headerAnnotations []HeaderAnnotation
+	var headerRepeaters []HeaderRepeater
+	for _, annotation := range annotations {
+		if rowFilter, ok := annotation.(RowFilter); ok {
+			rowFilters = append(rowFilters, rowFilter)
+		}
+		if columnFilter, ok := annotation.(ColumnFilter); ok {
+			columnFilters = append(columnFilters, columnFilter)
+		}
+		if headerAnnotation, ok := annotation.(HeaderAnnotation); ok {
+			headerAnnotations = append(headerAnnotations, headerAnnotation)
+		}
+		if headerRepeater, ok := annotation.(HeaderRepeater); ok {
+			headerRepeaters = append(headerRepeaters, headerRepeater)
+		}
+	}
+	// headerTag returns a th tag with the attributes of the header annotations
+	headerTag := func(attributes func(annotation HeaderAnnotation) []string) string {
+		var attrs []string
+		for _, annotation := range headerAnnotations {
+			attrs = append(attrs, attributes(annotation)...)
+		}
+		if len(attrs) == 0 {
+			return "<th>"
+		}
+		return "<th " + strings.Join(attrs, " ") + ">"
+	}
+
+	// 列アドレスの出力 (repeated columns out of the range first, regardless of the column filters)
+	var columns []int
+	for _, headerRepeater := range headerRepeaters {
+		columns = append(columns, headerRepeater.RepeatedColumns(startCol)...)
+	}
+columns:
 	for col := startCol; col <= endCol; col++ {
+		for _, columnFilter := range columnFilters {
+			if !columnFilter.IncludeColumn(col) {
+				continue columns
+			}
+		}
+		columns = append(columns, col)
+	}
+	for _, col := range columns {
 		name, _ := excelize.ColumnNumberToName(col)
-		result.WriteString(fmt.Sprintf("<th>%s</th>", name))
+		result.WriteString(fmt.Sprintf("%s%s</th>", headerTag(func(annotation HeaderAnnotation) []string {
+			return annotation.ColumnAttributes(col)
+		}), name))
 	}
 	result.WriteString("</tr>\n")
 
 	// データの出力とスタイル登録
-	for row := startRow; row <= endRow; row++ {
+	writeRow := func(row int) {
 		result.WriteString("<tr>")
-		result.WriteString(fmt.Sprintf("<th>%d</th>", row))
+		result.WriteString(fmt.Sprintf("%s%d</th>", headerTag(func(annotation HeaderAnnotation) []string {
+			return annotation.RowAttributes(row)
+		}), row))
 
-		for col := startCol; col <= endCol; col++ {
+		for _, col := range columns {
 			axis, _ := excelize.CoordinatesToCellName(col, row)
 			value, _ := extractor(axis)
 
@@ -407,11 +562,33 @@ func createHTMLTableWithStyle(startCol int, startRow int, endCol int, endRow int
 			} else {
 				tdTag = "<td>"
 			}
+			var attributes []string
+			for _, annotation := range annotations {
+				attributes = append(attributes, annotation.CellAttributes(col, row)...)
+			}
+			if len(attributes) > 0 {
+				tdTag = strings.TrimSuffix(tdTag, ">") + " " + strings.Join(attributes, " ") + ">"
+			}
 
 			result.WriteString(fmt.Sprintf("%s%s</td>", tdTag, strings.ReplaceAll(html.EscapeString(value), "\n", "<br>")))
 		}
 		result.WriteString("</tr>\n")
 	}
+	// repeated rows out of the range are written first, regardless of the row filters
+	for _, headerRepeater := range headerRepeaters {
+		for _, row := range headerRepeater.RepeatedRows(startRow) {
+			writeRow(row)
+		}
+	}
+rows:
+	for row := startRow; row <= endRow; row++ {
+		for _, rowFilter := range rowFilters {
+			if !rowFilter.IncludeRow(row) {
+				continue rows
+			}
+		}
+		writeRow(row)
+	}
 
 	result.WriteString("</table>")
 
@@ -421,6 +598,9 @@ func createHTMLTableWithStyle(startCol int, startRow int, endCol int, endRow int
 	if styleDefinitions != "" {
 		finalResult.WriteString(styleDefinitions)
 	}
+	for _, annotation := range annotations {
+		finalResult.WriteString(annotation.Definitions())
+	}
 
 	finalResult.WriteString("<h2>Sheet Data</h2>\n")
 	finalResult.WriteString(result.String())
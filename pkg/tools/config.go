@@ -6,11 +6,13 @@ import (
 )
 
 type EnvConfig struct {
-	EXCEL_MCP_PAGING_CELLS_LIMIT int
+	EXCEL_MCP_PAGING_CELLS_LIMIT  int
+	EXCEL_MCP_PAGING_TOKENS_LIMIT int
 }
 
 var configSchema = z.Struct(z.Shape{
-	"EXCEL_MCP_PAGING_CELLS_LIMIT": z.Int().GT(0).Default(4000),
+	"EXCEL_MCP_PAGING_CELLS_LIMIT":  z.Int().GT(0).Default(4000),
+	"EXCEL_MCP_PAGING_TOKENS_LIMIT": z.Int().GTE(0).Default(0),
 })
 
 func LoadConfig() (EnvConfig, z.ZogIssueMap) {
@@ -0,0 +1,226 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"html"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelAddChartArguments struct {
+	FileAbsolutePath string                     `zog:"fileAbsolutePath"`
+	SheetName        string                     `zog:"sheetName"`
+	Type             excel.ChartType            `zog:"type"`
+	Series           []ExcelChartSeriesArgument `zog:"series"`
+	Title            string                     `zog:"title"`
+	XAxisTitle       string                     `zog:"xAxisTitle"`
+	YAxisTitle       string                     `zog:"yAxisTitle"`
+	LegendPosition   excel.ChartLegendPosition  `zog:"legendPosition"`
+	Anchor           string                     `zog:"anchor"`
+	Width            int                        `zog:"width"`
+	Height           int                        `zog:"height"`
+}
+
+type ExcelChartSeriesArgument struct {
+	Name       string          `zog:"name"`
+	Categories string          `zog:"categories"`
+	Values     string          `zog:"values"`
+	Type       excel.ChartType `zog:"type"`
+}
+
+var excelAddChartArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"type":             z.StringLike[excel.ChartType]().OneOf(excel.ChartTypeValues()).Required(),
+	"series": z.Slice(z.Struct(z.Shape{
+		"name":       z.String(),
+		"categories": z.String(),
+		"values":     z.String().Required(),
+		"type":       z.StringLike[excel.ChartType]().OneOf(excel.ChartTypeValues()),
+	})).Min(1).Required(),
+	"title":          z.String(),
+	"xAxisTitle":     z.String(),
+	"yAxisTitle":     z.String(),
+	"legendPosition": z.StringLike[excel.ChartLegendPosition]().OneOf(excel.ChartLegendPositionValues()).Default(excel.ChartLegendPositionBottom),
+	"anchor":         z.String().Required(),
+	"width":          z.Int().GTE(50).LTE(4000).Default(480),
+	"height":         z.Int().GTE(50).LTE(4000).Default(260),
+})
+
+func AddExcelAddChartTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_add_chart",
+		mcp.WithDescription("Add a chart (column, bar, line, pie, scatter, area or combo) of data ranges to the Excel sheet"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file where the chart is placed"),
+		),
+		mcp.WithString("type",
+			mcp.Required(),
+			mcp.Enum(toStrings(excel.ChartTypeValues())...),
+			mcp.Description("Type of the chart. For combo, specify the type of each series."),
+		),
+		mcp.WithArray("series",
+			mcp.Required(),
+			mcp.Description("Data series of the chart. Ranges without sheet name refer to the sheet of the chart."),
+			mcp.Items(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name": map[string]any{
+						"type":        "string",
+						"description": "Cell of the series name (e.g., \"B1\")",
+					},
+					"categories": map[string]any{
+						"type":        "string",
+						"description": "Range of the category labels, or X values for scatter (e.g., \"A2:A13\")",
+					},
+					"values": map[string]any{
+						"type":        "string",
+						"description": "Range of the values (e.g., \"B2:B13\")",
+					},
+					"type": map[string]any{
+						"type":        "string",
+						"enum":        []excel.ChartType{excel.ChartTypeColumn, excel.ChartTypeBar, excel.ChartTypeLine, excel.ChartTypeArea, excel.ChartTypeScatter},
+						"description": "Type of the series for combo [default: column]",
+					},
+				},
+				"required": []string{"values"},
+			}),
+		),
+		mcp.WithString("title",
+			mcp.Description("Title of the chart"),
+		),
+		mcp.WithString("xAxisTitle",
+			mcp.Description("Title of the category (horizontal) axis"),
+		),
+		mcp.WithString("yAxisTitle",
+			mcp.Description("Title of the value axis"),
+		),
+		mcp.WithString("legendPosition",
+			mcp.Enum(toStrings(excel.ChartLegendPositionValues())...),
+			mcp.Description("Position of the legend [default: bottom]"),
+		),
+		mcp.WithString("anchor",
+			mcp.Required(),
+			mcp.Description("Top-left cell where the chart is placed (e.g., \"E2\")"),
+		),
+		mcp.WithNumber("width",
+			mcp.Description("Width of the chart in pixels [default: 480]"),
+		),
+		mcp.WithNumber("height",
+			mcp.Description("Height of the chart in pixels [default: 260]"),
+		),
+	), handleAddChart)
+}
+
+func handleAddChart(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelAddChartArguments{}
+	if issues := excelAddChartArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return addChart(args)
+}
+
+func addChart(args ExcelAddChartArguments) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	anchorRange, err := excel.ResolveRange(workbook, worksheet, args.Anchor)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	anchorCol, anchorRow, _, _, err := excel.ParseRange(anchorRange)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	anchor, err := excelize.CoordinatesToCellName(anchorCol, anchorRow)
+	if err != nil {
+		return nil, err
+	}
+
+	chart := &excel.Chart{
+		Type:           args.Type,
+		Title:          args.Title,
+		XAxisTitle:     args.XAxisTitle,
+		YAxisTitle:     args.YAxisTitle,
+		LegendPosition: args.LegendPosition,
+		Anchor:         anchor,
+		Width:          args.Width,
+		Height:         args.Height,
+	}
+	for i, seriesArg := range args.Series {
+		series, err := buildChartSeries(seriesArg, args.SheetName)
+		if err != nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("series[%d]: %s", i, err.Error())), nil
+		}
+		if args.Type == excel.ChartTypeCombo {
+			series.Type = seriesArg.Type
+			if series.Type == "" {
+				series.Type = excel.ChartTypeColumn
+			}
+			if series.Type == excel.ChartTypeCombo || series.Type == excel.ChartTypePie {
+				return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("series[%d]: %s cannot be combined", i, series.Type)), nil
+			}
+		}
+		chart.Series = append(chart.Series, series)
+	}
+
+	if err := worksheet.AddChart(chart); err != nil {
+		return nil, err
+	}
+
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("Chart [%s] with %d series added at cell %s in sheet [%s].\n", args.Type, len(chart.Series), anchor, html.EscapeString(args.SheetName))
+	return mcp.NewToolResultText(result), nil
+}
+
+// buildChartSeries qualifies the references of the series with the sheet name
+func buildChartSeries(seriesArg ExcelChartSeriesArgument, sheetName string) (excel.ChartSeries, error) {
+	series := excel.ChartSeries{}
+	var err error
+	if seriesArg.Name != "" {
+		if _, ref := excel.SplitSheetName(seriesArg.Name); !isRange(ref) {
+			return series, fmt.Errorf("name must be a cell reference: %s", seriesArg.Name)
+		}
+		if series.Name, err = qualifyRefersTo(seriesArg.Name, sheetName); err != nil {
+			return series, err
+		}
+	}
+	if seriesArg.Categories != "" {
+		if series.Categories, err = qualifyRefersTo(seriesArg.Categories, sheetName); err != nil {
+			return series, err
+		}
+	}
+	if series.Values, err = qualifyRefersTo(seriesArg.Values, sheetName); err != nil {
+		return series, err
+	}
+	return series, nil
+}
+
+func isRange(ref string) bool {
+	_, _, _, _, err := excel.ParseRange(ref)
+	return err == nil
+}
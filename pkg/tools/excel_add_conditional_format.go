@@ -0,0 +1,267 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"html"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+)
+
+type ExcelAddConditionalFormatArguments struct {
+	FileAbsolutePath string                          `zog:"fileAbsolutePath"`
+	SheetName        string                          `zog:"sheetName"`
+	Range            string                          `zog:"range"`
+	Type             excel.ConditionalFormatType     `zog:"type"`
+	Operator         excel.ConditionalFormatOperator `zog:"operator"`
+	Formula1         string                          `zog:"formula1"`
+	Formula2         string                          `zog:"formula2"`
+	Rank             int                             `zog:"rank"`
+	Percent          bool                            `zog:"percent"`
+	Style            *excel.CellStyle                `zog:"style"`
+	MinColor         string                          `zog:"minColor"`
+	MidColor         string                          `zog:"midColor"`
+	MaxColor         string                          `zog:"maxColor"`
+	BarColor         string                          `zog:"barColor"`
+	IconStyle        string                          `zog:"iconStyle"`
+	ReverseIcons     bool                            `zog:"reverseIcons"`
+	StopIfTrue       bool                            `zog:"stopIfTrue"`
+}
+
+var excelAddConditionalFormatArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"range":            z.String().Required(),
+	"type":             z.StringLike[excel.ConditionalFormatType]().OneOf(excel.ConditionalFormatTypeValues()).Required(),
+	"operator":         z.StringLike[excel.ConditionalFormatOperator]().OneOf(excel.ConditionalFormatOperatorValues()),
+	"formula1":         z.String(),
+	"formula2":         z.String(),
+	"rank":             z.Int().GTE(1).LTE(1000).Default(10),
+	"percent":          z.Bool().Default(false),
+	"style": z.Ptr(z.Struct(z.Shape{
+		"font": z.Ptr(z.Struct(z.Shape{
+			"bold":      z.Ptr(z.Bool()),
+			"italic":    z.Ptr(z.Bool()),
+			"underline": z.Ptr(z.StringLike[excel.FontUnderline]().OneOf(excel.FontUnderlineValues())),
+			"strike":    z.Ptr(z.Bool()),
+			"color":     z.Ptr(z.String().Match(colorPattern)),
+		})),
+		"fill": z.Ptr(z.Struct(z.Shape{
+			"type":    z.StringLike[excel.FillType]().OneOf(excel.FillTypeValues()).Default(excel.FillTypePattern),
+			"pattern": z.StringLike[excel.FillPattern]().OneOf(excel.FillPatternValues()).Default(excel.FillPatternSolid),
+			"color":   z.Slice(z.String().Match(colorPattern)).Default([]string{}),
+		})),
+		"numFmt": z.Ptr(z.String()),
+	})),
+	"minColor":     z.String().Match(colorPattern).Default("#F8696B"),
+	"midColor":     z.String().Match(colorPattern),
+	"maxColor":     z.String().Match(colorPattern).Default("#63BE7B"),
+	"barColor":     z.String().Match(colorPattern).Default("#638EC6"),
+	"iconStyle":    z.String().OneOf(excel.IconStyleValues()).Default("3TrafficLights1"),
+	"reverseIcons": z.Bool().Default(false),
+	"stopIfTrue":   z.Bool().Default(false),
+})
+
+func AddExcelAddConditionalFormatTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_add_conditional_format",
+		mcp.WithDescription("Add a conditional formatting rule (cell value, formula, top/bottom N, duplicates, color scale, data bar or icon set) to a range in the Excel sheet"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("range",
+			mcp.Required(),
+			mcp.Description("Range of cells to format (e.g., \"B2:B100\", \"A1:B5,D1:E5\" or a defined name)"),
+		),
+		mcp.WithString("type",
+			mcp.Required(),
+			mcp.Enum(toStrings(excel.ConditionalFormatTypeValues())...),
+			mcp.Description("Type of the rule"),
+		),
+		mcp.WithString("operator",
+			mcp.Enum(toStrings(excel.ConditionalFormatOperatorValues())...),
+			mcp.Description("Comparison operator for cellValue [default: between]"),
+		),
+		mcp.WithString("formula1",
+			mcp.Description("For cellValue, the compared value, reference or formula (e.g., \"100\", \"$D$1\"). For formula, the formula which must be TRUE for the top-left cell of the range (e.g., \"$C2>$D2\")."),
+		),
+		mcp.WithString("formula2",
+			mcp.Description("Second value for between and notBetween"),
+		),
+		mcp.WithNumber("rank",
+			mcp.Description("Number of items for top and bottom [default: 10]"),
+		),
+		mcp.WithBoolean("percent",
+			mcp.Description("Treat rank as percentage for top and bottom"),
+		),
+		mcp.WithObject("style",
+			mcp.Description("Format applied to matched cells for cellValue, formula, top, bottom, duplicate and unique [default: light red fill with dark red text]"),
+			mcp.Properties(map[string]any{
+				"font": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"bold":   map[string]any{"type": "boolean"},
+						"italic": map[string]any{"type": "boolean"},
+						"underline": map[string]any{
+							"type": "string",
+							"enum": excel.FontUnderlineValues(),
+						},
+						"strike": map[string]any{"type": "boolean"},
+						"color": map[string]any{
+							"type":    "string",
+							"pattern": colorPattern.String(),
+						},
+					},
+				},
+				"fill": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"color": map[string]any{
+							"type": "array",
+							"items": map[string]any{
+								"type":    "string",
+								"pattern": colorPattern.String(),
+							},
+						},
+					},
+				},
+				"numFmt": map[string]any{
+					"type":        "string",
+					"description": "Custom number format string",
+				},
+			}),
+		),
+		mcp.WithString("minColor",
+			mcp.Description("Color of the minimum for colorScale [default: #F8696B]"),
+		),
+		mcp.WithString("midColor",
+			mcp.Description("Color of the 50th percentile for colorScale. A 3-color scale is used if specified."),
+		),
+		mcp.WithString("maxColor",
+			mcp.Description("Color of the maximum for colorScale [default: #63BE7B]"),
+		),
+		mcp.WithString("barColor",
+			mcp.Description("Color of the bars for dataBar [default: #638EC6]"),
+		),
+		mcp.WithString("iconStyle",
+			mcp.Enum(excel.IconStyleValues()...),
+			mcp.Description("Icons for iconSet [default: 3TrafficLights1]"),
+		),
+		mcp.WithBoolean("reverseIcons",
+			mcp.Description("Reverse the order of icons for iconSet"),
+		),
+		mcp.WithBoolean("stopIfTrue",
+			mcp.Description("Stop evaluating the following rules if this rule matches"),
+		),
+	), handleAddConditionalFormat)
+}
+
+func handleAddConditionalFormat(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelAddConditionalFormatArguments{}
+	if issues := excelAddConditionalFormatArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return addConditionalFormat(args)
+}
+
+func addConditionalFormat(args ExcelAddConditionalFormatArguments) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	rangeStr, err := excel.ResolveRange(workbook, worksheet, args.Range)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+
+	format, err := buildConditionalFormat(rangeStr, args)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	if err := worksheet.AddConditionalFormat(format); err != nil {
+		return nil, err
+	}
+
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("Conditional format [%s] added to range %s in sheet [%s].\n", args.Type, rangeStr, html.EscapeString(args.SheetName))
+	return mcp.NewToolResultText(result), nil
+}
+
+// buildConditionalFormat validates the combination of arguments and builds a rule
+func buildConditionalFormat(rangeStr string, args ExcelAddConditionalFormatArguments) (*excel.ConditionalFormat, error) {
+	format := &excel.ConditionalFormat{
+		Range:      rangeStr,
+		Type:       args.Type,
+		StopIfTrue: args.StopIfTrue,
+	}
+	switch args.Type {
+	case excel.ConditionalFormatTypeCellValue:
+		format.Operator = args.Operator
+		if format.Operator == "" {
+			format.Operator = excel.ConditionalFormatOperatorBetween
+		}
+		if args.Formula1 == "" {
+			return nil, fmt.Errorf("formula1 is required for cellValue rule")
+		}
+		format.Formula1 = args.Formula1
+		if format.Operator == excel.ConditionalFormatOperatorBetween || format.Operator == excel.ConditionalFormatOperatorNotBetween {
+			if args.Formula2 == "" {
+				return nil, fmt.Errorf("formula2 is required for %s operator", format.Operator)
+			}
+			format.Formula2 = args.Formula2
+		}
+	case excel.ConditionalFormatTypeFormula:
+		if args.Formula1 == "" {
+			return nil, fmt.Errorf("formula1 is required for formula rule")
+		}
+		format.Formula1 = args.Formula1
+	case excel.ConditionalFormatTypeTop, excel.ConditionalFormatTypeBottom:
+		format.Rank = args.Rank
+		format.Percent = args.Percent
+	case excel.ConditionalFormatTypeColorScale:
+		format.MinColor = args.MinColor
+		format.MidColor = args.MidColor
+		format.MaxColor = args.MaxColor
+	case excel.ConditionalFormatTypeDataBar:
+		format.BarColor = args.BarColor
+	case excel.ConditionalFormatTypeIconSet:
+		format.IconStyle = args.IconStyle
+		format.ReverseIcons = args.ReverseIcons
+	}
+
+	switch args.Type {
+	case excel.ConditionalFormatTypeColorScale, excel.ConditionalFormatTypeDataBar, excel.ConditionalFormatTypeIconSet:
+	default:
+		format.Style = args.Style
+		if format.Style == nil {
+			// Excel's default "Light Red Fill with Dark Red Text"
+			fontColor := "#9C0006"
+			format.Style = &excel.CellStyle{
+				Font: &excel.FontStyle{Color: &fontColor},
+				Fill: &excel.FillStyle{Type: excel.FillTypePattern, Pattern: excel.FillPatternSolid, Color: []string{"#FFC7CE"}},
+			}
+		}
+	}
+	return format, nil
+}
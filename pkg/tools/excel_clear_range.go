@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+)
+
+type ExcelClearRangeArguments struct {
+	FileAbsolutePath string            `zog:"fileAbsolutePath"`
+	SheetName        string            `zog:"sheetName"`
+	Range            string            `zog:"range"`
+	Modes            []excel.ClearMode `zog:"modes"`
+}
+
+var excelClearRangeArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"range":            z.String().Required(),
+	"modes":            z.Slice(z.StringLike[excel.ClearMode]().OneOf(excel.ClearModeValues())).Min(1).Required(),
+})
+
+func AddExcelClearRangeTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_clear_range",
+		mcp.WithDescription("Clear values, formulas, styles, comments, hyperlinks and/or data validation rules from a range in the Excel sheet"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("range",
+			mcp.Required(),
+			mcp.Description("Range of cells to clear (e.g., \"A1:C10\")"),
+		),
+		mcp.WithArray("modes",
+			mcp.Required(),
+			mcp.Description("What to clear. values clears cells without formula, formulas clears cells with formula, styles also unmerges merged cells within the range, and all clears everything."),
+			mcp.Items(map[string]any{
+				"type": "string",
+				"enum": excel.ClearModeValues(),
+			}),
+		),
+	), handleClearRange)
+}
+
+func handleClearRange(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelClearRangeArguments{}
+	if issues := excelClearRangeArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return clearRange(args.FileAbsolutePath, args.SheetName, args.Range, args.Modes)
+}
+
+func clearRange(fileAbsolutePath string, sheetName string, rangeStr string, modes []excel.ClearMode) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(fileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(sheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	rangeStr, err = excel.ResolveRange(workbook, worksheet, rangeStr)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	if _, _, _, _, err := excel.ParseRange(rangeStr); err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+
+	if err := worksheet.ClearRange(rangeStr, modes); err != nil {
+		return nil, err
+	}
+
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	usedRange, err := worksheet.GetDimention()
+	if err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("Cleared %s in range %s of sheet [%s].\n", strings.Join(toStrings(modes), ", "), rangeStr, html.EscapeString(sheetName))
+	result += fmt.Sprintf("The used range of the sheet is %s.\n", usedRange)
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,160 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"html"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelCopyRangeArguments struct {
+	FileAbsolutePath     string         `zog:"fileAbsolutePath"`
+	SheetName            string         `zog:"sheetName"`
+	Range                string         `zog:"range"`
+	DestinationSheetName string         `zog:"destinationSheetName"`
+	Destination          string         `zog:"destination"`
+	Mode                 excel.CopyMode `zog:"mode"`
+	Transpose            bool           `zog:"transpose"`
+	Move                 bool           `zog:"move"`
+}
+
+var excelCopyRangeArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath":     z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":            z.String().Required(),
+	"range":                z.String().Required(),
+	"destinationSheetName": z.String(),
+	"destination":          z.String().Required(),
+	"mode":                 z.StringLike[excel.CopyMode]().OneOf(excel.CopyModeValues()).Default(excel.CopyModeAll),
+	"transpose":            z.Bool().Default(false),
+	"move":                 z.Bool().Default(false),
+})
+
+func AddExcelCopyRangeTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_copy_range",
+		mcp.WithDescription("Copy or move a range to another location in the same or another sheet of the Excel file"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name of the source range"),
+		),
+		mcp.WithString("range",
+			mcp.Required(),
+			mcp.Description("Source range (e.g., \"A1:C10\")"),
+		),
+		mcp.WithString("destinationSheetName",
+			mcp.Description("Sheet name of the destination [default: sheetName]"),
+		),
+		mcp.WithString("destination",
+			mcp.Required(),
+			mcp.Description("Top-left cell of the destination (e.g., \"E1\")"),
+		),
+		mcp.WithString("mode",
+			mcp.Enum(toStrings(excel.CopyModeValues())...),
+			mcp.Description("What to copy: all (values, formulas and formats), values (results of formulas as values), formulas (values and formulas without formats) or formats. Relative references in formulas are adjusted to the destination. [default: all]"),
+		),
+		mcp.WithBoolean("transpose",
+			mcp.Description("Swap rows and columns [default: false]"),
+		),
+		mcp.WithBoolean("move",
+			mcp.Description("Move the range as cut and paste does: the source is cleared and formulas are not adjusted. Only with mode all and without transpose. [default: false]"),
+		),
+	), handleCopyRange)
+}
+
+func handleCopyRange(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelCopyRangeArguments{}
+	if issues := excelCopyRangeArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return copyRange(args)
+}
+
+func copyRange(args ExcelCopyRangeArguments) (*mcp.CallToolResult, error) {
+	if args.Move && (args.Mode != excel.CopyModeAll || args.Transpose) {
+		return imcp.NewToolResultInvalidArgumentError("move can be used only with mode all and without transpose"), nil
+	}
+	if args.DestinationSheetName == "" {
+		args.DestinationSheetName = args.SheetName
+	}
+
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+	destWorksheet, err := workbook.FindSheet(args.DestinationSheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer destWorksheet.Release()
+
+	rangeStr, err := excel.ResolveRange(workbook, worksheet, args.Range)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	startCol, startRow, endCol, endRow, err := excel.ParseRange(rangeStr)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	destinationRange, err := excel.ResolveRange(workbook, destWorksheet, args.Destination)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	destCol, destRow, _, _, err := excel.ParseRange(destinationRange)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	width, height := endCol-startCol+1, endRow-startRow+1
+	if args.Transpose {
+		width, height = height, width
+	}
+	destination, err := excelize.CoordinatesToCellName(destCol, destRow)
+	if err != nil {
+		return nil, err
+	}
+	destinationEnd, err := excelize.CoordinatesToCellName(destCol+width-1, destRow+height-1)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("destination exceeds the sheet: %s", args.Destination)), nil
+	}
+
+	options := excel.CopyRangeOptions{
+		Mode:      args.Mode,
+		Transpose: args.Transpose,
+		Move:      args.Move,
+	}
+	if err := worksheet.CopyRange(rangeStr, args.DestinationSheetName, destination, options); err != nil {
+		return nil, err
+	}
+
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	verb := "Copied"
+	if args.Move {
+		verb = "Moved"
+	}
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("%s %s of range %s in sheet [%s] to range %s:%s in sheet [%s]", verb, args.Mode, rangeStr, html.EscapeString(args.SheetName), destination, destinationEnd, html.EscapeString(args.DestinationSheetName))
+	if args.Transpose {
+		result += " with rows and columns transposed"
+	}
+	result += ".\n"
+	return mcp.NewToolResultText(result), nil
+}
@@ -4,29 +4,34 @@ import (
 	"context"
 	"fmt"
 	"html"
+	"path/filepath"
+	"strings"
 
 	z "github.com/Oudwins/zog"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
 	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+	"github.com/xuri/excelize/v2"
 )
 
 type ExcelCopySheetArguments struct {
-	FileAbsolutePath string `zog:"fileAbsolutePath"`
-	SrcSheetName     string `zog:"srcSheetName"`
-	DstSheetName     string `zog:"dstSheetName"`
+	FileAbsolutePath    string `zog:"fileAbsolutePath"`
+	SrcSheetName        string `zog:"srcSheetName"`
+	DstSheetName        string `zog:"dstSheetName"`
+	DstFileAbsolutePath string `zog:"dstFileAbsolutePath"`
 }
 
 var excelCopySheetArgumentsSchema = z.Struct(z.Shape{
-	"fileAbsolutePath": z.String().Required(),
-	"srcSheetName":     z.String().Required(),
-	"dstSheetName":     z.String().Required(),
+	"fileAbsolutePath":    z.String().Required(),
+	"srcSheetName":        z.String().Required(),
+	"dstSheetName":        z.String().Required(),
+	"dstFileAbsolutePath": z.String().Test(AbsolutePathTest()),
 })
 
 func AddExcelCopySheetTool(server *server.MCPServer) {
 	server.AddTool(mcp.NewTool("excel_copy_sheet",
-		mcp.WithDescription("Copy existing sheet to a new sheet"),
+		mcp.WithDescription("Copy existing sheet to a new sheet in the same or another Excel file"),
 		mcp.WithString("fileAbsolutePath",
 			mcp.Required(),
 			mcp.Description("Absolute path to the Excel file"),
@@ -39,6 +44,9 @@ func AddExcelCopySheetTool(server *server.MCPServer) {
 			mcp.Required(),
 			mcp.Description("Sheet name to be copied"),
 		),
+		mcp.WithString("dstFileAbsolutePath",
+			mcp.Description("Absolute path to the Excel file to copy the sheet into. Values, formulas, styles, column widths, merged cells and tables are copied, and references to sheets which do not exist in the file are reported. [default: fileAbsolutePath]"),
+		),
 	), handleCopySheet)
 }
 
@@ -47,6 +55,9 @@ func handleCopySheet(ctx context.Context, request mcp.CallToolRequest) (*mcp.Cal
 	if issues := excelCopySheetArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
 		return imcp.NewToolResultZogIssueMap(issues), nil
 	}
+	if args.DstFileAbsolutePath != "" && filepath.Clean(args.DstFileAbsolutePath) != filepath.Clean(args.FileAbsolutePath) {
+		return copySheetToWorkbook(args.FileAbsolutePath, args.SrcSheetName, args.DstFileAbsolutePath, args.DstSheetName)
+	}
 	return copySheet(args.FileAbsolutePath, args.SrcSheetName, args.DstSheetName)
 }
 
@@ -79,3 +90,121 @@ func copySheet(fileAbsolutePath string, srcSheetName string, dstSheetName string
 	result += fmt.Sprintf("Sheet [%s] copied to [%s].\n", html.EscapeString(srcSheetName), html.EscapeString(dstSheetName))
 	return mcp.NewToolResultText(result), nil
 }
+
+func copySheetToWorkbook(fileAbsolutePath string, srcSheetName string, dstFileAbsolutePath string, dstSheetName string) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(fileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	dstWorkbook, dstRelease, err := excel.OpenFile(dstFileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer dstRelease()
+	if workbook.GetBackendName() != dstWorkbook.GetBackendName() {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("the files are opened by different backends (%s and %s). Open both files in Excel, or close both.", workbook.GetBackendName(), dstWorkbook.GetBackendName())), nil
+	}
+
+	srcSheet, err := workbook.FindSheet(srcSheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer srcSheet.Release()
+	srcSheetName, err = srcSheet.Name()
+	if err != nil {
+		return nil, err
+	}
+	if dstSheet, err := dstWorkbook.FindSheet(dstSheetName); err == nil {
+		dstSheet.Release()
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("sheet already exists in the destination file: %s", dstSheetName)), nil
+	}
+
+	missingSheetNames, err := findMissingSheetReferences(srcSheet, dstWorkbook, dstSheetName)
+	if err != nil {
+		return nil, err
+	}
+	srcTables, err := srcSheet.GetTables()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := workbook.CopySheetToWorkbook(srcSheetName, dstWorkbook, dstSheetName); err != nil {
+		return nil, err
+	}
+	if err := dstWorkbook.Save(); err != nil {
+		return nil, err
+	}
+
+	dstSheet, err := dstWorkbook.FindSheet(dstSheetName)
+	if err != nil {
+		return nil, err
+	}
+	defer dstSheet.Release()
+	dstTables, err := dstSheet.GetTables()
+	if err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("Sheet [%s] copied to [%s] in %s.\n", html.EscapeString(srcSheetName), html.EscapeString(dstSheetName), html.EscapeString(dstFileAbsolutePath))
+	// tables are listed in the order they are defined
+	for i := range min(len(srcTables), len(dstTables)) {
+		if srcTables[i].Name != dstTables[i].Name {
+			result += fmt.Sprintf("Table %s is renamed to %s, as the name is already used in the destination file. Structured references to the table need to be updated.\n", html.EscapeString(srcTables[i].Name), html.EscapeString(dstTables[i].Name))
+		}
+	}
+	if len(missingSheetNames) > 0 {
+		quoted := make([]string, len(missingSheetNames))
+		for i, name := range missingSheetNames {
+			quoted[i] = fmt.Sprintf("[%s]", html.EscapeString(name))
+		}
+		result += fmt.Sprintf("Formulas of the sheet refer to sheets which do not exist in the destination file: %s. These references are broken until the sheets are created.\n", strings.Join(quoted, ", "))
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+// findMissingSheetReferences returns the sheet names referenced by formulas of the worksheet which do not exist in the workbook.
+// destSheetName is the name of the sheet to be created in the workbook.
+func findMissingSheetReferences(worksheet excel.Worksheet, workbook excel.Excel, destSheetName string) ([]string, error) {
+	dimension, err := worksheet.GetDimention()
+	if err != nil {
+		return nil, err
+	}
+	startCol, startRow, endCol, endRow, err := excel.ParseRange(dimension)
+	if err != nil {
+		// empty sheet
+		return nil, nil
+	}
+	var missing []string
+	checked := map[string]bool{strings.ToUpper(destSheetName): true}
+	for row := startRow; row <= endRow; row++ {
+		for col := startCol; col <= endCol; col++ {
+			cell, err := excelize.CoordinatesToCellName(col, row)
+			if err != nil {
+				return nil, err
+			}
+			formula, err := worksheet.GetFormula(cell)
+			if err != nil {
+				return nil, err
+			}
+			if !isFormula(formula) {
+				continue
+			}
+			for _, name := range excel.FormulaSheetNames(formula) {
+				if checked[strings.ToUpper(name)] {
+					continue
+				}
+				checked[strings.ToUpper(name)] = true
+				sheet, err := workbook.FindSheet(name)
+				if err != nil {
+					missing = append(missing, name)
+					continue
+				}
+				sheet.Release()
+			}
+		}
+	}
+	return missing, nil
+}
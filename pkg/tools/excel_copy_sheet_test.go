@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestCopySheetToWorkbook(t *testing.T) {
+	tests := []struct {
+		name        string
+		formula     string
+		wantNotices []string
+		wantAbsent  []string
+	}{
+		{
+			name:    "missing sheet references",
+			formula: "Inputs!A1+'Tax Rates'!B2+Dest!A1",
+			wantNotices: []string{
+				"Table Data is renamed to Data_2",
+				"refer to sheets which do not exist in the destination file: [Inputs], [Tax Rates].",
+			},
+			wantAbsent: []string{"[Dest]"},
+		},
+		{
+			name:        "existing sheet references",
+			formula:     "Dest!A1*2",
+			wantNotices: []string{"Table Data is renamed to Data_2"},
+			wantAbsent:  []string{"do not exist"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			srcFile := filepath.Join(dir, "src.xlsx")
+			src := excelize.NewFile()
+			defer src.Close()
+			if err := src.SetSheetRow("Sheet1", "A1", &[]any{"Name", "Value"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := src.SetSheetRow("Sheet1", "A2", &[]any{"a", 1}); err != nil {
+				t.Fatal(err)
+			}
+			if err := src.SetCellFormula("Sheet1", "C2", tt.formula); err != nil {
+				t.Fatal(err)
+			}
+			if err := src.AddTable("Sheet1", &excelize.Table{Range: "A1:B2", Name: "Data"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := src.SetSheetDimension("Sheet1", "A1:C2"); err != nil {
+				t.Fatal(err)
+			}
+			if err := src.SaveAs(srcFile); err != nil {
+				t.Fatal(err)
+			}
+			dstFile := filepath.Join(dir, "dst.xlsx")
+			dst := excelize.NewFile()
+			defer dst.Close()
+			if err := dst.SetSheetName("Sheet1", "Dest"); err != nil {
+				t.Fatal(err)
+			}
+			if err := dst.SetSheetRow("Dest", "A1", &[]any{"Key", "Count"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := dst.AddTable("Dest", &excelize.Table{Range: "A1:B2", Name: "Data"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := dst.SaveAs(dstFile); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := copySheetToWorkbook(srcFile, "Sheet1", dstFile, "Copied")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.IsError {
+				t.Fatalf("IsError = true: %v", result.Content)
+			}
+			text := result.Content[0].(mcp.TextContent).Text
+			for _, notice := range tt.wantNotices {
+				if !strings.Contains(text, notice) {
+					t.Errorf("result does not contain %q:\n%s", notice, text)
+				}
+			}
+			for _, absent := range tt.wantAbsent {
+				if strings.Contains(text, absent) {
+					t.Errorf("result contains %q:\n%s", absent, text)
+				}
+			}
+
+			copied, err := excelize.OpenFile(dstFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer copied.Close()
+			formula, err := copied.GetCellFormula("Copied", "C2")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if formula != tt.formula {
+				t.Errorf("formula = %q, want %q", formula, tt.formula)
+			}
+		})
+	}
+}
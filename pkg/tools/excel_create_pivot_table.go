@@ -0,0 +1,271 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelCreatePivotTableArguments struct {
+	FileAbsolutePath string                         `zog:"fileAbsolutePath"`
+	SheetName        string                         `zog:"sheetName"`
+	Destination      string                         `zog:"destination"`
+	Source           string                         `zog:"source"`
+	Name             string                         `zog:"name"`
+	Rows             []string                       `zog:"rows"`
+	Columns          []string                       `zog:"columns"`
+	Filters          []string                       `zog:"filters"`
+	Values           []ExcelPivotValueFieldArgument `zog:"values"`
+}
+
+type ExcelPivotValueFieldArgument struct {
+	Field    string              `zog:"field"`
+	Function excel.PivotFunction `zog:"function"`
+	Name     string              `zog:"name"`
+}
+
+var excelCreatePivotTableArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"destination":      z.String().Required(),
+	"source":           z.String().Required(),
+	"name":             z.String(),
+	"rows":             z.Slice(z.String()),
+	"columns":          z.Slice(z.String()),
+	"filters":          z.Slice(z.String()),
+	"values": z.Slice(z.Struct(z.Shape{
+		"field":    z.String().Required(),
+		"function": z.StringLike[excel.PivotFunction]().OneOf(excel.PivotFunctionValues()).Default(excel.PivotFunctionSum),
+		"name":     z.String(),
+	})),
+})
+
+// pivotFunctionCaptions are the captions of functions which Excel uses for the default names of value fields
+var pivotFunctionCaptions = map[excel.PivotFunction]string{
+	excel.PivotFunctionSum:       "Sum",
+	excel.PivotFunctionCount:     "Count",
+	excel.PivotFunctionAverage:   "Average",
+	excel.PivotFunctionMax:       "Max",
+	excel.PivotFunctionMin:       "Min",
+	excel.PivotFunctionProduct:   "Product",
+	excel.PivotFunctionCountNums: "Count",
+	excel.PivotFunctionStdDev:    "StdDev",
+	excel.PivotFunctionStdDevp:   "StdDevp",
+	excel.PivotFunctionVar:       "Var",
+	excel.PivotFunctionVarp:      "Varp",
+}
+
+func AddExcelCreatePivotTableTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_create_pivot_table",
+		mcp.WithDescription("Create a pivot table which summarizes a data range or table in the Excel sheet. Existing pivot tables and their layouts are listed in the output of excel_describe_sheets."),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name where the pivot table is created"),
+		),
+		mcp.WithString("destination",
+			mcp.Required(),
+			mcp.Description("Top-left cell of the pivot table (e.g., \"H2\"). Filter fields are placed from this cell, above the pivot table."),
+		),
+		mcp.WithString("source",
+			mcp.Required(),
+			mcp.Description("Source data with a header row: a range (e.g., \"A1:E31\", \"Data!A1:E31\"), a table name or a defined name. A range without sheet name refers to the sheet of the pivot table."),
+		),
+		mcp.WithString("name",
+			mcp.Description("Name of the pivot table [default: generated name]"),
+		),
+		mcp.WithArray("rows",
+			mcp.Description("Fields (header names in the source) shown as rows, outermost first"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("columns",
+			mcp.Description("Fields shown as columns, outermost first"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("filters",
+			mcp.Description("Fields used as report filters"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("values",
+			mcp.Description("Fields summarized in the pivot table"),
+			mcp.Items(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"field": map[string]any{
+						"type":        "string",
+						"description": "Field to summarize",
+					},
+					"function": map[string]any{
+						"type":        "string",
+						"enum":        excel.PivotFunctionValues(),
+						"description": "Aggregation function [default: sum]",
+					},
+					"name": map[string]any{
+						"type":        "string",
+						"description": "Caption of the value field [default: e.g. \"Sum of Sales\"]",
+					},
+				},
+				"required": []string{"field"},
+			}),
+		),
+	), handleCreatePivotTable)
+}
+
+func handleCreatePivotTable(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelCreatePivotTableArguments{}
+	if issues := excelCreatePivotTableArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return createPivotTable(args)
+}
+
+func createPivotTable(args ExcelCreatePivotTableArguments) (*mcp.CallToolResult, error) {
+	if len(args.Rows) == 0 && len(args.Columns) == 0 && len(args.Values) == 0 {
+		return imcp.NewToolResultInvalidArgumentError("at least one of rows, columns or values is required"), nil
+	}
+
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	destinationRange, err := excel.ResolveRange(workbook, worksheet, args.Destination)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	destCol, destRow, _, _, err := excel.ParseRange(destinationRange)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	destination, err := excelize.CoordinatesToCellName(destCol, destRow)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceSheetName, sourceRange, err := excel.ResolveSourceRange(workbook, args.SheetName, args.Source)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	srcStartCol, srcStartRow, srcEndCol, srcEndRow, err := excel.ParseRange(sourceRange)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	if srcStartRow == srcEndRow {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("source must have a header row and data rows: %s", sourceRange)), nil
+	}
+	if strings.EqualFold(sourceSheetName, args.SheetName) &&
+		srcStartCol <= destCol && destCol <= srcEndCol && srcStartRow <= destRow && destRow <= srcEndRow {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("destination %s overlaps the source %s", destination, sourceRange)), nil
+	}
+
+	header, err := readSourceHeader(workbook, sourceSheetName, srcStartRow, srcStartCol, srcEndCol)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := header[""]; ok {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("header row of the source has an empty cell: %s", sourceRange)), nil
+	}
+	axes := []struct {
+		name   string
+		fields []string
+	}{
+		{"rows", args.Rows},
+		{"columns", args.Columns},
+		{"filters", args.Filters},
+	}
+	axisOfField := map[string]string{}
+	for _, axis := range axes {
+		for _, field := range axis.fields {
+			if _, ok := header[field]; !ok {
+				return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("field not found in the header of the source: %s", field)), nil
+			}
+			if other, ok := axisOfField[field]; ok {
+				return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("field %s cannot be in both %s and %s", field, other, axis.name)), nil
+			}
+			axisOfField[field] = axis.name
+		}
+	}
+
+	pivotTable := &excel.PivotTable{
+		Name:    args.Name,
+		Range:   destination,
+		Rows:    args.Rows,
+		Columns: args.Columns,
+		Filters: args.Filters,
+	}
+	// names are passed as is, so that the pivot table follows the source
+	pivotTable.SourceRange = args.Source
+	if _, _, _, _, _, err := excel.ParseSheetRange(args.Source); err == nil {
+		pivotTable.SourceRange = excel.QuoteSheetName(sourceSheetName) + "!" + sourceRange
+	}
+	for _, value := range args.Values {
+		if _, ok := header[value.Field]; !ok {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("field not found in the header of the source: %s", value.Field)), nil
+		}
+		name := value.Name
+		if name == "" {
+			name = fmt.Sprintf("%s of %s", pivotFunctionCaptions[value.Function], value.Field)
+		}
+		pivotTable.Values = append(pivotTable.Values, excel.PivotValueField{
+			Field:    value.Field,
+			Function: value.Function,
+			Name:     name,
+		})
+	}
+
+	if err := worksheet.AddPivotTable(pivotTable); err != nil {
+		return nil, err
+	}
+
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("Pivot table [%s] of %s created in range %s of sheet [%s].\n", html.EscapeString(pivotTable.Name), html.EscapeString(pivotTable.SourceRange), pivotTable.Range, html.EscapeString(args.SheetName))
+	if workbook.GetBackendName() == "excelize" {
+		result += "The values of the pivot table are computed when the file is opened in Excel. The range is estimated from the source data.\n"
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+// readSourceHeader reads the field names in the header row of the source data.
+func readSourceHeader(workbook excel.Excel, sheetName string, row int, startCol int, endCol int) (map[string]struct{}, error) {
+	worksheet, err := workbook.FindSheet(sheetName)
+	if err != nil {
+		return nil, err
+	}
+	defer worksheet.Release()
+	header := map[string]struct{}{}
+	for col := startCol; col <= endCol; col++ {
+		cell, err := excelize.CoordinatesToCellName(col, row)
+		if err != nil {
+			return nil, err
+		}
+		value, err := worksheet.GetValue(cell)
+		if err != nil {
+			return nil, err
+		}
+		header[value] = struct{}{}
+	}
+	return header, nil
+}
@@ -38,7 +38,7 @@ func AddExcelCreateTableTool(server *server.MCPServer) {
 			mcp.Description("Sheet name where the table is created"),
 		),
 		mcp.WithString("range",
-			mcp.Description("Range to be a table (e.g., \"A1:C10\")"),
+			mcp.Description("Range to be a table (e.g., \"A1:C10\", \"A:C\" or a defined name)"),
 		),
 		mcp.WithString("tableName",
 			mcp.Required(),
@@ -67,6 +67,15 @@ func createTable(fileAbsolutePath string, sheetName string, tableRange string, t
 		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
 	}
 	defer worksheet.Release()
+	if tableRange != "" {
+		tableRange, err = excel.ResolveRange(workbook, worksheet, tableRange)
+		if err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+		if _, _, _, _, err := excel.ParseRange(tableRange); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+	}
 	if err := worksheet.AddTable(tableRange, tableName); err != nil {
 		return nil, err
 	}
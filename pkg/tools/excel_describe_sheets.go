@@ -9,6 +9,7 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/wxyzh/excel-mcp-server/pkg/excel"
 	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+	"github.com/xuri/excelize/v2"
 )
 
 type ExcelDescribeSheetsArguments struct {
@@ -39,15 +40,27 @@ func handleDescribeSheets(ctx context.Context, request mcp.CallToolRequest) (*mc
 }
 
 type Response struct {
-	Backend string      `json:"backend"`
-	Sheets  []Worksheet `json:"sheets"`
+	Backend      string        `json:"backend"`
+	Sheets       []Worksheet   `json:"sheets"`
+	DefinedNames []DefinedName `json:"definedNames"`
 }
 type Worksheet struct {
-	Name         string       `json:"name"`
-	UsedRange    string       `json:"usedRange"`
-	Tables       []Table      `json:"tables"`
-	PivotTables  []PivotTable `json:"pivotTables"`
-	PagingRanges []string     `json:"pagingRanges"`
+	Name            string           `json:"name"`
+	UsedRange       string           `json:"usedRange"`
+	Tables          []Table          `json:"tables"`
+	PivotTables     []PivotTable     `json:"pivotTables"`
+	DefinedNames    []DefinedName    `json:"definedNames"`
+	DataValidations []DataValidation `json:"dataValidations"`
+	Charts          []Chart          `json:"charts"`
+	AutoFilter      *AutoFilter      `json:"autoFilter,omitempty"`
+	DataRegions     []DataRegion     `json:"dataRegions,omitempty"`
+	PagingRanges    []string         `json:"pagingRanges"`
+}
+
+// DataRegion is a block of data separated from others by blank rows and columns
+type DataRegion struct {
+	Range  string   `json:"range"`
+	Labels []string `json:"labels,omitempty"`
 }
 
 type Table struct {
@@ -56,8 +69,77 @@ type Table struct {
 }
 
 type PivotTable struct {
-	Name  string `json:"name"`
-	Range string `json:"range"`
+	Name        string            `json:"name"`
+	Range       string            `json:"range"`
+	SourceRange string            `json:"sourceRange"`
+	Rows        []string          `json:"rows,omitempty"`
+	Columns     []string          `json:"columns,omitempty"`
+	Filters     []string          `json:"filters,omitempty"`
+	Values      []PivotValueField `json:"values,omitempty"`
+}
+
+type PivotValueField struct {
+	Field    string `json:"field"`
+	Function string `json:"function"`
+	Name     string `json:"name"`
+}
+
+type DataValidation struct {
+	Range        string   `json:"range"`
+	Type         string   `json:"type"`
+	Operator     string   `json:"operator,omitempty"`
+	Formula1     string   `json:"formula1,omitempty"`
+	Formula2     string   `json:"formula2,omitempty"`
+	List         []string `json:"list,omitempty"`
+	AllowBlank   bool     `json:"allowBlank"`
+	InputTitle   string   `json:"inputTitle,omitempty"`
+	InputMessage string   `json:"inputMessage,omitempty"`
+	ErrorStyle   string   `json:"errorStyle,omitempty"`
+	ErrorTitle   string   `json:"errorTitle,omitempty"`
+	ErrorMessage string   `json:"errorMessage,omitempty"`
+}
+
+type Chart struct {
+	Name           string        `json:"name"`
+	Type           string        `json:"type"`
+	Title          string        `json:"title,omitempty"`
+	XAxisTitle     string        `json:"xAxisTitle,omitempty"`
+	YAxisTitle     string        `json:"yAxisTitle,omitempty"`
+	LegendPosition string        `json:"legendPosition"`
+	Anchor         string        `json:"anchor"`
+	Series         []ChartSeries `json:"series"`
+}
+
+type ChartSeries struct {
+	Name       string `json:"name,omitempty"`
+	Categories string `json:"categories,omitempty"`
+	Values     string `json:"values"`
+	Type       string `json:"type,omitempty"`
+}
+
+type AutoFilter struct {
+	Range   string         `json:"range"`
+	Columns []FilterColumn `json:"columns"`
+}
+
+type FilterColumn struct {
+	Column     string            `json:"column"`
+	Values     []string          `json:"values,omitempty"`
+	Blank      bool              `json:"blank,omitempty"`
+	Conditions []FilterCondition `json:"conditions,omitempty"`
+	And        bool              `json:"and,omitempty"`
+	Other      string            `json:"other,omitempty"`
+}
+
+type FilterCondition struct {
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+type DefinedName struct {
+	Name     string `json:"name"`
+	RefersTo string `json:"refersTo"`
+	Comment  string `json:"comment,omitempty"`
 }
 
 func describeSheets(fileAbsolutePath string) (*mcp.CallToolResult, error) {
@@ -75,6 +157,10 @@ func describeSheets(fileAbsolutePath string) (*mcp.CallToolResult, error) {
 	if err != nil {
 		return nil, err
 	}
+	definedNames, err := workbook.GetDefinedNames()
+	if err != nil {
+		return nil, err
+	}
 	worksheets := make([]Worksheet, len(sheetList))
 	for i, sheet := range sheetList {
 		defer sheet.Release()
@@ -103,28 +189,153 @@ func describeSheets(fileAbsolutePath string) (*mcp.CallToolResult, error) {
 		}
 		pivotTableList := make([]PivotTable, len(pivotTables))
 		for i, pivotTable := range pivotTables {
+			valueList := make([]PivotValueField, len(pivotTable.Values))
+			for j, value := range pivotTable.Values {
+				valueList[j] = PivotValueField{
+					Field:    value.Field,
+					Function: value.Function.String(),
+					Name:     value.Name,
+				}
+			}
 			pivotTableList[i] = PivotTable{
-				Name:  pivotTable.Name,
-				Range: pivotTable.Range,
+				Name:        pivotTable.Name,
+				Range:       pivotTable.Range,
+				SourceRange: pivotTable.SourceRange,
+				Rows:        pivotTable.Rows,
+				Columns:     pivotTable.Columns,
+				Filters:     pivotTable.Filters,
+				Values:      valueList,
+			}
+		}
+		dataValidations, err := sheet.GetDataValidations()
+		if err != nil {
+			return nil, err
+		}
+		dataValidationList := make([]DataValidation, len(dataValidations))
+		for i, dv := range dataValidations {
+			dataValidationList[i] = DataValidation{
+				Range:        dv.Range,
+				Type:         dv.Type.String(),
+				Operator:     dv.Operator.String(),
+				Formula1:     dv.Formula1,
+				Formula2:     dv.Formula2,
+				List:         dv.List,
+				AllowBlank:   dv.AllowBlank,
+				InputTitle:   dv.InputTitle,
+				InputMessage: dv.InputMessage,
+				ErrorStyle:   dv.ErrorStyle.String(),
+				ErrorTitle:   dv.ErrorTitle,
+				ErrorMessage: dv.ErrorMessage,
+			}
+		}
+		charts, err := sheet.GetCharts()
+		if err != nil {
+			return nil, err
+		}
+		chartList := make([]Chart, len(charts))
+		for i, chart := range charts {
+			seriesList := make([]ChartSeries, len(chart.Series))
+			for j, series := range chart.Series {
+				seriesList[j] = ChartSeries{
+					Name:       series.Name,
+					Categories: series.Categories,
+					Values:     series.Values,
+					Type:       series.Type.String(),
+				}
+			}
+			chartList[i] = Chart{
+				Name:           chart.Name,
+				Type:           chart.Type.String(),
+				Title:          chart.Title,
+				XAxisTitle:     chart.XAxisTitle,
+				YAxisTitle:     chart.YAxisTitle,
+				LegendPosition: chart.LegendPosition.String(),
+				Anchor:         chart.Anchor,
+				Series:         seriesList,
+			}
+		}
+		autoFilter, err := sheet.GetAutoFilter()
+		if err != nil {
+			return nil, err
+		}
+		var autoFilterDescription *AutoFilter
+		if autoFilter != nil {
+			autoFilterDescription = &AutoFilter{
+				Range:   autoFilter.Range,
+				Columns: make([]FilterColumn, len(autoFilter.Columns)),
+			}
+			for j, column := range autoFilter.Columns {
+				columnName, _ := excelize.ColumnNumberToName(column.Column)
+				conditionList := make([]FilterCondition, len(column.Conditions))
+				for k, condition := range column.Conditions {
+					conditionList[k] = FilterCondition{
+						Operator: condition.Operator.String(),
+						Value:    condition.Value,
+					}
+				}
+				autoFilterDescription.Columns[j] = FilterColumn{
+					Column:     columnName,
+					Values:     column.Values,
+					Blank:      column.Blank,
+					Conditions: conditionList,
+					And:        column.And,
+					Other:      column.Other,
+				}
+			}
+		}
+		sheetDefinedNameList := []DefinedName{}
+		for _, definedName := range definedNames {
+			if definedName.Scope == name {
+				sheetDefinedNameList = append(sheetDefinedNameList, DefinedName{
+					Name:     definedName.Name,
+					RefersTo: definedName.RefersTo,
+					Comment:  definedName.Comment,
+				})
+			}
+		}
+		// the sheet keeps the detected regions, which the paging strategy below reuses
+		var dataRegionList []DataRegion
+		if regions, err := excel.DetectDataRegions(sheet); err == nil {
+			for _, region := range regions {
+				dataRegionList = append(dataRegionList, DataRegion{
+					Range:  region.Range,
+					Labels: region.Labels,
+				})
 			}
 		}
 		var pagingRanges []string
-		strategy, err := sheet.GetPagingStrategy(config.EXCEL_MCP_PAGING_CELLS_LIMIT)
+		strategy, _, err := newPagingStrategy(sheet, config, -1)
 		if err == nil {
 			pagingService := excel.NewPagingRangeService(strategy)
 			pagingRanges = pagingService.GetPagingRanges()
 		}
 		worksheets[i] = Worksheet{
-			Name:         name,
-			UsedRange:    usedRange,
-			Tables:       tableList,
-			PivotTables:  pivotTableList,
-			PagingRanges: pagingRanges,
+			Name:            name,
+			UsedRange:       usedRange,
+			Tables:          tableList,
+			PivotTables:     pivotTableList,
+			DefinedNames:    sheetDefinedNameList,
+			DataValidations: dataValidationList,
+			Charts:          chartList,
+			AutoFilter:      autoFilterDescription,
+			DataRegions:     dataRegionList,
+			PagingRanges:    pagingRanges,
+		}
+	}
+	workbookDefinedNameList := []DefinedName{}
+	for _, definedName := range definedNames {
+		if definedName.Scope == "" {
+			workbookDefinedNameList = append(workbookDefinedNameList, DefinedName{
+				Name:     definedName.Name,
+				RefersTo: definedName.RefersTo,
+				Comment:  definedName.Comment,
+			})
 		}
 	}
 	response := Response{
-		Backend: workbook.GetBackendName(),
-		Sheets:  worksheets,
+		Backend:      workbook.GetBackendName(),
+		Sheets:       worksheets,
+		DefinedNames: workbookDefinedNameList,
 	}
 	jsonBytes, err := json.MarshalIndent(response, "", "  ")
 	if err != nil {
@@ -0,0 +1,367 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelFindArguments struct {
+	FileAbsolutePath      string `zog:"fileAbsolutePath"`
+	DirectoryAbsolutePath string `zog:"directoryAbsolutePath"`
+	Recursive             bool   `zog:"recursive"`
+	SheetName             string `zog:"sheetName"`
+	Query                 string `zog:"query"`
+	Mode                  string `zog:"mode"`
+	Target                string `zog:"target"`
+	WholeCell             bool   `zog:"wholeCell"`
+	ContextCells          int    `zog:"contextCells"`
+	MaxResults            int    `zog:"maxResults"`
+	MaxFiles              int    `zog:"maxFiles"`
+}
+
+var findModes = []string{"literal", "caseInsensitive", "regex"}
+
+var findTargets = []string{"values", "formulas", "both"}
+
+// workbookExtensions are the extensions of workbooks searched in a directory
+var workbookExtensions = []string{".xlsx", ".xlsm", ".xltx", ".xltm"}
+
+var excelFindArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath":      z.String().Test(AbsolutePathTest()),
+	"directoryAbsolutePath": z.String().Test(AbsolutePathTest()),
+	"recursive":             z.Bool().Default(true),
+	"sheetName":             z.String(),
+	"query":                 z.String().Min(1).Required(),
+	"mode":                  z.String().OneOf(findModes).Default("caseInsensitive"),
+	"target":                z.String().OneOf(findTargets).Default("values"),
+	"wholeCell":             z.Bool().Default(false),
+	"contextCells":          z.Int().GTE(0).LTE(10).Default(2),
+	"maxResults":            z.Int().GT(0).LTE(1000).Default(100),
+	"maxFiles":              z.Int().GT(0).LTE(1000).Default(100),
+})
+
+type FindResponse struct {
+	Matches []FindMatch `json:"matches"`
+	// Truncated is true if the search stopped at maxResults or maxFiles, so more matches may exist
+	Truncated     bool          `json:"truncated"`
+	SearchedFiles int           `json:"searchedFiles"`
+	SkippedFiles  []SkippedFile `json:"skippedFiles,omitempty"`
+}
+
+type FindMatch struct {
+	File         string        `json:"file"`
+	Sheet        string        `json:"sheet"`
+	Cell         string        `json:"cell"`
+	Value        string        `json:"value"`
+	Formula      string        `json:"formula,omitempty"`
+	ColumnHeader string        `json:"columnHeader,omitempty"`
+	Context      []ContextCell `json:"context,omitempty"`
+}
+
+type ContextCell struct {
+	Cell  string `json:"cell"`
+	Value string `json:"value"`
+}
+
+type SkippedFile struct {
+	File   string `json:"file"`
+	Reason string `json:"reason"`
+}
+
+func AddExcelFindTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_find",
+		mcp.WithDescription("Find cells whose values or formulas match a query across all sheets of an Excel file, or across all Excel files in a directory"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Description("Absolute path to the Excel file to search"),
+		),
+		mcp.WithString("directoryAbsolutePath",
+			mcp.Description("Absolute path to the directory whose Excel files (.xlsx, .xlsm, .xltx, .xltm) are searched. Either fileAbsolutePath or directoryAbsolutePath is required."),
+		),
+		mcp.WithBoolean("recursive",
+			mcp.Description("Search subdirectories of the directory [default: true]"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Description("Sheet name to search. Workbooks without the sheet are skipped. [default: all sheets]"),
+		),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("Text or regular expression (RE2 syntax) to find"),
+		),
+		mcp.WithString("mode",
+			mcp.Enum(findModes...),
+			mcp.Description("How the query is matched: literal (case-sensitive), caseInsensitive or regex [default: caseInsensitive]"),
+		),
+		mcp.WithString("target",
+			mcp.Enum(findTargets...),
+			mcp.Description("Whether values (as displayed), formulas or both are searched [default: values]"),
+		),
+		mcp.WithBoolean("wholeCell",
+			mcp.Description("Match the whole content of the cell instead of a part of it [default: false]"),
+		),
+		mcp.WithNumber("contextCells",
+			mcp.Description("Number of cells on each side of a match in the same row returned as context [default: 2]"),
+		),
+		mcp.WithNumber("maxResults",
+			mcp.Description("Maximum number of matches [default: 100]"),
+		),
+		mcp.WithNumber("maxFiles",
+			mcp.Description("Maximum number of files searched in the directory [default: 100]"),
+		),
+	), handleFind)
+}
+
+func handleFind(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelFindArguments{}
+	if issues := excelFindArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return find(args)
+}
+
+func find(args ExcelFindArguments) (*mcp.CallToolResult, error) {
+	if (args.FileAbsolutePath == "") == (args.DirectoryAbsolutePath == "") {
+		return imcp.NewToolResultInvalidArgumentError("either fileAbsolutePath or directoryAbsolutePath is required"), nil
+	}
+	match, err := newCellMatcher(args.Query, args.Mode, args.WholeCell)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+
+	response := FindResponse{Matches: []FindMatch{}}
+	files := []string{args.FileAbsolutePath}
+	if args.DirectoryAbsolutePath != "" {
+		files, err = findWorkbookFiles(args.DirectoryAbsolutePath, args.Recursive, args.MaxFiles+1)
+		if err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+		if len(files) > args.MaxFiles {
+			files = files[:args.MaxFiles]
+			response.Truncated = true
+		}
+	}
+
+	for _, file := range files {
+		if len(response.Matches) >= args.MaxResults {
+			response.Truncated = true
+			break
+		}
+		matches, truncated, err := findInWorkbook(file, args, match, args.MaxResults-len(response.Matches))
+		if err != nil {
+			if args.DirectoryAbsolutePath == "" {
+				return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+			}
+			response.SkippedFiles = append(response.SkippedFiles, SkippedFile{File: file, Reason: err.Error()})
+			continue
+		}
+		response.SearchedFiles++
+		response.Matches = append(response.Matches, matches...)
+		response.Truncated = response.Truncated || truncated
+	}
+
+	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// newCellMatcher returns a function which reports whether the text matches the query.
+func newCellMatcher(query string, mode string, wholeCell bool) (func(text string) bool, error) {
+	switch mode {
+	case "regex":
+		if wholeCell {
+			query = "^(?:" + query + ")$"
+		}
+		re, err := regexp.Compile(query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %w", err)
+		}
+		return re.MatchString, nil
+	case "caseInsensitive":
+		if wholeCell {
+			return func(text string) bool { return strings.EqualFold(text, query) }, nil
+		}
+		lowerQuery := strings.ToLower(query)
+		return func(text string) bool { return strings.Contains(strings.ToLower(text), lowerQuery) }, nil
+	default:
+		if wholeCell {
+			return func(text string) bool { return text == query }, nil
+		}
+		return func(text string) bool { return strings.Contains(text, query) }, nil
+	}
+}
+
+// findWorkbookFiles lists the Excel files in the directory in lexical order, up to limit files.
+func findWorkbookFiles(directory string, recursive bool, limit int) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(filepath.Clean(directory), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if !recursive && path != filepath.Clean(directory) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// skip lock files of opened workbooks
+		if strings.HasPrefix(d.Name(), "~$") {
+			return nil
+		}
+		if slices.Contains(workbookExtensions, strings.ToLower(filepath.Ext(path))) {
+			files = append(files, path)
+			if len(files) >= limit {
+				return filepath.SkipAll
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+	return files, nil
+}
+
+// findInWorkbook searches the sheets of the workbook, and reports whether it stopped at the limit.
+func findInWorkbook(file string, args ExcelFindArguments, match func(text string) bool, limit int) ([]FindMatch, bool, error) {
+	workbook, release, err := excel.OpenFile(file)
+	defer release()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var worksheets []excel.Worksheet
+	if args.SheetName != "" {
+		worksheet, err := workbook.FindSheet(args.SheetName)
+		if err != nil {
+			return nil, false, err
+		}
+		worksheets = []excel.Worksheet{worksheet}
+	} else {
+		worksheets, err = workbook.GetSheets()
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	for _, worksheet := range worksheets {
+		defer worksheet.Release()
+	}
+
+	var matches []FindMatch
+	for _, worksheet := range worksheets {
+		sheetMatches, truncated, err := findInSheet(worksheet, args, match, limit-len(matches))
+		if err != nil {
+			return nil, false, err
+		}
+		for i := range sheetMatches {
+			sheetMatches[i].File = file
+		}
+		matches = append(matches, sheetMatches...)
+		if truncated {
+			return matches, true, nil
+		}
+	}
+	return matches, false, nil
+}
+
+// findChunkCells is the number of cells to read at once while searching a sheet
+const findChunkCells = 10000
+
+func findInSheet(worksheet excel.Worksheet, args ExcelFindArguments, match func(text string) bool, limit int) ([]FindMatch, bool, error) {
+	sheetName, err := worksheet.Name()
+	if err != nil {
+		return nil, false, err
+	}
+	dimension, err := worksheet.GetDimention()
+	if err != nil {
+		return nil, false, err
+	}
+	startCol, startRow, endCol, endRow, err := excel.ParseRange(dimension)
+	if err != nil {
+		// empty sheet
+		return nil, false, nil
+	}
+
+	// the header row and the sheet are read in bulk, chunk by chunk of rows
+	headerCells, err := worksheet.GetRangeValues(formatCellRange(startCol, startRow, endCol, startRow))
+	if err != nil {
+		return nil, false, err
+	}
+	chunkRows := max(findChunkCells/(endCol-startCol+1), 1)
+	var matches []FindMatch
+	for chunkStartRow := startRow; chunkStartRow <= endRow; chunkStartRow += chunkRows {
+		chunkEndRow := min(chunkStartRow+chunkRows-1, endRow)
+		cells, err := worksheet.GetRangeValues(formatCellRange(startCol, chunkStartRow, endCol, chunkEndRow))
+		if err != nil {
+			return nil, false, err
+		}
+		for i, rowCells := range cells {
+			row := chunkStartRow + i
+			for j, cellData := range rowCells {
+				col := startCol + j
+				value := cellData.Value
+				formula := ""
+				if args.Target != "values" {
+					formula = cellData.Formula
+				}
+				matched := (args.Target != "formulas" && value != "" && match(value)) ||
+					(args.Target != "values" && formula != "" && match(formula))
+				if !matched {
+					continue
+				}
+				if len(matches) >= limit {
+					return matches, true, nil
+				}
+				cell, err := excelize.CoordinatesToCellName(col, row)
+				if err != nil {
+					return nil, false, err
+				}
+				found := FindMatch{
+					Sheet:   sheetName,
+					Cell:    cell,
+					Value:   value,
+					Formula: formula,
+				}
+				if row > startRow {
+					found.ColumnHeader = headerCells[0][j].Value
+				}
+				for contextCol := max(col-args.ContextCells, startCol); contextCol <= min(col+args.ContextCells, endCol); contextCol++ {
+					if contextCol == col {
+						continue
+					}
+					contextValue := rowCells[contextCol-startCol].Value
+					if contextValue == "" {
+						continue
+					}
+					contextCell, err := excelize.CoordinatesToCellName(contextCol, row)
+					if err != nil {
+						return nil, false, err
+					}
+					found.Context = append(found.Context, ContextCell{Cell: contextCell, Value: contextValue})
+				}
+				matches = append(matches, found)
+			}
+		}
+	}
+	return matches, false, nil
+}
+
+// formatCellRange formats the range from the start cell to the end cell (e.g. A1:C10)
+func formatCellRange(startCol, startRow, endCol, endRow int) string {
+	startCell, _ := excelize.CoordinatesToCellName(startCol, startRow)
+	endCell, _ := excelize.CoordinatesToCellName(endCol, endRow)
+	return startCell + ":" + endCell
+}
@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestFindInWorkbook(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "find.xlsx")
+	workbook := excelize.NewFile()
+	rows := [][]any{
+		{"Name", "Amount", "Note"},
+		{"apple", 120, "fresh apple"},
+		{"banana", 80, "ripe"},
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := workbook.SetSheetRow("Sheet1", cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a formula which excelize fails to calculate must not abort the search
+	if err := workbook.SetCellFormula("Sheet1", "D3", "UNKNOWNFUNC(B3)"); err != nil {
+		t.Fatal(err)
+	}
+	if err := workbook.SetCellFormula("Sheet1", "D2", "B2*2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := workbook.SetSheetDimension("Sheet1", "A1:D3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := workbook.SaveAs(file); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		target string
+		want   []string
+	}{
+		{name: "values", query: "apple", target: "values", want: []string{"A2", "C2"}},
+		{name: "formulas", query: "B", target: "formulas", want: []string{"D2", "D3"}},
+		{name: "calculated value", query: "240", target: "values", want: []string{"D2"}},
+		{name: "no match", query: "cherry", target: "both", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := newCellMatcher(tt.query, "caseInsensitive", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			args := ExcelFindArguments{Target: tt.target, ContextCells: 1}
+			matches, truncated, err := findInWorkbook(file, args, match, 100)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if truncated {
+				t.Error("unexpected truncation")
+			}
+			var got []string
+			for _, m := range matches {
+				got = append(got, m.Cell)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+			for _, m := range matches {
+				if m.Cell == "A2" && (m.ColumnHeader != "Name" || len(m.Context) != 1 || m.Context[0].Value != "120") {
+					t.Errorf("unexpected header or context: %+v", m)
+				}
+			}
+		})
+	}
+}
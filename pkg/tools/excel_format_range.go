@@ -67,7 +67,7 @@ func AddExcelFormatRangeTool(server *server.MCPServer) {
 		),
 		mcp.WithString("range",
 			mcp.Required(),
-			mcp.Description("Range of cells in the Excel sheet (e.g., \"A1:C3\")"),
+			mcp.Description("Range of cells in the Excel sheet (e.g., \"A1:C3\", \"R1C1:R3C3\" or a defined name)"),
 		),
 		mcp.WithArray("styles",
 			mcp.Required(),
@@ -189,6 +189,17 @@ func formatRange(fileAbsolutePath string, sheetName string, rangeStr string, sty
 	}
 	defer closeFn()
 
+	// Get worksheet
+	worksheet, err := workbook.FindSheet(sheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	rangeStr, err = excel.ResolveRange(workbook, worksheet, rangeStr)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
 	startCol, startRow, endCol, endRow, err := excel.ParseRange(rangeStr)
 	if err != nil {
 		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
@@ -200,13 +211,6 @@ func formatRange(fileAbsolutePath string, sheetName string, rangeStr string, sty
 		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("number of style rows (%d) does not match range size (%d)", len(styles), rangeRowSize)), nil
 	}
 
-	// Get worksheet
-	worksheet, err := workbook.FindSheet(sheetName)
-	if err != nil {
-		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
-	}
-	defer worksheet.Release()
-
 	// Apply styles to each cell
 	for i, styleRow := range styles {
 		rangeColumnSize := endCol - startCol + 1
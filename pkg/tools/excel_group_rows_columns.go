@@ -0,0 +1,164 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"html"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+)
+
+type ExcelGroupRowsColumnsArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Action           string `zog:"action"`
+	Range            string `zog:"range"`
+	Level            int    `zog:"level"`
+	Collapse         bool   `zog:"collapse"`
+}
+
+var groupRowsColumnsActions = []string{"group", "ungroup"}
+
+var excelGroupRowsColumnsArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"action":           z.String().OneOf(groupRowsColumnsActions).Required(),
+	"range":            z.String().Required(),
+	"level":            z.Int().GTE(0).LTE(excel.MaxOutlineLevel).Default(0),
+	"collapse":         z.Bool().Default(false),
+})
+
+func AddExcelGroupRowsColumnsTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_group_rows_columns",
+		mcp.WithDescription("Group or ungroup rows or columns in the Excel sheet by changing their outline levels. Outline levels are shown in the output of excel_read_sheet."),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("action",
+			mcp.Required(),
+			mcp.Enum(groupRowsColumnsActions...),
+			mcp.Description("Group (increase the outline level by one) or ungroup (decrease it by one) the rows or columns"),
+		),
+		mcp.WithString("range",
+			mcp.Required(),
+			mcp.Description("Rows (e.g., \"3:5\" or \"3\") or columns (e.g., \"B:D\" or \"B\"). Multiple ranges can be separated by commas (e.g., \"2:3,7\")."),
+		),
+		mcp.WithNumber("level",
+			mcp.Description(fmt.Sprintf("Outline level to set, from 1 to %d, instead of increasing it by one. Only for group.", excel.MaxOutlineLevel)),
+		),
+		mcp.WithBoolean("collapse",
+			mcp.Description("Hide the grouped rows or columns, as collapsing the group does. Only for group. [default: false]"),
+		),
+	), handleGroupRowsColumns)
+}
+
+func handleGroupRowsColumns(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelGroupRowsColumnsArguments{}
+	if issues := excelGroupRowsColumnsArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return groupRowsColumns(args)
+}
+
+func groupRowsColumns(args ExcelGroupRowsColumnsArguments) (*mcp.CallToolResult, error) {
+	if args.Action == "ungroup" && (args.Level != 0 || args.Collapse) {
+		return imcp.NewToolResultInvalidArgumentError("level and collapse are only for group"), nil
+	}
+	lines, err := parseRowsOrColumnsList(args.Range)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	for _, line := range lines {
+		getLevel := worksheet.GetColumnOutlineLevel
+		setLevel := worksheet.SetColumnsOutlineLevel
+		setHidden := worksheet.SetColumnsHidden
+		if line.rows {
+			getLevel = worksheet.GetRowOutlineLevel
+			setLevel = worksheet.SetRowsOutlineLevel
+			setHidden = worksheet.SetRowsHidden
+		}
+
+		// levels may differ by row or column, so they are changed one by one
+		levels := make([]int, 0, line.end-line.start+1)
+		for i := line.start; i <= line.end; i++ {
+			level, err := getLevel(i)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case args.Level > 0:
+				level = args.Level
+			case args.Action == "group":
+				if level == excel.MaxOutlineLevel {
+					return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("%s already have the maximum outline level %d", line, excel.MaxOutlineLevel)), nil
+				}
+				level++
+			default:
+				level = max(level-1, 0)
+			}
+			levels = append(levels, level)
+		}
+		for i, level := range levels {
+			if err := setLevel(line.start+i, line.start+i, level); err != nil {
+				return nil, err
+			}
+		}
+		if args.Collapse {
+			if err := setHidden(line.start, line.end, true); err != nil {
+				return nil, err
+			}
+		}
+
+		result += fmt.Sprintf("%s of sheet [%s] are %sed. Outline levels: %s\n", line, html.EscapeString(args.SheetName), args.Action, describeOutlineLevels(line, levels))
+		if args.Collapse {
+			result += "They are collapsed (hidden).\n"
+		}
+	}
+
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+// describeOutlineLevels lists the levels of consecutive rows or columns with the same level (e.g. "3:4 = 2, 5 = 1").
+func describeOutlineLevels(line rowsOrColumns, levels []int) string {
+	var result string
+	for i := 0; i < len(levels); {
+		j := i
+		for j+1 < len(levels) && levels[j+1] == levels[i] {
+			j++
+		}
+		if result != "" {
+			result += ", "
+		}
+		span := rowsOrColumns{rows: line.rows, start: line.start + i, end: line.start + j}
+		result += fmt.Sprintf("%s = %d", span.ref(), levels[i])
+		i = j + 1
+	}
+	return result
+}
@@ -0,0 +1,151 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelHideRowsColumnsArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Action           string `zog:"action"`
+	Range            string `zog:"range"`
+}
+
+var hideRowsColumnsActions = []string{"hide", "unhide"}
+
+var excelHideRowsColumnsArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"action":           z.String().OneOf(hideRowsColumnsActions).Required(),
+	"range":            z.String().Required(),
+})
+
+func AddExcelHideRowsColumnsTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_hide_rows_columns",
+		mcp.WithDescription("Hide or unhide rows or columns in the Excel sheet. Hidden rows and columns are marked in the output of excel_read_sheet."),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("action",
+			mcp.Required(),
+			mcp.Enum(hideRowsColumnsActions...),
+			mcp.Description("Hide or unhide the rows or columns"),
+		),
+		mcp.WithString("range",
+			mcp.Required(),
+			mcp.Description("Rows (e.g., \"3:5\" or \"3\") or columns (e.g., \"B:D\" or \"B\"). Multiple ranges can be separated by commas (e.g., \"2:3,7\")."),
+		),
+	), handleHideRowsColumns)
+}
+
+func handleHideRowsColumns(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelHideRowsColumnsArguments{}
+	if issues := excelHideRowsColumnsArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return hideRowsColumns(args)
+}
+
+func hideRowsColumns(args ExcelHideRowsColumnsArguments) (*mcp.CallToolResult, error) {
+	lines, err := parseRowsOrColumnsList(args.Range)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	hidden := args.Action == "hide"
+	state := "shown"
+	if hidden {
+		state = "hidden"
+	}
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	for _, line := range lines {
+		if line.rows {
+			err = worksheet.SetRowsHidden(line.start, line.end, hidden)
+		} else {
+			err = worksheet.SetColumnsHidden(line.start, line.end, hidden)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result += fmt.Sprintf("%s of sheet [%s] are %s.\n", line, html.EscapeString(args.SheetName), state)
+	}
+
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+// rowsOrColumns is whole rows or whole columns from start to end
+type rowsOrColumns struct {
+	rows       bool
+	start, end int
+}
+
+// String describes the rows or columns in the whole rows or columns notation (e.g. "Rows 3:5", "Columns B:B")
+func (l rowsOrColumns) String() string {
+	span := l.ref()
+	if l.start == l.end {
+		span += ":" + span
+	}
+	if l.rows {
+		return "Rows " + span
+	}
+	return "Columns " + span
+}
+
+// ref returns the reference of the rows or columns (e.g. "3:5", "B:D", or "B" for a single column)
+func (l rowsOrColumns) ref() string {
+	name := func(number int) string {
+		if l.rows {
+			return strconv.Itoa(number)
+		}
+		name, _ := excelize.ColumnNumberToName(number)
+		return name
+	}
+	if l.start == l.end {
+		return name(l.start)
+	}
+	return name(l.start) + ":" + name(l.end)
+}
+
+// parseRowsOrColumnsList parses comma separated rows or columns (e.g. "2:3,7", "B:D").
+func parseRowsOrColumnsList(rangeStr string) ([]rowsOrColumns, error) {
+	var lines []rowsOrColumns
+	for _, area := range excel.SplitRangeAreas(rangeStr) {
+		rows, start, end, err := excel.ParseRowsOrColumns(area)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, rowsOrColumns{rows: rows, start: start, end: end})
+	}
+	return lines, nil
+}
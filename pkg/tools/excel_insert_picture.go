@@ -0,0 +1,219 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelInsertPictureArguments struct {
+	FileAbsolutePath string  `zog:"fileAbsolutePath"`
+	SheetName        string  `zog:"sheetName"`
+	Cell             string  `zog:"cell"`
+	ImagePath        string  `zog:"imagePath"`
+	ImageBase64      string  `zog:"imageBase64"`
+	Scale            float64 `zog:"scale"`
+	Width            int     `zog:"width"`
+	Height           int     `zog:"height"`
+	AltText          string  `zog:"altText"`
+}
+
+var excelInsertPictureArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"cell":             z.String().Required(),
+	"imagePath":        z.String(),
+	"imageBase64":      z.String(),
+	"scale":            z.Float64().GT(0).LTE(10),
+	"width":            z.Int().GT(0).LTE(10000),
+	"height":           z.Int().GT(0).LTE(10000),
+	"altText":          z.String(),
+})
+
+// supportedPictureExtensions are the image formats whose size can be decoded, which excelize requires
+var supportedPictureExtensions = []string{".png", ".jpg", ".jpeg", ".gif"}
+
+// detectedPictureExtensions maps the content types detected from image data to extensions
+var detectedPictureExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+}
+
+func AddExcelInsertPictureTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_insert_picture",
+		mcp.WithDescription("Insert a picture from an image file or base64 data at a cell in the Excel sheet"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("cell",
+			mcp.Required(),
+			mcp.Description("Cell where the top-left corner of the picture is placed (e.g., \"B2\")"),
+		),
+		mcp.WithString("imagePath",
+			mcp.Description("Absolute path to the image file (png, jpg or gif)"),
+		),
+		mcp.WithString("imageBase64",
+			mcp.Description("Base64 encoded image data (png, jpg or gif), optionally as a data URL. Either imagePath or imageBase64 is required."),
+		),
+		mcp.WithNumber("scale",
+			mcp.Description("Scale to the original size of the image (e.g., 0.5) [default: 1]"),
+		),
+		mcp.WithNumber("width",
+			mcp.Description("Width of the picture in pixels. If height is omitted, the aspect ratio is kept."),
+		),
+		mcp.WithNumber("height",
+			mcp.Description("Height of the picture in pixels. If width is omitted, the aspect ratio is kept."),
+		),
+		mcp.WithString("altText",
+			mcp.Description("Alternative text of the picture"),
+		),
+	), handleInsertPicture)
+}
+
+func handleInsertPicture(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelInsertPictureArguments{}
+	if issues := excelInsertPictureArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return insertPicture(args)
+}
+
+func insertPicture(args ExcelInsertPictureArguments) (*mcp.CallToolResult, error) {
+	picture, err := loadPicture(args.ImagePath, args.ImageBase64)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	picture.AltText = args.AltText
+	picture.ScaleX, picture.ScaleY = 1, 1
+	if args.Scale != 0 {
+		if args.Width != 0 || args.Height != 0 {
+			return imcp.NewToolResultInvalidArgumentError("scale cannot be specified with width or height"), nil
+		}
+		picture.ScaleX, picture.ScaleY = args.Scale, args.Scale
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(picture.Data))
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid image data: %v", err)), nil
+	}
+	if args.Width != 0 || args.Height != 0 {
+		if args.Width != 0 {
+			picture.ScaleX = float64(args.Width) / float64(config.Width)
+		}
+		if args.Height != 0 {
+			picture.ScaleY = float64(args.Height) / float64(config.Height)
+		}
+		if args.Width == 0 {
+			picture.ScaleX = picture.ScaleY
+		}
+		if args.Height == 0 {
+			picture.ScaleY = picture.ScaleX
+		}
+	}
+
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	rangeStr, err := excel.ResolveRange(workbook, worksheet, args.Cell)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	startCol, startRow, endCol, endRow, err := excel.ParseRange(rangeStr)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	if startCol != endCol || startRow != endRow {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("cell must be a single cell: %s", args.Cell)), nil
+	}
+	picture.Cell, err = excelize.CoordinatesToCellName(startCol, startRow)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := worksheet.AddPicture(picture); err != nil {
+		return nil, err
+	}
+
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("Picture (%s, %dx%d pixels) inserted at cell %s in sheet [%s].\n", picture.Extension,
+		int(float64(config.Width)*picture.ScaleX+0.5), int(float64(config.Height)*picture.ScaleY+0.5), picture.Cell, html.EscapeString(args.SheetName))
+	return mcp.NewToolResultText(result), nil
+}
+
+// loadPicture reads image data from the file path or base64 data, and determines its format.
+func loadPicture(imagePath string, imageBase64 string) (*excel.Picture, error) {
+	if (imagePath == "") == (imageBase64 == "") {
+		return nil, fmt.Errorf("either imagePath or imageBase64 is required")
+	}
+	picture := &excel.Picture{}
+	if imagePath != "" {
+		if !filepath.IsAbs(imagePath) {
+			return nil, fmt.Errorf("path '%s' is not absolute", imagePath)
+		}
+		picture.Extension = strings.ToLower(filepath.Ext(imagePath))
+		if !slices.Contains(supportedPictureExtensions, picture.Extension) {
+			return nil, fmt.Errorf("unsupported image format: %s", imagePath)
+		}
+		data, err := os.ReadFile(filepath.Clean(imagePath))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read image file: %w", err)
+		}
+		picture.Data = data
+		return picture, nil
+	}
+
+	// data URL (e.g. "data:image/png;base64,...")
+	if strings.HasPrefix(imageBase64, "data:") {
+		if i := strings.Index(imageBase64, ","); i >= 0 {
+			imageBase64 = imageBase64[i+1:]
+		}
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(imageBase64))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 image data: %w", err)
+	}
+	extension, ok := detectedPictureExtensions[http.DetectContentType(data)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported image format of base64 data, use png, jpg or gif")
+	}
+	picture.Extension = extension
+	picture.Data = data
+	return picture, nil
+}
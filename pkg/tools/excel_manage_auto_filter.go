@@ -0,0 +1,234 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"html"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelManageAutoFilterArguments struct {
+	FileAbsolutePath string                      `zog:"fileAbsolutePath"`
+	SheetName        string                      `zog:"sheetName"`
+	Action           string                      `zog:"action"`
+	Range            string                      `zog:"range"`
+	Columns          []ExcelFilterColumnArgument `zog:"columns"`
+}
+
+type ExcelFilterColumnArgument struct {
+	Column     string                         `zog:"column"`
+	Values     []string                       `zog:"values"`
+	Blank      bool                           `zog:"blank"`
+	Conditions []ExcelFilterConditionArgument `zog:"conditions"`
+	And        bool                           `zog:"and"`
+}
+
+type ExcelFilterConditionArgument struct {
+	Operator excel.FilterOperator `zog:"operator"`
+	Value    string               `zog:"value"`
+}
+
+var autoFilterActions = []string{"set", "remove"}
+
+var excelManageAutoFilterArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"action":           z.String().OneOf(autoFilterActions).Required(),
+	"range":            z.String(),
+	"columns": z.Slice(z.Struct(z.Shape{
+		"column": z.String().Required(),
+		"values": z.Slice(z.String()),
+		"blank":  z.Bool().Default(false),
+		"conditions": z.Slice(z.Struct(z.Shape{
+			"operator": z.StringLike[excel.FilterOperator]().OneOf(excel.FilterOperatorValues()).Default(excel.FilterOperatorEqual),
+			"value":    z.String(),
+		})).Max(2),
+		"and": z.Bool().Default(false),
+	})),
+})
+
+func AddExcelManageAutoFilterTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_manage_auto_filter",
+		mcp.WithDescription("Set an AutoFilter with column criteria on a range of the Excel sheet, hiding rows which do not match, or remove it. The current AutoFilter is shown in the output of excel_describe_sheets."),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("action",
+			mcp.Required(),
+			mcp.Enum(autoFilterActions...),
+			mcp.Description("Set the AutoFilter replacing the existing one, or remove it and show the rows hidden by it"),
+		),
+		mcp.WithString("range",
+			mcp.Description("Range of the AutoFilter including the header row (e.g., \"A1:D20\") [default: used range]"),
+		),
+		mcp.WithArray("columns",
+			mcp.Description("Criteria of columns. Rows are shown only if they match the criteria of all columns. Without columns, only the filter buttons are added."),
+			mcp.Items(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"column": map[string]any{
+						"type":        "string",
+						"description": "Header name or column letter (e.g., \"B\") of the column",
+					},
+					"values": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string"},
+						"description": "Displayed values to show",
+					},
+					"blank": map[string]any{
+						"type":        "boolean",
+						"description": "Also show rows whose cell in the column is empty [default: false]",
+					},
+					"conditions": map[string]any{
+						"type":        "array",
+						"description": "Up to 2 custom conditions, used instead of values. They compare the stored values of cells, not the displayed text (e.g. 0.05 for 5.00%). Dates may be given as text (e.g. 2024-01-31) and percentages with % (e.g. 10%).",
+						"items": map[string]any{
+							"type": "object",
+							"properties": map[string]any{
+								"operator": map[string]any{
+									"type":        "string",
+									"enum":        excel.FilterOperatorValues(),
+									"description": "Comparison operator [default: equal]",
+								},
+								"value": map[string]any{
+									"type":        "string",
+									"description": "Value to compare. With equal and notEqual, * and ? are wildcards (e.g., \"*York\").",
+								},
+							},
+						},
+					},
+					"and": map[string]any{
+						"type":        "boolean",
+						"description": "Rows must satisfy both conditions instead of either [default: false]",
+					},
+				},
+				"required": []string{"column"},
+			}),
+		),
+	), handleManageAutoFilter)
+}
+
+func handleManageAutoFilter(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelManageAutoFilterArguments{}
+	if issues := excelManageAutoFilterArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return manageAutoFilter(args)
+}
+
+func manageAutoFilter(args ExcelManageAutoFilterArguments) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+
+	if args.Action == "remove" {
+		current, err := worksheet.GetAutoFilter()
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("sheet has no AutoFilter: %s", args.SheetName)), nil
+		}
+		if err := worksheet.SetAutoFilter(nil); err != nil {
+			return nil, err
+		}
+		if err := workbook.Save(); err != nil {
+			return nil, err
+		}
+		result += fmt.Sprintf("AutoFilter of range %s removed from sheet [%s].\n", current.Range, html.EscapeString(args.SheetName))
+		return mcp.NewToolResultText(result), nil
+	}
+
+	rangeStr := args.Range
+	if rangeStr == "" {
+		if rangeStr, err = worksheet.GetDimention(); err != nil {
+			return nil, err
+		}
+	} else if rangeStr, err = excel.ResolveRange(workbook, worksheet, rangeStr); err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	startCol, startRow, endCol, endRow, err := excel.ParseRange(rangeStr)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+
+	header := make([]string, 0, endCol-startCol+1)
+	for col := startCol; col <= endCol; col++ {
+		cell, err := excelize.CoordinatesToCellName(col, startRow)
+		if err != nil {
+			return nil, err
+		}
+		value, err := worksheet.GetValue(cell)
+		if err != nil {
+			return nil, err
+		}
+		header = append(header, value)
+	}
+	autoFilter := &excel.AutoFilter{Range: rangeStr}
+	for _, column := range args.Columns {
+		col := headerColumn(column.Column, header, startCol)
+		if col < startCol || endCol < col {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("column is neither a header name nor a column letter within the range %s: %s", rangeStr, column.Column)), nil
+		}
+		if (len(column.Values) > 0 || column.Blank) && len(column.Conditions) > 0 {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("column %s can have either values or conditions", column.Column)), nil
+		}
+		filterColumn := excel.FilterColumn{
+			Column: col,
+			Values: column.Values,
+			Blank:  column.Blank,
+			And:    column.And,
+		}
+		for _, condition := range column.Conditions {
+			filterColumn.Conditions = append(filterColumn.Conditions, excel.FilterCondition{
+				Operator: condition.Operator,
+				Value:    condition.Value,
+			})
+		}
+		autoFilter.Columns = append(autoFilter.Columns, filterColumn)
+	}
+
+	if err := worksheet.SetAutoFilter(autoFilter); err != nil {
+		return nil, err
+	}
+	visibleRows := 0
+	for row := startRow + 1; row <= endRow; row++ {
+		hidden, err := worksheet.IsRowHidden(row)
+		if err != nil {
+			return nil, err
+		}
+		if !hidden {
+			visibleRows++
+		}
+	}
+
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	result += fmt.Sprintf("AutoFilter set on range %s of sheet [%s].\n", rangeStr, html.EscapeString(args.SheetName))
+	result += fmt.Sprintf("%d of %d rows are shown. To read only the shown rows, call excel_read_sheet with `{ \"rowFilter\": \"autoFilter\" }`.\n", visibleRows, endRow-startRow)
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,159 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"html"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelManageCommentArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Action           string `zog:"action"`
+	Cell             string `zog:"cell"`
+	Author           string `zog:"author"`
+	Text             string `zog:"text"`
+}
+
+var commentActions = []string{"add", "edit", "delete"}
+
+var excelManageCommentArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"action":           z.String().OneOf(commentActions).Required(),
+	"cell":             z.String().Required(),
+	"author":           z.String(),
+	"text":             z.String(),
+})
+
+func AddExcelManageCommentTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_manage_comment",
+		mcp.WithDescription("Add, edit or delete a comment (note) on a cell in the Excel sheet"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("action",
+			mcp.Required(),
+			mcp.Enum(commentActions...),
+			mcp.Description("Operation to perform on the comment"),
+		),
+		mcp.WithString("cell",
+			mcp.Required(),
+			mcp.Description("Cell of the comment (e.g., \"B2\")"),
+		),
+		mcp.WithString("author",
+			mcp.Description("Author of the comment"),
+		),
+		mcp.WithString("text",
+			mcp.Description("Text of the comment. Required for add and edit."),
+		),
+	), handleManageComment)
+}
+
+func handleManageComment(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelManageCommentArguments{}
+	if issues := excelManageCommentArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return manageComment(args.FileAbsolutePath, args.SheetName, args.Action, args.Cell, args.Author, args.Text)
+}
+
+func manageComment(fileAbsolutePath string, sheetName string, action string, cell string, author string, text string) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(fileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(sheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	rangeStr, err := excel.ResolveRange(workbook, worksheet, cell)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	startCol, startRow, endCol, endRow, err := excel.ParseRange(rangeStr)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	if startCol != endCol || startRow != endRow {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("cell must be a single cell: %s", cell)), nil
+	}
+	cell, err = excelize.CoordinatesToCellName(startCol, startRow)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := worksheet.GetComments()
+	if err != nil {
+		return nil, err
+	}
+	var existing *excel.Comment
+	for i := range comments {
+		if comments[i].Cell == cell {
+			existing = &comments[i]
+			break
+		}
+	}
+
+	switch action {
+	case "add", "edit":
+		if action == "add" && existing != nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("comment already exists in cell %s", cell)), nil
+		}
+		if action == "edit" && existing == nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("comment not found in cell %s", cell)), nil
+		}
+		if text == "" {
+			return imcp.NewToolResultInvalidArgumentError("text is required to " + action + " a comment"), nil
+		}
+		if author == "" && existing != nil {
+			author = existing.Author
+		}
+		if err := worksheet.SetComment(excel.Comment{
+			Cell:   cell,
+			Author: author,
+			Text:   text,
+		}); err != nil {
+			return nil, err
+		}
+	case "delete":
+		if existing == nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("comment not found in cell %s", cell)), nil
+		}
+		if err := worksheet.DeleteComment(cell); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	switch action {
+	case "add":
+		result += fmt.Sprintf("Comment added to cell %s in sheet [%s].\n", cell, html.EscapeString(sheetName))
+	case "edit":
+		result += fmt.Sprintf("Comment in cell %s in sheet [%s] edited.\n", cell, html.EscapeString(sheetName))
+	case "delete":
+		result += fmt.Sprintf("Comment in cell %s in sheet [%s] deleted.\n", cell, html.EscapeString(sheetName))
+	}
+	return mcp.NewToolResultText(result), nil
+}
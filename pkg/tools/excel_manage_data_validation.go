@@ -0,0 +1,235 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"html"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+)
+
+type ExcelManageDataValidationArguments struct {
+	FileAbsolutePath string                         `zog:"fileAbsolutePath"`
+	SheetName        string                         `zog:"sheetName"`
+	Action           string                         `zog:"action"`
+	Range            string                         `zog:"range"`
+	Type             excel.DataValidationType       `zog:"type"`
+	Operator         excel.DataValidationOperator   `zog:"operator"`
+	Formula1         string                         `zog:"formula1"`
+	Formula2         string                         `zog:"formula2"`
+	List             []string                       `zog:"list"`
+	ListRange        string                         `zog:"listRange"`
+	AllowBlank       bool                           `zog:"allowBlank"`
+	InputTitle       string                         `zog:"inputTitle"`
+	InputMessage     string                         `zog:"inputMessage"`
+	ErrorStyle       excel.DataValidationErrorStyle `zog:"errorStyle"`
+	ErrorTitle       string                         `zog:"errorTitle"`
+	ErrorMessage     string                         `zog:"errorMessage"`
+}
+
+var dataValidationActions = []string{"add", "remove"}
+
+var excelManageDataValidationArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"action":           z.String().OneOf(dataValidationActions).Required(),
+	"range":            z.String().Required(),
+	"type":             z.StringLike[excel.DataValidationType]().OneOf(excel.DataValidationTypeValues()),
+	"operator":         z.StringLike[excel.DataValidationOperator]().OneOf(excel.DataValidationOperatorValues()),
+	"formula1":         z.String(),
+	"formula2":         z.String(),
+	"list":             z.Slice(z.String()),
+	"listRange":        z.String(),
+	"allowBlank":       z.Bool().Default(true),
+	"inputTitle":       z.String(),
+	"inputMessage":     z.String(),
+	"errorStyle":       z.StringLike[excel.DataValidationErrorStyle]().OneOf(excel.DataValidationErrorStyleValues()),
+	"errorTitle":       z.String(),
+	"errorMessage":     z.String(),
+})
+
+func AddExcelManageDataValidationTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_manage_data_validation",
+		mcp.WithDescription("Add or remove data validation rules (dropdown lists, numeric bounds, dates, text length or custom formula) on a range in the Excel sheet"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("action",
+			mcp.Required(),
+			mcp.Enum(dataValidationActions...),
+			mcp.Description("Add a rule to the range, or remove all rules from the range"),
+		),
+		mcp.WithString("range",
+			mcp.Required(),
+			mcp.Description("Range of cells to validate (e.g., \"B2:B100\")"),
+		),
+		mcp.WithString("type",
+			mcp.Enum(toStrings(excel.DataValidationTypeValues())...),
+			mcp.Description("Type of the rule. Required for add."),
+		),
+		mcp.WithString("operator",
+			mcp.Enum(toStrings(excel.DataValidationOperatorValues())...),
+			mcp.Description("Comparison operator for whole, decimal, date, time and textLength. [default: between]"),
+		),
+		mcp.WithString("formula1",
+			mcp.Description("First value, reference or formula (e.g., \"1\", \"DATE(2025,1,1)\", \"$D$1\"). For custom, the formula which must be TRUE."),
+		),
+		mcp.WithString("formula2",
+			mcp.Description("Second value for between and notBetween"),
+		),
+		mcp.WithArray("list",
+			mcp.Description("Literal items of the dropdown list"),
+			mcp.Items(map[string]any{
+				"type": "string",
+			}),
+		),
+		mcp.WithString("listRange",
+			mcp.Description("Range of the dropdown items (e.g., \"Lists!A1:A10\")"),
+		),
+		mcp.WithBoolean("allowBlank",
+			mcp.Description("Allow blank cells [default: true]"),
+		),
+		mcp.WithString("inputTitle",
+			mcp.Description("Title of the message shown when the cell is selected"),
+		),
+		mcp.WithString("inputMessage",
+			mcp.Description("Message shown when the cell is selected"),
+		),
+		mcp.WithString("errorStyle",
+			mcp.Enum(toStrings(excel.DataValidationErrorStyleValues())...),
+			mcp.Description("Style of the alert shown on invalid input [default: stop]"),
+		),
+		mcp.WithString("errorTitle",
+			mcp.Description("Title of the alert shown on invalid input"),
+		),
+		mcp.WithString("errorMessage",
+			mcp.Description("Message of the alert shown on invalid input"),
+		),
+	), handleManageDataValidation)
+}
+
+func handleManageDataValidation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelManageDataValidationArguments{}
+	if issues := excelManageDataValidationArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return manageDataValidation(args)
+}
+
+func manageDataValidation(args ExcelManageDataValidationArguments) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	rangeStr, err := excel.ResolveRange(workbook, worksheet, args.Range)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+
+	switch args.Action {
+	case "add":
+		validation, err := buildDataValidation(rangeStr, args)
+		if err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+		if err := worksheet.AddDataValidation(validation); err != nil {
+			return nil, err
+		}
+	case "remove":
+		if err := worksheet.DeleteDataValidation(rangeStr); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	if args.Action == "add" {
+		result += fmt.Sprintf("Data validation [%s] added to range %s in sheet [%s].\n", args.Type, rangeStr, html.EscapeString(args.SheetName))
+	} else {
+		result += fmt.Sprintf("Data validations removed from range %s in sheet [%s].\n", rangeStr, html.EscapeString(args.SheetName))
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+// buildDataValidation validates the combination of arguments and builds a rule
+func buildDataValidation(rangeStr string, args ExcelManageDataValidationArguments) (*excel.DataValidation, error) {
+	validation := &excel.DataValidation{
+		Range:        rangeStr,
+		Type:         args.Type,
+		AllowBlank:   args.AllowBlank,
+		InputTitle:   args.InputTitle,
+		InputMessage: args.InputMessage,
+		ErrorStyle:   args.ErrorStyle,
+		ErrorTitle:   args.ErrorTitle,
+		ErrorMessage: args.ErrorMessage,
+	}
+	switch args.Type {
+	case "":
+		return nil, fmt.Errorf("type is required to add a data validation")
+	case excel.DataValidationTypeList:
+		switch {
+		case len(args.List) > 0:
+			validation.List = args.List
+		case args.ListRange != "":
+			listRange, err := qualifyRefersTo(args.ListRange, args.SheetName)
+			if err != nil {
+				return nil, err
+			}
+			validation.Formula1 = listRange
+		case args.Formula1 != "":
+			validation.Formula1 = args.Formula1
+		default:
+			return nil, fmt.Errorf("list, listRange or formula1 is required for list validation")
+		}
+	case excel.DataValidationTypeCustom:
+		if args.Formula1 == "" {
+			return nil, fmt.Errorf("formula1 is required for custom validation")
+		}
+		validation.Formula1 = args.Formula1
+	default:
+		validation.Operator = args.Operator
+		if validation.Operator == "" {
+			validation.Operator = excel.DataValidationOperatorBetween
+		}
+		if args.Formula1 == "" {
+			return nil, fmt.Errorf("formula1 is required for %s validation", args.Type)
+		}
+		validation.Formula1 = args.Formula1
+		if validation.Operator == excel.DataValidationOperatorBetween || validation.Operator == excel.DataValidationOperatorNotBetween {
+			if args.Formula2 == "" {
+				return nil, fmt.Errorf("formula2 is required for %s operator", validation.Operator)
+			}
+			validation.Formula2 = args.Formula2
+		}
+	}
+	return validation, nil
+}
+
+func toStrings[T ~string](values []T) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = string(v)
+	}
+	return result
+}
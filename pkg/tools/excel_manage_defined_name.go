@@ -0,0 +1,177 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelManageDefinedNameArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	Action           string `zog:"action"`
+	Name             string `zog:"name"`
+	RefersTo         string `zog:"refersTo"`
+	Scope            string `zog:"scope"`
+	Comment          string `zog:"comment"`
+}
+
+var definedNameActions = []string{"create", "update", "delete"}
+
+var excelManageDefinedNameArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"action":           z.String().OneOf(definedNameActions).Required(),
+	"name":             z.String().Required(),
+	"refersTo":         z.String(),
+	"scope":            z.String(),
+	"comment":          z.String(),
+})
+
+func AddExcelManageDefinedNameTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_manage_defined_name",
+		mcp.WithDescription("Create, update or delete a defined name (named range) in the Excel file"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("action",
+			mcp.Required(),
+			mcp.Enum(definedNameActions...),
+			mcp.Description("Operation to perform on the defined name"),
+		),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Defined name"),
+		),
+		mcp.WithString("refersTo",
+			mcp.Description("Range or formula the name refers to (e.g., \"Sheet1!A1:C10\"). A range without sheet name refers to the scope sheet. Required for create and update."),
+		),
+		mcp.WithString("scope",
+			mcp.Description("Sheet name for a sheet-scoped name. [default: workbook scope]"),
+		),
+		mcp.WithString("comment",
+			mcp.Description("Comment of the defined name"),
+		),
+	), handleManageDefinedName)
+}
+
+func handleManageDefinedName(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelManageDefinedNameArguments{}
+	if issues := excelManageDefinedNameArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return manageDefinedName(args.FileAbsolutePath, args.Action, args.Name, args.RefersTo, args.Scope, args.Comment)
+}
+
+func manageDefinedName(fileAbsolutePath string, action string, name string, refersTo string, scope string, comment string) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(fileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if scope != "" {
+		worksheet, err := workbook.FindSheet(scope)
+		if err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+		worksheet.Release()
+	}
+
+	definedNames, err := workbook.GetDefinedNames()
+	if err != nil {
+		return nil, err
+	}
+	exists := false
+	for _, definedName := range definedNames {
+		if strings.EqualFold(definedName.Name, name) && definedName.Scope == scope {
+			exists = true
+			break
+		}
+	}
+
+	switch action {
+	case "create", "update":
+		if action == "create" && exists {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("defined name already exists: %s", name)), nil
+		}
+		if action == "update" && !exists {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("defined name not found: %s", name)), nil
+		}
+		if refersTo == "" {
+			return imcp.NewToolResultInvalidArgumentError("refersTo is required to " + action + " a defined name"), nil
+		}
+		refersTo, err = qualifyRefersTo(strings.TrimPrefix(refersTo, "="), scope)
+		if err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+		if err := workbook.SetDefinedName(excel.DefinedName{
+			Name:     name,
+			Scope:    scope,
+			RefersTo: refersTo,
+			Comment:  comment,
+		}); err != nil {
+			return nil, err
+		}
+	case "delete":
+		if !exists {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("defined name not found: %s", name)), nil
+		}
+		if err := workbook.DeleteDefinedName(name, scope); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	scopeLabel := "workbook"
+	if scope != "" {
+		scopeLabel = scope
+	}
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	if action == "delete" {
+		result += fmt.Sprintf("Defined name [%s] in scope [%s] deleted.\n", html.EscapeString(name), html.EscapeString(scopeLabel))
+	} else {
+		result += fmt.Sprintf("Defined name [%s] in scope [%s] %sd: %s\n", html.EscapeString(name), html.EscapeString(scopeLabel), action, html.EscapeString(refersTo))
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+// qualifyRefersTo converts a range reference to an absolute reference qualified with a sheet name.
+// Formulas and constants are returned as is.
+func qualifyRefersTo(refersTo string, scope string) (string, error) {
+	sheetName, ref := excel.SplitSheetName(refersTo)
+	startCol, startRow, endCol, endRow, err := excel.ParseRange(ref)
+	if err != nil {
+		// not a simple range
+		return refersTo, nil
+	}
+	if sheetName == "" {
+		if scope == "" {
+			return "", fmt.Errorf("refersTo must be qualified with a sheet name for a workbook-scoped name: %s", refersTo)
+		}
+		sheetName = scope
+	}
+	startCell, err := excelize.CoordinatesToCellName(startCol, startRow, true)
+	if err != nil {
+		return "", err
+	}
+	endCell, err := excelize.CoordinatesToCellName(endCol, endRow, true)
+	if err != nil {
+		return "", err
+	}
+	if startCell == endCell {
+		return fmt.Sprintf("%s!%s", excel.QuoteSheetName(sheetName), startCell), nil
+	}
+	return fmt.Sprintf("%s!%s:%s", excel.QuoteSheetName(sheetName), startCell, endCell), nil
+}
@@ -0,0 +1,172 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelManageHyperlinkArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Action           string `zog:"action"`
+	Cell             string `zog:"cell"`
+	Url              string `zog:"url"`
+	Location         string `zog:"location"`
+	Display          string `zog:"display"`
+	Tooltip          string `zog:"tooltip"`
+}
+
+var hyperlinkActions = []string{"set", "remove"}
+
+var excelManageHyperlinkArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"action":           z.String().OneOf(hyperlinkActions).Required(),
+	"cell":             z.String().Required(),
+	"url":              z.String(),
+	"location":         z.String(),
+	"display":          z.String(),
+	"tooltip":          z.String(),
+})
+
+func AddExcelManageHyperlinkTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_manage_hyperlink",
+		mcp.WithDescription("Set or remove a hyperlink to an external URL/file or to a location in the workbook on a cell in the Excel sheet"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("action",
+			mcp.Required(),
+			mcp.Enum(hyperlinkActions...),
+			mcp.Description("Set a hyperlink to the cell replacing the existing one, or remove it"),
+		),
+		mcp.WithString("cell",
+			mcp.Required(),
+			mcp.Description("Cell of the hyperlink (e.g., \"B2\")"),
+		),
+		mcp.WithString("url",
+			mcp.Description("External URL or file path (e.g., \"https://example.com\", \"mailto:someone@example.com\", \"report.xlsx\")"),
+		),
+		mcp.WithString("location",
+			mcp.Description("Location in this workbook (e.g., \"Sheet2!A1\" or a defined name), or in the file of url. A location without sheet name refers to this sheet."),
+		),
+		mcp.WithString("display",
+			mcp.Description("Text shown in the cell [default: current value of the cell]"),
+		),
+		mcp.WithString("tooltip",
+			mcp.Description("Text shown when the mouse is over the hyperlink"),
+		),
+	), handleManageHyperlink)
+}
+
+func handleManageHyperlink(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelManageHyperlinkArguments{}
+	if issues := excelManageHyperlinkArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return manageHyperlink(args)
+}
+
+func manageHyperlink(args ExcelManageHyperlinkArguments) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	rangeStr, err := excel.ResolveRange(workbook, worksheet, args.Cell)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	startCol, startRow, endCol, endRow, err := excel.ParseRange(rangeStr)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	if startCol != endCol || startRow != endRow {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("cell must be a single cell: %s", args.Cell)), nil
+	}
+	cell, err := excelize.CoordinatesToCellName(startCol, startRow)
+	if err != nil {
+		return nil, err
+	}
+
+	hyperlink := excel.Hyperlink{
+		Cell:     cell,
+		Address:  args.Url,
+		Location: strings.TrimPrefix(args.Location, "#"),
+		Display:  args.Display,
+		Tooltip:  args.Tooltip,
+	}
+	switch args.Action {
+	case "set":
+		if hyperlink.Address == "" && hyperlink.Location == "" {
+			return imcp.NewToolResultInvalidArgumentError("url or location is required to set a hyperlink"), nil
+		}
+		if hyperlink.Address == "" {
+			hyperlink.Location, err = qualifyLocation(hyperlink.Location, args.SheetName)
+			if err != nil {
+				return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+			}
+		}
+		if err := worksheet.SetHyperlink(hyperlink); err != nil {
+			return nil, err
+		}
+	case "remove":
+		if err := worksheet.DeleteHyperlink(cell); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	if args.Action == "set" {
+		target := hyperlink.Address
+		if hyperlink.Location != "" {
+			if target != "" {
+				target += "#"
+			}
+			target += hyperlink.Location
+		}
+		result += fmt.Sprintf("Hyperlink to %s set to cell %s in sheet [%s].\n", html.EscapeString(target), cell, html.EscapeString(args.SheetName))
+	} else {
+		result += fmt.Sprintf("Hyperlink removed from cell %s in sheet [%s].\n", cell, html.EscapeString(args.SheetName))
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+// qualifyLocation qualifies a range in this workbook with the sheet name, as Excel requires it.
+// Defined names are returned as is.
+func qualifyLocation(location string, sheetName string) (string, error) {
+	locationSheet, ref := excel.SplitSheetName(location)
+	if locationSheet != "" {
+		return location, nil
+	}
+	if _, _, _, _, err := excel.ParseRange(ref); err != nil {
+		return location, nil
+	}
+	return excel.QuoteSheetName(sheetName) + "!" + strings.ToUpper(ref), nil
+}
@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"html"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+)
+
+type ExcelReadPicturesArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Range            string `zog:"range"`
+}
+
+var excelReadPicturesArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"range":            z.String(),
+})
+
+// pictureMimeTypes are the image formats which can be returned as image content
+var pictureMimeTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+}
+
+func AddExcelReadPicturesTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_read_pictures",
+		mcp.WithDescription("Read pictures (e.g. photos, signatures) embedded in the Excel sheet as images with their anchor cells"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("range",
+			mcp.Description("Range where the top-left corner of pictures is anchored (e.g., \"A1:C10\") [default: whole sheet]"),
+		),
+	), handleReadPictures)
+}
+
+func handleReadPictures(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelReadPicturesArguments{}
+	if issues := excelReadPicturesArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return readPictures(args.FileAbsolutePath, args.SheetName, args.Range)
+}
+
+func readPictures(fileAbsolutePath string, sheetName string, rangeStr string) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(fileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(sheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	if rangeStr != "" {
+		rangeStr, err = excel.ResolveRange(workbook, worksheet, rangeStr)
+		if err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+	}
+	pictures, err := worksheet.GetPictures(rangeStr)
+	if err != nil {
+		return nil, err
+	}
+
+	text := "# Metadata\n"
+	text += fmt.Sprintf("- backend: %s\n", workbook.GetBackendName())
+	text += fmt.Sprintf("- sheet name: %s\n", html.EscapeString(sheetName))
+	if rangeStr != "" {
+		text += fmt.Sprintf("- read range: %s\n", rangeStr)
+	}
+	text += "# Pictures\n"
+	if len(pictures) == 0 {
+		text += "No pictures found.\n"
+	}
+	var images []mcp.Content
+	for i, picture := range pictures {
+		text += fmt.Sprintf("%d. cell: %s, format: %s, size: %d bytes", i+1, picture.Cell, picture.Extension, len(picture.Data))
+		if picture.AltText != "" {
+			text += fmt.Sprintf(", alt text: %s", html.EscapeString(picture.AltText))
+		}
+		mimeType, ok := pictureMimeTypes[picture.Extension]
+		if !ok {
+			text += " (not returned as the format is not supported)"
+		} else {
+			images = append(images, mcp.NewImageContent(base64.StdEncoding.EncodeToString(picture.Data), mimeType))
+		}
+		text += "\n"
+	}
+	if len(images) > 0 {
+		text += "Images of the pictures follow in the order above, excluding unsupported formats.\n"
+	}
+
+	return &mcp.CallToolResult{
+		Content: append([]mcp.Content{mcp.NewTextContent(text)}, images...),
+	}, nil
+}
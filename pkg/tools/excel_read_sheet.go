@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"html"
+	"strings"
 
 	z "github.com/Oudwins/zog"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,14 +19,22 @@ type ExcelReadSheetArguments struct {
 	Range            string `zog:"range"`
 	ShowFormula      bool   `zog:"showFormula"`
 	ShowStyle        bool   `zog:"showStyle"`
+	RowFilter        string `zog:"rowFilter"`
+	SkipHidden       bool   `zog:"skipHidden"`
+	HeaderRows       *int   `zog:"headerRows"`
 }
 
+var readRowFilters = []string{"all", "autoFilter", "visible"}
+
 var excelReadSheetArgumentsSchema = z.Struct(z.Shape{
 	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
 	"sheetName":        z.String().Required(),
 	"range":            z.String(),
 	"showFormula":      z.Bool().Default(false),
 	"showStyle":        z.Bool().Default(false),
+	"rowFilter":        z.String().OneOf(readRowFilters).Default("all"),
+	"skipHidden":       z.Bool().Default(false),
+	"headerRows":       z.Ptr(z.Int().GTE(0)),
 })
 
 func AddExcelReadSheetTool(server *server.MCPServer) {
@@ -40,13 +49,23 @@ func AddExcelReadSheetTool(server *server.MCPServer) {
 			mcp.Description("Sheet name in the Excel file"),
 		),
 		mcp.WithString("range",
-			mcp.Description("Range of cells to read in the Excel sheet (e.g., \"A1:C10\"). [default: first paging range]"),
+			mcp.Description("Range of cells to read in the Excel sheet (e.g., \"A1:C10\", \"A:C\", \"R1C1:R10C3\", \"A1:B5,D1:E5\" or a defined name). [default: first paging range]"),
 		),
 		mcp.WithBoolean("showFormula",
 			mcp.Description("Show formula instead of value"),
 		),
 		mcp.WithBoolean("showStyle",
-			mcp.Description("Show style information for cells"),
+			mcp.Description("Show style information and conditional formatting rules for cells"),
+		),
+		mcp.WithString("rowFilter",
+			mcp.Enum(readRowFilters...),
+			mcp.Description("Rows to read: all, autoFilter (only rows which satisfy the AutoFilter of the sheet, as a user sees after applying it) or visible (only rows which are not hidden). Omitted rows are listed. [default: all]"),
+		),
+		mcp.WithBoolean("skipHidden",
+			mcp.Description("Omit hidden rows and columns. Otherwise, headers of hidden rows and columns are marked with the hidden attribute. Headers of grouped rows and columns have the outline-level attribute. [default: false]"),
+		),
+		mcp.WithNumber("headerRows",
+			mcp.Description("Number of header rows at the top of the sheet, or of each data region when the sheet is paged by regions, which are repeated at the top of every range below them with the repeated attribute. 0 disables the repetition. [default: a header row is detected from values]"),
 		),
 	), handleReadSheet)
 }
@@ -56,10 +75,10 @@ func handleReadSheet(ctx context.Context, request mcp.CallToolRequest) (*mcp.Cal
 	if issues := excelReadSheetArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
 		return imcp.NewToolResultZogIssueMap(issues), nil
 	}
-	return readSheet(args.FileAbsolutePath, args.SheetName, args.Range, args.ShowFormula, args.ShowStyle)
+	return readSheet(args.FileAbsolutePath, args.SheetName, args.Range, args.ShowFormula, args.ShowStyle, args.RowFilter, args.SkipHidden, args.HeaderRows)
 }
 
-func readSheet(fileAbsolutePath string, sheetName string, valueRange string, showFormula bool, showStyle bool) (*mcp.CallToolResult, error) {
+func readSheet(fileAbsolutePath string, sheetName string, valueRange string, showFormula bool, showStyle bool, rowFilter string, skipHidden bool, headerRows *int) (*mcp.CallToolResult, error) {
 	config, issues := LoadConfig()
 	if issues != nil {
 		return imcp.NewToolResultZogIssueMap(issues), nil
@@ -77,8 +96,12 @@ func readSheet(fileAbsolutePath string, sheetName string, valueRange string, sho
 	}
 	defer worksheet.Release()
 
-	// ページング戦略の初期化
-	strategy, err := worksheet.GetPagingStrategy(config.EXCEL_MCP_PAGING_CELLS_LIMIT)
+	// ページング戦略の初期化 (header rows are detected unless they are given)
+	headerRowCount := -1
+	if headerRows != nil {
+		headerRowCount = *headerRows
+	}
+	strategy, headerStrategy, err := newPagingStrategy(worksheet, config, headerRowCount)
 	if err != nil {
 		return nil, err
 	}
@@ -95,62 +118,170 @@ func readSheet(fileAbsolutePath string, sheetName string, valueRange string, sho
 	if currentRange == "" && len(allRanges) > 0 {
 		currentRange = allRanges[0]
 	}
+	// Resolve defined names, whole columns/rows and other notations to A1 ranges
+	currentRange, err = excel.ResolveRange(workbook, worksheet, currentRange)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
 
 	// Find next paging range if current range matches a paging range
-	nextRange := pagingService.FindNextRange(allRanges, currentRange)
+	var nextRange string
+	if budgetStrategy, ok := strategy.(*excel.BudgetPagingStrategy); ok {
+		// pages are split to fit in the token budget as they are read
+		if currentRange, nextRange, err = budgetStrategy.Page(currentRange, showStyle); err != nil {
+			return nil, err
+		}
+	} else {
+		nextRange = pagingService.FindNextRange(allRanges, currentRange)
+	}
 	// Validate the current range against the used range
 	usedRange, err := worksheet.GetDimention()
 	if err != nil {
 		return nil, err
 	}
-	if err := validateRangeWithinUsedRange(currentRange, usedRange); err != nil {
-		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
-	}
 
-	// 範囲を解析
-	startCol, startRow, endCol, endRow, err := excel.ParseRange(currentRange)
+	dataValidations, err := worksheet.GetDataValidations()
 	if err != nil {
 		return nil, err
 	}
-
-	// HTMLテーブルの生成
-	var table *string
+	comments, err := worksheet.GetComments()
+	if err != nil {
+		return nil, err
+	}
+	var autoFilter *excel.AutoFilter
+	if rowFilter == "autoFilter" {
+		if autoFilter, err = worksheet.GetAutoFilter(); err != nil {
+			return nil, err
+		}
+		if autoFilter == nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("sheet has no AutoFilter: %s", sheetName)), nil
+		}
+	}
+	var conditionalFormats []excel.ConditionalFormat
 	if showStyle {
-		if showFormula {
-			table, err = CreateHTMLTableOfFormulaWithStyle(worksheet, startCol, startRow, endCol, endRow)
-		} else {
-			table, err = CreateHTMLTableOfValuesWithStyle(worksheet, startCol, startRow, endCol, endRow)
+		conditionalFormats, err = worksheet.GetConditionalFormats()
+		if err != nil {
+			return nil, err
 		}
-	} else {
-		if showFormula {
-			table, err = CreateHTMLTableOfFormula(worksheet, startCol, startRow, endCol, endRow)
+	}
+
+	// HTMLテーブルの生成 (multi-area ranges are rendered area by area)
+	var tables []string
+	for _, area := range excel.SplitRangeAreas(currentRange) {
+		if err := validateRangeWithinUsedRange(area, usedRange); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+
+		// 範囲を解析
+		startCol, startRow, endCol, endRow, err := excel.ParseRange(area)
+		if err != nil {
+			return nil, err
+		}
+
+		hyperlinks, err := worksheet.GetHyperlinks(area)
+		if err != nil {
+			return nil, err
+		}
+		annotations := []CellAnnotation{
+			NewDataValidationAnnotation(dataValidations),
+			NewCommentAnnotation(comments),
+			NewHyperlinkAnnotation(hyperlinks),
+		}
+		switch rowFilter {
+		case "autoFilter":
+			values, err := autoFilterValues(worksheet, autoFilter, startRow, endRow)
+			if err != nil {
+				return nil, err
+			}
+			annotations = append(annotations, NewAutoFilterAnnotation(autoFilter, values))
+		case "visible":
+			annotations = append(annotations, NewHiddenRowAnnotation(func(row int) bool {
+				hidden, _ := worksheet.IsRowHidden(row)
+				return hidden
+			}))
+		}
+		// each data region has its own header rows, and tiles of wide sheets or regions repeat the row labels
+		headerStartRow, headerEndRow := headerStrategy.HeaderRowsOf(area)
+		labelStartCol, labelEndCol := headerStrategy.LabelColumnsOf(area)
+		if headerStartRow > 0 || labelStartCol > 0 {
+			annotations = append(annotations, NewRepeatedHeaderAnnotation(headerStartRow, headerEndRow, labelStartCol, labelEndCol))
+		}
+		annotations = append(annotations, NewOutlineAnnotation(
+			func(row int) (bool, int) {
+				hidden, _ := worksheet.IsRowHidden(row)
+				level, _ := worksheet.GetRowOutlineLevel(row)
+				return hidden, level
+			},
+			func(col int) (bool, int) {
+				hidden, _ := worksheet.IsColumnHidden(col)
+				level, _ := worksheet.GetColumnOutlineLevel(col)
+				return hidden, level
+			},
+			skipHidden,
+		))
+		var table *string
+		if showStyle {
+			annotations = append(annotations, NewConditionalFormatAnnotation(conditionalFormats))
+			if showFormula {
+				table, err = CreateHTMLTableOfFormulaWithStyle(worksheet, startCol, startRow, endCol, endRow, annotations...)
+			} else {
+				table, err = CreateHTMLTableOfValuesWithStyle(worksheet, startCol, startRow, endCol, endRow, annotations...)
+			}
 		} else {
-			table, err = CreateHTMLTableOfValues(worksheet, startCol, startRow, endCol, endRow)
+			if showFormula {
+				table, err = CreateHTMLTableOfFormula(worksheet, startCol, startRow, endCol, endRow, annotations...)
+			} else {
+				table, err = CreateHTMLTableOfValues(worksheet, startCol, startRow, endCol, endRow, annotations...)
+			}
 		}
-	}
-	if err != nil {
-		return nil, err
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, *table)
 	}
 
 	result := "<h2>Read Sheet</h2>\n"
-	result += *table + "\n"
+	result += strings.Join(tables, "\n") + "\n"
 	result += "<h2>Metadata</h2>\n"
 	result += "<ul>\n"
 	result += fmt.Sprintf("<li>backend: %s</li>\n", workbook.GetBackendName())
 	result += fmt.Sprintf("<li>sheet name: %s</li>\n", html.EscapeString(sheetName))
 	result += fmt.Sprintf("<li>read range: %s</li>\n", currentRange)
+	if headerStartRow, headerEndRow := headerStrategy.HeaderRowsOf(excel.SplitRangeAreas(currentRange)[0]); headerStartRow > 0 {
+		result += fmt.Sprintf("<li>header rows: %d:%d</li>\n", headerStartRow, headerEndRow)
+	}
 	result += "</ul>\n"
 	result += "<h2>Notice</h2>\n"
 	if nextRange != "" {
 		result += "<p>This sheet has more ranges.</p>\n"
 		result += "<p>To read the next range, you should specify 'range' argument as follows.</p>\n"
 		result += fmt.Sprintf("<code>{ \"range\": \"%s\" }</code>\n", nextRange)
+		if labelStartCol, _ := headerStrategy.LabelColumnsOf(excel.SplitRangeAreas(currentRange)[0]); labelStartCol > 0 {
+			result += "<p>This wide sheet or data region is read in tiles, from left to right and then from top to bottom.</p>\n"
+		}
 	} else {
 		result += "<p>This is the last range or no more ranges available.</p>\n"
 	}
 	return mcp.NewToolResultText(result), nil
 }
 
+// newPagingStrategy creates the paging strategy of excel_read_sheet, whose ranges excel_describe_sheets also lists.
+// It also returns the strategy which tells the header rows, detected if headerRows is negative.
+func newPagingStrategy(worksheet excel.Worksheet, config EnvConfig, headerRows int) (excel.PagingStrategy, *excel.HeaderPagingStrategy, error) {
+	strategy, err := worksheet.GetPagingStrategy(config.EXCEL_MCP_PAGING_CELLS_LIMIT)
+	if err != nil {
+		return nil, nil, err
+	}
+	headerStrategy, err := excel.NewHeaderPagingStrategy(strategy, worksheet, headerRows)
+	if err != nil {
+		return nil, nil, err
+	}
+	if config.EXCEL_MCP_PAGING_TOKENS_LIMIT > 0 {
+		return excel.NewBudgetPagingStrategy(headerStrategy, worksheet, config.EXCEL_MCP_PAGING_TOKENS_LIMIT), headerStrategy, nil
+	}
+	return headerStrategy, headerStrategy, nil
+}
+
 func validateRangeWithinUsedRange(targetRange, usedRange string) error {
 	// Parse target range
 	targetStartCol, targetStartRow, targetEndCol, targetEndRow, err := excel.ParseRange(targetRange)
@@ -172,3 +303,32 @@ func validateRangeWithinUsedRange(targetRange, usedRange string) error {
 
 	return nil
 }
+
+// autoFilterValues reads the filtered columns in the rows at once, and returns a function which gives
+// the value stored in a cell and its displayed text as NewAutoFilterAnnotation takes.
+func autoFilterValues(worksheet excel.Worksheet, autoFilter *excel.AutoFilter, startRow, endRow int) (func(col int, row int) (string, string), error) {
+	_, filterStartRow, _, filterEndRow, err := excel.ParseRange(autoFilter.Range)
+	if err != nil {
+		return nil, err
+	}
+	// the header row of the AutoFilter is not filtered
+	startRow, endRow = max(startRow, filterStartRow+1), min(endRow, filterEndRow)
+	if startRow > endRow || len(autoFilter.Columns) == 0 {
+		return func(col int, row int) (string, string) { return "", "" }, nil
+	}
+	startCol, endCol := autoFilter.Columns[0].Column, autoFilter.Columns[0].Column
+	for _, column := range autoFilter.Columns {
+		startCol, endCol = min(startCol, column.Column), max(endCol, column.Column)
+	}
+	cells, err := worksheet.GetRangeValues(formatCellRange(startCol, startRow, endCol, endRow))
+	if err != nil {
+		return nil, err
+	}
+	return func(col int, row int) (string, string) {
+		if col < startCol || endCol < col || row < startRow || endRow < row {
+			return "", ""
+		}
+		cell := cells[row-startRow][col-startCol]
+		return cell.RawValue, cell.Value
+	}, nil
+}
@@ -0,0 +1,350 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelReplaceArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Range            string `zog:"range"`
+	Query            string `zog:"query"`
+	Replacement      string `zog:"replacement"`
+	Mode             string `zog:"mode"`
+	Target           string `zog:"target"`
+	WholeCell        bool   `zog:"wholeCell"`
+	Apply            bool   `zog:"apply"`
+}
+
+var excelReplaceArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String(),
+	"range":            z.String(),
+	"query":            z.String().Min(1).Required(),
+	"replacement":      z.String(),
+	"mode":             z.String().OneOf(findModes).Default("caseInsensitive"),
+	"target":           z.String().OneOf(findTargets).Default("values"),
+	"wholeCell":        z.Bool().Default(false),
+	"apply":            z.Bool().Default(false),
+})
+
+// maxReplacePreviewCells is the maximum number of cells listed in the output
+const maxReplacePreviewCells = 200
+
+type cellReplacement struct {
+	worksheet excel.Worksheet
+	sheetName string
+	cell      string
+	formula   bool
+	// kind is the kind of the value stored in the cell, which the replacement keeps
+	kind   excel.CellValueKind
+	before string
+	after  string
+}
+
+func AddExcelReplaceTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_replace",
+		mcp.WithDescription("Find and replace text in values and/or formulas of a range, a sheet or the whole Excel file. By default, it only previews the affected cells; call again with apply to change them. Values are matched as stored without number formats (e.g. 0.123456 formatted as 0.12, or 45306 for the date 2024-01-15), and numbers and booleans stay numbers and booleans."),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Description("Sheet name in the Excel file [default: all sheets]"),
+		),
+		mcp.WithString("range",
+			mcp.Description("Range of cells in the sheet (e.g., \"A1:C10\"). sheetName is required. [default: used range]"),
+		),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("Text or regular expression (RE2 syntax) to find"),
+		),
+		mcp.WithString("replacement",
+			mcp.Description("Replacement text. In regex mode, $1 or ${name} refers to a capture group. [default: empty]"),
+		),
+		mcp.WithString("mode",
+			mcp.Enum(findModes...),
+			mcp.Description("How the query is matched: literal (case-sensitive), caseInsensitive or regex [default: caseInsensitive]"),
+		),
+		mcp.WithString("target",
+			mcp.Enum(findTargets...),
+			mcp.Description("Whether values of cells without formula, formulas or both are replaced [default: values]"),
+		),
+		mcp.WithBoolean("wholeCell",
+			mcp.Description("Replace only cells whose whole content matches [default: false]"),
+		),
+		mcp.WithBoolean("apply",
+			mcp.Description("Apply the replacement and save the file. If false, only the affected cells are listed. [default: false]"),
+		),
+	), handleReplace)
+}
+
+func handleReplace(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelReplaceArguments{}
+	if issues := excelReplaceArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return replace(args)
+}
+
+func replace(args ExcelReplaceArguments) (*mcp.CallToolResult, error) {
+	if args.Range != "" && args.SheetName == "" {
+		return imcp.NewToolResultInvalidArgumentError("sheetName is required with range"), nil
+	}
+	replacer, err := newCellReplacer(args.Query, args.Replacement, args.Mode, args.WholeCell)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var worksheets []excel.Worksheet
+	if args.SheetName != "" {
+		worksheet, err := workbook.FindSheet(args.SheetName)
+		if err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+		worksheets = []excel.Worksheet{worksheet}
+	} else {
+		worksheets, err = workbook.GetSheets()
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, worksheet := range worksheets {
+		defer worksheet.Release()
+	}
+
+	var replacements []cellReplacement
+	for _, worksheet := range worksheets {
+		rangeStr := args.Range
+		if rangeStr != "" {
+			if rangeStr, err = excel.ResolveRange(workbook, worksheet, rangeStr); err != nil {
+				return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+			}
+		} else if rangeStr, err = worksheet.GetDimention(); err != nil {
+			return nil, err
+		}
+		sheetReplacements, err := findReplacements(worksheet, rangeStr, args.Target, replacer)
+		if err != nil {
+			return nil, err
+		}
+		for _, replacement := range sheetReplacements {
+			if err := validateReplacement(replacement); err != nil {
+				return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+			}
+		}
+		replacements = append(replacements, sheetReplacements...)
+	}
+
+	if args.Apply && len(replacements) > 0 {
+		for _, replacement := range replacements {
+			if err := applyReplacement(replacement); err != nil {
+				return nil, err
+			}
+		}
+		if err := workbook.Save(); err != nil {
+			return nil, err
+		}
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	switch {
+	case len(replacements) == 0:
+		result += "No cells match the query.\n"
+	case args.Apply:
+		result += fmt.Sprintf("%d cells replaced.\n", len(replacements))
+	default:
+		result += fmt.Sprintf("%d cells will be replaced. This is a preview and the file is not changed. To apply the replacement, call again with `{ \"apply\": true }`.\n", len(replacements))
+	}
+	if len(replacements) == 0 {
+		return mcp.NewToolResultText(result), nil
+	}
+
+	result += "# Cells\n"
+	result += "<table>\n<tr><th>Sheet</th><th>Cell</th><th>Target</th><th>Before</th><th>After</th></tr>\n"
+	for i, replacement := range replacements {
+		if i >= maxReplacePreviewCells {
+			break
+		}
+		target := "value"
+		if replacement.formula {
+			target = "formula"
+		}
+		result += fmt.Sprintf("<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			html.EscapeString(replacement.sheetName), replacement.cell, target, html.EscapeString(replacement.before), html.EscapeString(replacement.after))
+	}
+	result += "</table>\n"
+	if len(replacements) > maxReplacePreviewCells {
+		result += fmt.Sprintf("%d more cells are not listed.\n", len(replacements)-maxReplacePreviewCells)
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+// newCellReplacer returns a function which replaces the matches of the query in the text, and reports whether it matched.
+func newCellReplacer(query string, replacement string, mode string, wholeCell bool) (func(text string) (string, bool), error) {
+	var re *regexp.Regexp
+	var err error
+	switch mode {
+	case "regex":
+		if wholeCell {
+			query = "^(?:" + query + ")$"
+		}
+		if re, err = regexp.Compile(query); err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %w", err)
+		}
+		return func(text string) (string, bool) {
+			if !re.MatchString(text) {
+				return text, false
+			}
+			return re.ReplaceAllString(text, replacement), true
+		}, nil
+	case "caseInsensitive":
+		if wholeCell {
+			return func(text string) (string, bool) {
+				if !strings.EqualFold(text, query) {
+					return text, false
+				}
+				return replacement, true
+			}, nil
+		}
+		re = regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+		return func(text string) (string, bool) {
+			if !re.MatchString(text) {
+				return text, false
+			}
+			return re.ReplaceAllLiteralString(text, replacement), true
+		}, nil
+	default:
+		return func(text string) (string, bool) {
+			if wholeCell && text != query || !wholeCell && !strings.Contains(text, query) {
+				return text, false
+			}
+			return strings.ReplaceAll(text, query, replacement), true
+		}, nil
+	}
+}
+
+// findReplacements lists the cells in the range whose content changes by the replacement.
+// Values are matched as stored without number formats, so numbers and dates only match by their raw values
+// (e.g. 0.123456 formatted as 0.12, or 45306 for 2024-01-15).
+// The range may have multiple areas, which are read in bulk chunk by chunk of rows as excel_find does.
+func findReplacements(worksheet excel.Worksheet, rangeStr string, target string, replacer func(text string) (string, bool)) ([]cellReplacement, error) {
+	sheetName, err := worksheet.Name()
+	if err != nil {
+		return nil, err
+	}
+	var replacements []cellReplacement
+	for _, area := range excel.SplitRangeAreas(rangeStr) {
+		startCol, startRow, endCol, endRow, err := excel.ParseRange(area)
+		if err != nil {
+			return nil, err
+		}
+		chunkRows := max(findChunkCells/(endCol-startCol+1), 1)
+		for chunkStartRow := startRow; chunkStartRow <= endRow; chunkStartRow += chunkRows {
+			chunkEndRow := min(chunkStartRow+chunkRows-1, endRow)
+			cells, err := worksheet.GetRangeValues(formatCellRange(startCol, chunkStartRow, endCol, chunkEndRow))
+			if err != nil {
+				return nil, err
+			}
+			for i, rowCells := range cells {
+				for j, cellData := range rowCells {
+					formula := cellData.Formula != ""
+					if formula && target == "values" || !formula && target == "formulas" {
+						continue
+					}
+					// the stored value, not to replace a part of a formatted number
+					text := cellData.RawValue
+					if formula {
+						text = cellData.Formula
+					}
+					if text == "" {
+						continue
+					}
+					after, matched := replacer(text)
+					if !matched || after == text {
+						continue
+					}
+					cell, err := excelize.CoordinatesToCellName(startCol+j, chunkStartRow+i)
+					if err != nil {
+						return nil, err
+					}
+					kind := excel.CellValueKindString
+					if !formula {
+						// the kind is read only for the matched cells
+						if _, kind, err = worksheet.GetRawValue(cell); err != nil {
+							return nil, err
+						}
+						if kind == excel.CellValueKindEmpty || kind == excel.CellValueKindError {
+							continue
+						}
+					}
+					replacements = append(replacements, cellReplacement{
+						worksheet: worksheet,
+						sheetName: sheetName,
+						cell:      cell,
+						formula:   formula,
+						kind:      kind,
+						before:    text,
+						after:     after,
+					})
+				}
+			}
+		}
+	}
+	return replacements, nil
+}
+
+// validateReplacement checks that the replacement keeps the kind of the value, e.g. a number stays a number.
+func validateReplacement(replacement cellReplacement) error {
+	if replacement.formula {
+		return nil
+	}
+	switch replacement.kind {
+	case excel.CellValueKindNumber:
+		if _, err := strconv.ParseFloat(replacement.after, 64); err != nil {
+			return fmt.Errorf("replacement turns the number %s in %s!%s into a non-numeric value: %s", replacement.before, replacement.sheetName, replacement.cell, replacement.after)
+		}
+	case excel.CellValueKindBool:
+		if !strings.EqualFold(replacement.after, "TRUE") && !strings.EqualFold(replacement.after, "FALSE") {
+			return fmt.Errorf("replacement turns the boolean %s in %s!%s into a non-boolean value: %s", replacement.before, replacement.sheetName, replacement.cell, replacement.after)
+		}
+	}
+	return nil
+}
+
+func applyReplacement(replacement cellReplacement) error {
+	worksheet := replacement.worksheet
+	if replacement.formula && isFormula(replacement.after) {
+		return worksheet.SetFormula(replacement.cell, replacement.after)
+	}
+	// keep the kind of the value, which validateReplacement has checked
+	switch replacement.kind {
+	case excel.CellValueKindNumber:
+		number, err := strconv.ParseFloat(replacement.after, 64)
+		if err != nil {
+			return err
+		}
+		return worksheet.SetValue(replacement.cell, number)
+	case excel.CellValueKindBool:
+		return worksheet.SetValue(replacement.cell, strings.EqualFold(replacement.after, "TRUE"))
+	}
+	return worksheet.SetValue(replacement.cell, replacement.after)
+}
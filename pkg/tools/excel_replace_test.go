@@ -0,0 +1,165 @@
+package tools
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestReplaceKeepsStoredValues(t *testing.T) {
+	percent := "0.00"
+	thousands := "#,##0.00"
+	date := "yyyy-mm-dd"
+	tests := []struct {
+		name        string
+		value       any
+		format      *string
+		query       string
+		replacement string
+		wantRaw     string
+		wantType    excelize.CellType
+		wantInvalid bool
+	}{
+		{name: "formatted decimal", value: 0.123456, format: &percent, query: "1", replacement: "9", wantRaw: "0.923456"},
+		{name: "date does not match its display", value: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), format: &date, query: "1", replacement: "9", wantRaw: "45306"},
+		{name: "thousands separator", value: 1234567, format: &thousands, query: "1", replacement: "9", wantRaw: "9234567"},
+		{name: "number into text", value: 1234567, query: "1", replacement: "x", wantRaw: "1234567", wantInvalid: true},
+		{name: "text", value: "a1b", query: "1", replacement: "9", wantRaw: "a9b", wantType: excelize.CellTypeSharedString},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "replace.xlsx")
+			workbook := excelize.NewFile()
+			if err := workbook.SetCellValue("Sheet1", "A1", tt.value); err != nil {
+				t.Fatal(err)
+			}
+			if tt.format != nil {
+				style, err := workbook.NewStyle(&excelize.Style{CustomNumFmt: tt.format})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := workbook.SetCellStyle("Sheet1", "A1", "A1", style); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := workbook.SaveAs(file); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := replace(ExcelReplaceArguments{
+				FileAbsolutePath: file,
+				SheetName:        "Sheet1",
+				Query:            tt.query,
+				Replacement:      tt.replacement,
+				Mode:             "literal",
+				Target:           "values",
+				Apply:            true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.IsError != tt.wantInvalid {
+				t.Fatalf("IsError = %v, want %v: %v", result.IsError, tt.wantInvalid, result.Content)
+			}
+
+			replaced, err := excelize.OpenFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer replaced.Close()
+			raw, err := replaced.GetCellValue("Sheet1", "A1", excelize.Options{RawCellValue: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if raw != tt.wantRaw {
+				t.Errorf("raw value = %q, want %q", raw, tt.wantRaw)
+			}
+			cellType, err := replaced.GetCellType("Sheet1", "A1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cellType != tt.wantType {
+				t.Errorf("cell type = %v, want %v", cellType, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestReplaceRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		rng    string
+		target string
+		want   map[string]string
+	}{
+		{
+			name: "multiple areas", rng: "A1:A2,C1:C2", target: "values",
+			want: map[string]string{"A1": "new", "A2": "new", "B1": "old", "C1": "new", "C2": "=\"old\"&A1", "A5": "old"},
+		},
+		{
+			name: "defined name of multiple areas", rng: "Targets", target: "values",
+			want: map[string]string{"A1": "new", "A2": "old", "B1": "old", "C1": "old", "A5": "new"},
+		},
+		{
+			name: "formulas", rng: "A1:C5", target: "formulas",
+			want: map[string]string{"A1": "old", "C2": "=\"new\"&A1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "replace.xlsx")
+			workbook := excelize.NewFile()
+			for _, cell := range []string{"A1", "A2", "B1", "C1", "A5"} {
+				if err := workbook.SetCellValue("Sheet1", cell, "old"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := workbook.SetCellFormula("Sheet1", "C2", `"old"&A1`); err != nil {
+				t.Fatal(err)
+			}
+			if err := workbook.SetDefinedName(&excelize.DefinedName{Name: "Targets", RefersTo: "Sheet1!$A$1,Sheet1!$A$5"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := workbook.SaveAs(file); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := replace(ExcelReplaceArguments{
+				FileAbsolutePath: file,
+				SheetName:        "Sheet1",
+				Range:            tt.rng,
+				Query:            "old",
+				Replacement:      "new",
+				Mode:             "literal",
+				Target:           tt.target,
+				Apply:            true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.IsError {
+				t.Fatalf("replace() failed: %v", result.Content)
+			}
+
+			replaced, err := excelize.OpenFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer replaced.Close()
+			for cell, want := range tt.want {
+				got, err := replaced.GetCellValue("Sheet1", cell)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if formula, _ := replaced.GetCellFormula("Sheet1", cell); formula != "" {
+					got = "=" + formula
+				}
+				if got != want {
+					t.Errorf("%s = %q, want %q", cell, got, want)
+				}
+			}
+		})
+	}
+}
@@ -25,7 +25,7 @@ var ExcelScreenCaptureArgumentsSchema = z.Struct(z.Shape{
 
 func AddExcelScreenCaptureTool(server *server.MCPServer) {
 	server.AddTool(mcp.NewTool("excel_screen_capture",
-		mcp.WithDescription("[Windows only] Take a screenshot of the Excel sheet with pagination."),
+		mcp.WithDescription("Take a screenshot of the Excel sheet with pagination. Without Excel, the range is rendered from the file, with fonts substituted."),
 		mcp.WithString("fileAbsolutePath",
 			mcp.Required(),
 			mcp.Description("Absolute path to the Excel file"),
@@ -50,13 +50,19 @@ func handleScreenCapture(ctx context.Context, request mcp.CallToolRequest) (*mcp
 }
 
 func readSheetImage(fileAbsolutePath string, sheetName string, rangeStr string) (*mcp.CallToolResult, error) {
+	var workbook excel.Excel
 	workbook, releaseWorkbook, err := excel.NewExcelOle(fileAbsolutePath)
 	defer releaseWorkbook()
 	if err != nil {
 		workbook, releaseWorkbook, err = excel.NewExcelOleWithNewObject(fileAbsolutePath)
 		defer releaseWorkbook()
 		if err != nil {
-			return imcp.NewToolResultInvalidArgumentError(fmt.Errorf("failed to open workbook: %w", err).Error()), nil
+			// render the range from the file without Excel
+			workbook, releaseWorkbook, err = excel.OpenFile(fileAbsolutePath)
+			defer releaseWorkbook()
+			if err != nil {
+				return imcp.NewToolResultInvalidArgumentError(fmt.Errorf("failed to open workbook: %w", err).Error()), nil
+			}
 		}
 	}
 
@@ -83,7 +89,13 @@ func readSheetImage(fileAbsolutePath string, sheetName string, rangeStr string)
 		currentRange = allRanges[0]
 	} else {
 		// range が指定されている場合は指定された範囲を使用
-		currentRange = rangeStr
+		currentRange, err = excel.ResolveRange(workbook, worksheet, rangeStr)
+		if err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+		if _, _, _, _, err := excel.ParseRange(currentRange); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
 	}
 	// Find next paging range if current range matches a paging range
 	nextRange := pagingService.FindNextRange(allRanges, currentRange)
@@ -0,0 +1,214 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	excel "github.com/wxyzh/excel-mcp-server/pkg/excel"
+	imcp "github.com/wxyzh/excel-mcp-server/pkg/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelSortRangeArguments struct {
+	FileAbsolutePath string                 `zog:"fileAbsolutePath"`
+	SheetName        string                 `zog:"sheetName"`
+	Range            string                 `zog:"range"`
+	HasHeader        bool                   `zog:"hasHeader"`
+	Keys             []ExcelSortKeyArgument `zog:"keys"`
+}
+
+type ExcelSortKeyArgument struct {
+	Column      string             `zog:"column"`
+	Order       excel.SortOrder    `zog:"order"`
+	DataType    excel.SortDataType `zog:"dataType"`
+	CustomOrder []string           `zog:"customOrder"`
+}
+
+var excelSortRangeArgumentsSchema = z.Struct(z.Shape{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"range":            z.String().Required(),
+	"hasHeader":        z.Bool().Default(false),
+	"keys": z.Slice(z.Struct(z.Shape{
+		"column":      z.String().Required(),
+		"order":       z.StringLike[excel.SortOrder]().OneOf(excel.SortOrderValues()).Default(excel.SortOrderAscending),
+		"dataType":    z.StringLike[excel.SortDataType]().OneOf(excel.SortDataTypeValues()).Default(excel.SortDataTypeAuto),
+		"customOrder": z.Slice(z.String()),
+	})).Min(1).Required(),
+})
+
+func AddExcelSortRangeTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_sort_range",
+		mcp.WithDescription("Sort rows of a range or table in the Excel sheet by one or more columns. Styles of cells move with their rows."),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("range",
+			mcp.Required(),
+			mcp.Description("Range to sort (e.g., \"A1:C10\"), a table name or a defined name. A table is sorted below its header row."),
+		),
+		mcp.WithBoolean("hasHeader",
+			mcp.Description("Keep the first row of the range as a header row, and allow header names in keys [default: false, true for tables]"),
+		),
+		mcp.WithArray("keys",
+			mcp.Required(),
+			mcp.Description("Sort keys, most significant first"),
+			mcp.Items(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"column": map[string]any{
+						"type":        "string",
+						"description": "Column letter (e.g., \"B\") or header name of the column",
+					},
+					"order": map[string]any{
+						"type":        "string",
+						"enum":        excel.SortOrderValues(),
+						"description": "Sort order. Empty cells are always sorted last. [default: ascending]",
+					},
+					"dataType": map[string]any{
+						"type":        "string",
+						"enum":        excel.SortDataTypeValues(),
+						"description": "How values are compared: auto (numbers, text, then booleans), number (also numbers stored as text), text (displayed text) or date (also dates stored as text such as \"2024-01-31\") [default: auto]",
+					},
+					"customOrder": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string"},
+						"description": "Values sorted first in this order (e.g., [\"High\", \"Medium\", \"Low\"]). Other values follow in the normal order.",
+					},
+				},
+				"required": []string{"column"},
+			}),
+		),
+	), handleSortRange)
+}
+
+func handleSortRange(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelSortRangeArguments{}
+	if issues := excelSortRangeArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return sortRange(args)
+}
+
+func sortRange(args ExcelSortRangeArguments) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	rangeStr := ""
+	hasHeader := args.HasHeader
+	tables, err := worksheet.GetTables()
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range tables {
+		if strings.EqualFold(table.Name, args.Range) {
+			rangeStr = table.Range
+			hasHeader = true
+		}
+	}
+	if rangeStr == "" {
+		if rangeStr, err = excel.ResolveRange(workbook, worksheet, args.Range); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+	}
+	startCol, startRow, endCol, endRow, err := excel.ParseRange(rangeStr)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+
+	var header []string
+	if hasHeader {
+		for col := startCol; col <= endCol; col++ {
+			cell, err := excelize.CoordinatesToCellName(col, startRow)
+			if err != nil {
+				return nil, err
+			}
+			value, err := worksheet.GetValue(cell)
+			if err != nil {
+				return nil, err
+			}
+			header = append(header, value)
+		}
+	}
+	keys := make([]excel.SortKey, len(args.Keys))
+	keyDescriptions := make([]string, len(args.Keys))
+	for i, key := range args.Keys {
+		column := headerColumn(key.Column, header, startCol)
+		if column < startCol || endCol < column {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("column is neither a header name nor a column letter within the range %s: %s", rangeStr, key.Column)), nil
+		}
+		keys[i] = excel.SortKey{
+			Column:      column,
+			Order:       key.Order,
+			DataType:    key.DataType,
+			CustomOrder: key.CustomOrder,
+		}
+		keyDescriptions[i] = fmt.Sprintf("%s (%s)", html.EscapeString(key.Column), key.Order)
+	}
+
+	if err := worksheet.SortRange(rangeStr, keys, hasHeader); err != nil {
+		return nil, err
+	}
+
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	dataStartRow := startRow
+	if hasHeader {
+		dataStartRow++
+	}
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	if dataStartRow >= endRow {
+		result += fmt.Sprintf("Range %s of sheet [%s] has no rows to sort.\n", rangeStr, html.EscapeString(args.SheetName))
+		return mcp.NewToolResultText(result), nil
+	}
+	dataStart, err := excelize.CoordinatesToCellName(startCol, dataStartRow)
+	if err != nil {
+		return nil, err
+	}
+	dataEnd, err := excelize.CoordinatesToCellName(endCol, endRow)
+	if err != nil {
+		return nil, err
+	}
+	result += fmt.Sprintf("Sorted rows %s:%s of sheet [%s] by %s.\n", dataStart, dataEnd, html.EscapeString(args.SheetName), strings.Join(keyDescriptions, ", "))
+	if hasHeader {
+		result += fmt.Sprintf("Row %d is kept as the header row.\n", startRow)
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+// headerColumn returns the column number of the header name or the column letter, or 0 if not found.
+// Header names take precedence over column letters.
+func headerColumn(column string, header []string, startCol int) int {
+	for i, name := range header {
+		if name != "" && strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(column)) {
+			return startCol + i
+		}
+	}
+	number, err := excelize.ColumnNameToNumber(strings.TrimSpace(column))
+	if err != nil {
+		return 0
+	}
+	return number
+}
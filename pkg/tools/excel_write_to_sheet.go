@@ -45,7 +45,7 @@ func AddExcelWriteToSheetTool(server *server.MCPServer) {
 		),
 		mcp.WithString("range",
 			mcp.Required(),
-			mcp.Description("Range of cells in the Excel sheet (e.g., \"A1:C10\")"),
+			mcp.Description("Range of cells in the Excel sheet (e.g., \"A1:C10\", \"R1C1:R10C3\" or a defined name)"),
 		),
 		mcp.WithArray("values",
 			mcp.Required(),
@@ -97,6 +97,9 @@ func handleWriteToSheet(ctx context.Context, request mcp.CallToolRequest) (*mcp.
 	return writeSheet(args.FileAbsolutePath, args.SheetName, args.NewSheet, args.Range, values)
 }
 
+// bulkWriteCellsThreshold is the number of cells above which values are written with SetRangeValues
+const bulkWriteCellsThreshold = 1000
+
 func writeSheet(fileAbsolutePath string, sheetName string, newSheet bool, rangeStr string, values [][]any) (*mcp.CallToolResult, error) {
 	workbook, closeFn, err := excel.OpenFile(fileAbsolutePath)
 	if err != nil {
@@ -104,6 +107,22 @@ func writeSheet(fileAbsolutePath string, sheetName string, newSheet bool, rangeS
 	}
 	defer closeFn()
 
+	// Resolve defined names, whole columns/rows and other notations to an A1 range.
+	// All arguments are validated before a new sheet is created, not to leave an empty sheet behind.
+	var worksheet excel.Worksheet
+	if newSheet {
+		rangeStr, err = excel.ResolveNewSheetRange(workbook, sheetName, rangeStr)
+	} else {
+		// シートの取得
+		if worksheet, err = workbook.FindSheet(sheetName); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+		defer worksheet.Release()
+		rangeStr, err = excel.ResolveRange(workbook, worksheet, rangeStr)
+	}
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
 	startCol, startRow, endCol, endRow, err := excel.ParseRange(rangeStr)
 	if err != nil {
 		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
@@ -115,41 +134,53 @@ func writeSheet(fileAbsolutePath string, sheetName string, newSheet bool, rangeS
 		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("number of rows in data (%d) does not match range size (%d)", len(values), rangeRowSize)), nil
 	}
 
-	if newSheet {
-		if err := workbook.CreateNewSheet(sheetName); err != nil {
-			return nil, err
-		}
-	}
-
-	// シートの取得
-	worksheet, err := workbook.FindSheet(sheetName)
-	if err != nil {
-		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
-	}
-	defer worksheet.Release()
-
-	// データの書き込み
+	// check the number of columns and whether formulas are written
 	wroteFormula := false
+	rangeColumnSize := endCol - startCol + 1
 	for i, row := range values {
-		rangeColumnSize := endCol - startCol + 1
 		if len(row) != rangeColumnSize {
 			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("number of columns in row %d (%d) does not match range size (%d)", i, len(row), rangeColumnSize)), nil
 		}
-		for j, cellValue := range row {
-			cell, err := excelize.CoordinatesToCellName(startCol+j, startRow+i)
-			if err != nil {
-				return nil, err
-			}
+		for _, cellValue := range row {
 			if cellStr, ok := cellValue.(string); ok && isFormula(cellStr) {
-				// if cellValue is formula, set it as formula
-				err = worksheet.SetFormula(cell, cellStr)
 				wroteFormula = true
-			} else {
-				// if cellValue is not formula, set it as value
-				err = worksheet.SetValue(cell, cellValue)
 			}
-			if err != nil {
-				return nil, err
+		}
+	}
+
+	if newSheet {
+		if err := workbook.CreateNewSheet(sheetName); err != nil {
+			return nil, err
+		}
+		if worksheet, err = workbook.FindSheet(sheetName); err != nil {
+			return nil, err
+		}
+		defer worksheet.Release()
+	}
+
+	// データの書き込み
+	if rangeRowSize*rangeColumnSize > bulkWriteCellsThreshold {
+		// large data is written at once, because writing cell by cell is slow
+		if err := worksheet.SetRangeValues(rangeStr, values); err != nil {
+			return nil, err
+		}
+	} else {
+		for i, row := range values {
+			for j, cellValue := range row {
+				cell, err := excelize.CoordinatesToCellName(startCol+j, startRow+i)
+				if err != nil {
+					return nil, err
+				}
+				if cellStr, ok := cellValue.(string); ok && isFormula(cellStr) {
+					// if cellValue is formula, set it as formula
+					err = worksheet.SetFormula(cell, cellStr)
+				} else {
+					// if cellValue is not formula, set it as value
+					err = worksheet.SetValue(cell, cellValue)
+				}
+				if err != nil {
+					return nil, err
+				}
 			}
 		}
 	}
@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWriteSheetNewSheet(t *testing.T) {
+	tests := []struct {
+		name       string
+		rangeStr   string
+		values     [][]any
+		wantError  bool
+		wantSheets []string
+	}{
+		{name: "valid values", rangeStr: "B2:C3", values: [][]any{{"a", 1}, {"b", "=C2*2"}}, wantSheets: []string{"Sheet1", "New"}},
+		{name: "workbook-scoped name", rangeStr: "Target", values: [][]any{{"a", 1}}, wantSheets: []string{"Sheet1", "New"}},
+		{name: "rows not matching the range", rangeStr: "A1:B2", values: [][]any{{"a", 1}}, wantError: true, wantSheets: []string{"Sheet1"}},
+		{name: "columns not matching the range", rangeStr: "A1:B1", values: [][]any{{"a"}}, wantError: true, wantSheets: []string{"Sheet1"}},
+		{name: "invalid range", rangeStr: "A1:", values: [][]any{{"a"}}, wantError: true, wantSheets: []string{"Sheet1"}},
+		{name: "range of another sheet", rangeStr: "Sheet1!A1", values: [][]any{{"a"}}, wantError: true, wantSheets: []string{"Sheet1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "write.xlsx")
+			file := excelize.NewFile()
+			if err := file.SetDefinedName(&excelize.DefinedName{Name: "Target", RefersTo: "New!$A$1:$B$1"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := file.SaveAs(path); err != nil {
+				t.Fatal(err)
+			}
+			file.Close()
+
+			result, err := writeSheet(path, "New", true, tt.rangeStr, tt.values)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.IsError != tt.wantError {
+				t.Errorf("writeSheet() IsError = %v, want %v: %v", result.IsError, tt.wantError, result.Content)
+			}
+
+			saved, err := excelize.OpenFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer saved.Close()
+			if got := saved.GetSheetList(); !slices.Equal(got, tt.wantSheets) {
+				t.Errorf("sheets = %v, want %v", got, tt.wantSheets)
+			}
+		})
+	}
+}